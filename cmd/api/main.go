@@ -24,6 +24,7 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -32,13 +33,28 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 
 	"github.com/tomtom2k/kairo-anchor-server/internal/config"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+	"github.com/tomtom2k/kairo-anchor-server/internal/infrastructure/eventbus"
 	"github.com/tomtom2k/kairo-anchor-server/internal/infrastructure/postgres"
+	"github.com/tomtom2k/kairo-anchor-server/internal/infrastructure/realtime"
 	"github.com/tomtom2k/kairo-anchor-server/internal/interface/http"
+	"github.com/tomtom2k/kairo-anchor-server/internal/usecase/account"
+	"github.com/tomtom2k/kairo-anchor-server/internal/usecase/admin"
+	auditUC "github.com/tomtom2k/kairo-anchor-server/internal/usecase/audit"
 	"github.com/tomtom2k/kairo-anchor-server/internal/usecase/auth"
+	"github.com/tomtom2k/kairo-anchor-server/internal/usecase/mail"
+	notificationUC "github.com/tomtom2k/kairo-anchor-server/internal/usecase/notification"
 	projectUC "github.com/tomtom2k/kairo-anchor-server/internal/usecase/project"
+	webhookUC "github.com/tomtom2k/kairo-anchor-server/internal/usecase/webhook"
+	workspaceUC "github.com/tomtom2k/kairo-anchor-server/internal/usecase/workspace"
 	"github.com/tomtom2k/kairo-anchor-server/pkg/crypto"
 	"github.com/tomtom2k/kairo-anchor-server/pkg/email"
 	"github.com/tomtom2k/kairo-anchor-server/pkg/jwt"
+	"github.com/tomtom2k/kairo-anchor-server/pkg/password"
+	"github.com/tomtom2k/kairo-anchor-server/pkg/pubsub"
+	"github.com/tomtom2k/kairo-anchor-server/pkg/scheduler"
+	"github.com/tomtom2k/kairo-anchor-server/pkg/webhook"
 
 	_ "github.com/tomtom2k/kairo-anchor-server/docs" // Import generated docs
 )
@@ -66,44 +82,255 @@ func main() {
 	// Initialize repositories
 	userRepo := postgres.NewUserRepository(db)
 	projectRepo := postgres.NewProjectRepository(db)
+	inviteCodeRepo := postgres.NewInviteCodeRepository(db)
+	magicLinkRepo := postgres.NewMagicLinkRepository(db)
+	invitationRepo := postgres.NewInvitationRepository(db)
+	workspaceRepo := postgres.NewWorkspaceRepository(db)
+	commentRepo := postgres.NewCommentRepository(db)
+	streamTicketRepo := postgres.NewStreamTicketRepository(db)
+	notificationRepo := postgres.NewNotificationRepository(db)
+	webhookRepo := postgres.NewWebhookRepository(db)
+	auditRepo := postgres.NewAuditRepository(db)
+	labelRepo := postgres.NewLabelRepository(db)
 
 	// Initialize services
 	hasher := &crypto.BcryptHasher{}
 	tokenService := jwt.NewJWTService(cfg.JWT.Secret, cfg.JWT.ExpirationHours)
 	emailService := email.NewMockEmailService(cfg.App.BaseURL)
+	notifier := notificationUC.NewDispatcher(notificationRepo,
+		notificationUC.NewInAppChannel(notificationRepo),
+		notificationUC.NewEmailChannel(emailService),
+	)
+
+	// Live project events go through Postgres so every instance sees them
+	var broker pubsub.Broker = pubsub.NewMemory()
+	if cfg.Realtime.Backend == "postgres" {
+		broker = pubsub.NewPostgres(context.Background(), db, cfg.DatabaseURL(), "kairo_project_events")
+	}
+	projectEvents := realtime.NewProjectEvents(broker)
+
+	webhookClient := webhook.NewClient(time.Duration(cfg.Webhook.TimeoutSeconds)*time.Second, cfg.Webhook.AllowPrivateTargets)
+
+	// Use cases publish domain events once their changes are saved. The audit log is written
+	// and webhook deliveries queued before the request returns; live streams, emails and
+	// notifications follow in the background.
+	events := eventbus.New()
+	defer events.Close()
+	auditUC.NewRecorder(auditRepo).Subscribe(events)
+	events.Subscribe(event.All, event.ForwardProjectEvents(webhookUC.NewPublisher(webhookRepo)))
+	events.SubscribeAsync(event.All, event.ForwardProjectEvents(projectEvents))
+	mail.NewMailer(emailService).Subscribe(events)
+	notificationUC.NewSubscriber(notifier, projectRepo, projectRepo).Subscribe(events)
+
+	// Initialize registration and password policies
+	registrationPolicy := user.RegistrationPolicy{
+		Mode:           user.RegistrationMode(cfg.Registration.Mode),
+		AllowedDomains: cfg.Registration.AllowedDomains,
+		DeniedDomains:  cfg.Registration.DeniedDomains,
+	}
+	passwordPolicy := password.Policy{
+		MinLength:     cfg.Password.MinLength,
+		RequireUpper:  cfg.Password.RequireUpper,
+		RequireLower:  cfg.Password.RequireLower,
+		RequireDigit:  cfg.Password.RequireDigit,
+		RequireSymbol: cfg.Password.RequireSymbol,
+		RejectCommon:  cfg.Password.RejectCommon,
+	}
 
 	// Initialize auth use cases
-	registerUC := auth.NewRegisterUseCase(userRepo, hasher, emailService)
-	loginUC := auth.NewLoginUseCase(userRepo, hasher, tokenService)
+	registerUC := auth.NewRegisterUseCase(userRepo, hasher, events, inviteCodeRepo,
+		invitationRepo, projectRepo, registrationPolicy, passwordPolicy)
+	loginUC := auth.NewLoginUseCase(userRepo, hasher, tokenService, events)
 	getProfileUC := auth.NewGetProfileUseCase(userRepo)
-	activateUC := auth.NewActivateAccountUseCase(userRepo)
-	forgotPasswordUC := auth.NewForgotPasswordUseCase(userRepo, emailService)
-	changePasswordUC := auth.NewChangePasswordUseCase(userRepo, hasher)
-	resetPasswordUC := auth.NewResetPasswordUseCase(userRepo, hasher)
+	activateUC := auth.NewActivateAccountUseCase(userRepo, events)
+	forgotPasswordUC := auth.NewForgotPasswordUseCase(userRepo, events)
+	changePasswordUC := auth.NewChangePasswordUseCase(userRepo, hasher, passwordPolicy, events)
+	resetPasswordUC := auth.NewResetPasswordUseCase(userRepo, hasher, passwordPolicy, events)
+	requestEmailChangeUC := auth.NewRequestEmailChangeUseCase(userRepo, hasher, events)
+	confirmEmailChangeUC := auth.NewConfirmEmailChangeUseCase(userRepo, events)
+	revertEmailChangeUC := auth.NewRevertEmailChangeUseCase(userRepo, events)
+	updateProfileUC := auth.NewUpdateProfileUseCase(userRepo)
+	requestMagicLinkUC := auth.NewRequestMagicLinkUseCase(userRepo, magicLinkRepo, events,
+		time.Duration(cfg.MagicLink.TTLMinutes)*time.Minute, cfg.MagicLink.MaxPerHour, cfg.MagicLink.MaxPerHourPerIP)
+	verifyMagicLinkUC := auth.NewVerifyMagicLinkUseCase(userRepo, magicLinkRepo, tokenService, events)
+
+	// Initialize account use cases
+	exportDataUC := account.NewExportDataUseCase(userRepo, projectRepo, commentRepo,
+		workspaceRepo, notificationRepo, webhookRepo, auditRepo)
+	scheduleDeletionUC := account.NewScheduleDeletionUseCase(userRepo, hasher, events,
+		time.Duration(cfg.Account.DeletionGraceDays)*24*time.Hour)
+	cancelDeletionUC := account.NewCancelDeletionUseCase(userRepo)
+	purgeAccountsUC := account.NewPurgeAccountsUseCase(userRepo)
+
+	// Initialize admin use cases
+	listUsersUC := admin.NewListUsersUseCase(userRepo)
+	getUserUC := admin.NewGetUserUseCase(userRepo, projectRepo)
+	setUserActiveUC := admin.NewSetUserActiveUseCase(userRepo, events)
+	banUserUC := admin.NewBanUserUseCase(userRepo)
+	forcePasswordResetUC := admin.NewForcePasswordResetUseCase(userRepo, events)
+	setUserRoleUC := admin.NewSetUserRoleUseCase(userRepo)
+	createInviteCodeUC := admin.NewCreateInviteCodeUseCase(inviteCodeRepo)
+	listInviteCodesUC := admin.NewListInviteCodesUseCase(inviteCodeRepo)
+	revokeInviteCodeUC := admin.NewRevokeInviteCodeUseCase(inviteCodeRepo)
+
+	// Initialize workspace use cases
+	resolveWorkspaceUC := workspaceUC.NewResolveWorkspaceUseCase(workspaceRepo)
+	createWorkspaceUC := workspaceUC.NewCreateWorkspaceUseCase(workspaceRepo)
+	listWorkspacesUC := workspaceUC.NewListWorkspacesUseCase(workspaceRepo)
+	updateWorkspaceUC := workspaceUC.NewUpdateWorkspaceUseCase(workspaceRepo)
+	deleteWorkspaceUC := workspaceUC.NewDeleteWorkspaceUseCase(workspaceRepo)
+	listWorkspaceMembersUC := workspaceUC.NewListMembersUseCase(workspaceRepo)
+	addWorkspaceMemberUC := workspaceUC.NewAddMemberUseCase(workspaceRepo, userRepo)
+	updateWorkspaceMemberRoleUC := workspaceUC.NewUpdateMemberRoleUseCase(workspaceRepo)
+	removeWorkspaceMemberUC := workspaceUC.NewRemoveMemberUseCase(workspaceRepo)
+	listLabelsUC := workspaceUC.NewListLabelsUseCase(workspaceRepo, labelRepo)
+	createLabelUC := workspaceUC.NewCreateLabelUseCase(workspaceRepo, labelRepo)
+	updateLabelUC := workspaceUC.NewUpdateLabelUseCase(workspaceRepo, labelRepo)
+	deleteLabelUC := workspaceUC.NewDeleteLabelUseCase(workspaceRepo, labelRepo)
 
 	// Initialize project use cases
-	createProjectUC := projectUC.NewCreateProjectUseCase(projectRepo)
-	updateProjectUC := projectUC.NewUpdateProjectUseCase(projectRepo)
-	deleteProjectUC := projectUC.NewDeleteProjectUseCase(projectRepo)
+	createProjectUC := projectUC.NewCreateProjectUseCase(projectRepo, workspaceRepo, labelRepo, events)
+	updateProjectUC := projectUC.NewUpdateProjectUseCase(projectRepo, labelRepo, events)
+	deleteProjectUC := projectUC.NewDeleteProjectUseCase(projectRepo, events)
 	getProjectUC := projectUC.NewGetProjectUseCase(projectRepo)
 	listProjectsUC := projectUC.NewListProjectsUseCase(projectRepo)
-	addTaskUC := projectUC.NewAddTaskUseCase(projectRepo)
-	updateTaskUC := projectUC.NewUpdateTaskUseCase(projectRepo)
-	deleteTaskUC := projectUC.NewDeleteTaskUseCase(projectRepo)
-	reorderTasksUC := projectUC.NewReorderTasksUseCase(projectRepo)
-	addDocumentUC := projectUC.NewAddDocumentUseCase(projectRepo)
-	updateDocumentUC := projectUC.NewUpdateDocumentUseCase(projectRepo)
-	deleteDocumentUC := projectUC.NewDeleteDocumentUseCase(projectRepo)
+	addTaskUC := projectUC.NewAddTaskUseCase(projectRepo, workspaceRepo, projectRepo, labelRepo, userRepo, events)
+	updateTaskUC := projectUC.NewUpdateTaskUseCase(projectRepo, workspaceRepo, projectRepo, labelRepo, events)
+	deleteTaskUC := projectUC.NewDeleteTaskUseCase(projectRepo, workspaceRepo, commentRepo, events)
+	reorderTasksUC := projectUC.NewReorderTasksUseCase(projectRepo, events)
+	rankTaskUC := projectUC.NewRankTaskUseCase(projectRepo, events)
+	rebalanceRanksUC := projectUC.NewRebalanceRanksUseCase(projectRepo)
+	reorderSubtasksUC := projectUC.NewReorderSubtasksUseCase(projectRepo, events)
+	addChecklistItemUC := projectUC.NewAddChecklistItemUseCase(projectRepo, events)
+	updateChecklistItemUC := projectUC.NewUpdateChecklistItemUseCase(projectRepo, events)
+	deleteChecklistItemUC := projectUC.NewDeleteChecklistItemUseCase(projectRepo, events)
+	reorderChecklistUC := projectUC.NewReorderChecklistUseCase(projectRepo, events)
+	addDependencyUC := projectUC.NewAddDependencyUseCase(projectRepo, events)
+	removeDependencyUC := projectUC.NewRemoveDependencyUseCase(projectRepo, events)
+	criticalPathUC := projectUC.NewGetCriticalPathUseCase(projectRepo)
+	editRecurringTaskUC := projectUC.NewEditRecurringTaskUseCase(projectRepo, projectRepo, userRepo, events)
+	stopRecurrenceUC := projectUC.NewStopRecurrenceUseCase(projectRepo, events)
+	updateWorkflowUC := projectUC.NewUpdateWorkflowUseCase(projectRepo, events)
+	moveTaskUC := projectUC.NewMoveTaskUseCase(projectRepo, workspaceRepo, events)
+	updateProgressSettingsUC := projectUC.NewUpdateProgressSettingsUseCase(projectRepo, events)
+	addDocumentUC := projectUC.NewAddDocumentUseCase(projectRepo, events)
+	updateDocumentUC := projectUC.NewUpdateDocumentUseCase(projectRepo, events)
+	deleteDocumentUC := projectUC.NewDeleteDocumentUseCase(projectRepo, events)
+	listMyTasksUC := projectUC.NewListMyTasksUseCase(projectRepo)
+	listProjectLabelsUC := projectUC.NewListProjectLabelsUseCase(projectRepo, labelRepo)
+	searchUC := projectUC.NewSearchUseCase(projectRepo)
+	streamEventsUC := projectUC.NewStreamEventsUseCase(projectRepo, projectRepo, userRepo, projectEvents)
+	issueStreamTicketUC := projectUC.NewIssueStreamTicketUseCase(projectRepo, streamTicketRepo)
+	redeemStreamTicketUC := projectUC.NewRedeemStreamTicketUseCase(streamTicketRepo)
+	listMembersUC := projectUC.NewListMembersUseCase(projectRepo, projectRepo)
+	addMemberUC := projectUC.NewAddMemberUseCase(projectRepo, projectRepo, userRepo, events)
+	updateMemberRoleUC := projectUC.NewUpdateMemberRoleUseCase(projectRepo, projectRepo, events)
+	removeMemberUC := projectUC.NewRemoveMemberUseCase(projectRepo, projectRepo, events)
+	inviteMemberUC := projectUC.NewInviteMemberUseCase(projectRepo, projectRepo, invitationRepo, userRepo, events,
+		time.Duration(cfg.Invitation.TTLHours)*time.Hour)
+	listInvitationsUC := projectUC.NewListInvitationsUseCase(projectRepo, invitationRepo)
+	acceptInvitationUC := projectUC.NewAcceptInvitationUseCase(invitationRepo, projectRepo, userRepo)
+	declineInvitationUC := projectUC.NewDeclineInvitationUseCase(invitationRepo)
+	listCommentsUC := projectUC.NewListCommentsUseCase(projectRepo, commentRepo)
+	addCommentUC := projectUC.NewAddCommentUseCase(projectRepo, commentRepo, projectRepo, events)
+	editCommentUC := projectUC.NewEditCommentUseCase(projectRepo, commentRepo, projectRepo, events)
+	deleteCommentUC := projectUC.NewDeleteCommentUseCase(projectRepo, commentRepo)
+	commentHistoryUC := projectUC.NewListCommentHistoryUseCase(projectRepo, commentRepo)
+	listRevisionsUC := projectUC.NewListRevisionsUseCase(projectRepo, projectRepo)
+	getRevisionUC := projectUC.NewGetRevisionUseCase(projectRepo, projectRepo)
+	diffRevisionsUC := projectUC.NewDiffRevisionsUseCase(projectRepo, projectRepo)
+	restoreRevisionUC := projectUC.NewRestoreRevisionUseCase(projectRepo, projectRepo, projectRepo, labelRepo, events)
+	compactRevisionsUC := projectUC.NewCompactRevisionsUseCase(projectRepo,
+		time.Duration(cfg.Revision.KeepAllHours)*time.Hour, time.Duration(cfg.Revision.KeepHourlyDays)*24*time.Hour)
+
+	// Initialize notification use cases
+	listNotificationsUC := notificationUC.NewListNotificationsUseCase(notificationRepo)
+	countUnreadUC := notificationUC.NewCountUnreadUseCase(notificationRepo)
+	markReadUC := notificationUC.NewMarkReadUseCase(notificationRepo)
+	markAllReadUC := notificationUC.NewMarkAllReadUseCase(notificationRepo)
+	getNotificationPreferencesUC := notificationUC.NewGetPreferencesUseCase(notificationRepo)
+	updateNotificationPreferencesUC := notificationUC.NewUpdatePreferencesUseCase(notificationRepo)
+	notifyDueSoonUC := notificationUC.NewNotifyDueSoonUseCase(projectRepo, projectRepo, notificationRepo, notifier,
+		time.Duration(cfg.Notification.DueSoonHours)*time.Hour)
+
+	// Initialize webhook use cases
+	createWebhookUC := webhookUC.NewCreateWebhookUseCase(webhookRepo, projectRepo, webhookClient)
+	listWebhooksUC := webhookUC.NewListWebhooksUseCase(webhookRepo)
+	getWebhookUC := webhookUC.NewGetWebhookUseCase(webhookRepo)
+	updateWebhookUC := webhookUC.NewUpdateWebhookUseCase(webhookRepo, webhookClient)
+	deleteWebhookUC := webhookUC.NewDeleteWebhookUseCase(webhookRepo)
+	listDeliveriesUC := webhookUC.NewListDeliveriesUseCase(webhookRepo)
+	redeliverUC := webhookUC.NewRedeliverUseCase(webhookRepo)
+	deliverWebhooksUC := webhookUC.NewDeliverWebhooksUseCase(webhookRepo, webhookClient,
+		cfg.Webhook.MaxAttempts, cfg.Webhook.DisableAfterFailures)
+
+	// Initialize audit use cases
+	listActivityUC := auditUC.NewListActivityUseCase(projectRepo, auditRepo)
+	listSecurityEventsUC := auditUC.NewListSecurityEventsUseCase(auditRepo)
 
 	// Initialize HTTP handlers
-	authHandler := http.NewHandler(registerUC, loginUC, getProfileUC, activateUC, forgotPasswordUC, changePasswordUC, resetPasswordUC)
+	authHandler := http.NewHandler(
+		registerUC, loginUC, getProfileUC, activateUC, forgotPasswordUC, changePasswordUC, resetPasswordUC,
+		requestEmailChangeUC, confirmEmailChangeUC, revertEmailChangeUC, updateProfileUC,
+		requestMagicLinkUC, verifyMagicLinkUC,
+	)
+	accountHandler := http.NewAccountHandler(exportDataUC, scheduleDeletionUC, cancelDeletionUC)
+	adminHandler := http.NewAdminHandler(
+		listUsersUC, getUserUC, setUserActiveUC, banUserUC, forcePasswordResetUC, setUserRoleUC,
+		createInviteCodeUC, listInviteCodesUC, revokeInviteCodeUC,
+	)
 	projectHandler := http.NewProjectHandler(
 		createProjectUC, updateProjectUC, deleteProjectUC, getProjectUC, listProjectsUC,
-		addTaskUC, updateTaskUC, deleteTaskUC, reorderTasksUC,
+		addTaskUC, updateTaskUC, deleteTaskUC, reorderTasksUC, rankTaskUC, reorderSubtasksUC,
 		addDocumentUC, updateDocumentUC, deleteDocumentUC,
+		listMyTasksUC,
+	)
+	projectEventsHandler := http.NewProjectEventsHandler(streamEventsUC, issueStreamTicketUC)
+	memberHandler := http.NewMemberHandler(listMembersUC, addMemberUC, updateMemberRoleUC, removeMemberUC)
+	checklistHandler := http.NewChecklistHandler(addChecklistItemUC, updateChecklistItemUC, deleteChecklistItemUC, reorderChecklistUC)
+	dependencyHandler := http.NewDependencyHandler(addDependencyUC, removeDependencyUC, criticalPathUC)
+	recurrenceHandler := http.NewRecurrenceHandler(editRecurringTaskUC, stopRecurrenceUC)
+	workflowHandler := http.NewWorkflowHandler(updateWorkflowUC)
+	boardHandler := http.NewBoardHandler(getProjectUC, moveTaskUC)
+	progressHandler := http.NewProgressHandler(updateProgressSettingsUC)
+	revisionHandler := http.NewRevisionHandler(listRevisionsUC, getRevisionUC, diffRevisionsUC, restoreRevisionUC)
+	commentHandler := http.NewCommentHandler(listCommentsUC, addCommentUC, editCommentUC, deleteCommentUC, commentHistoryUC)
+	notificationHandler := http.NewNotificationHandler(
+		listNotificationsUC, countUnreadUC, markReadUC, markAllReadUC,
+		getNotificationPreferencesUC, updateNotificationPreferencesUC,
 	)
+	auditHandler := http.NewAuditHandler(listActivityUC, listSecurityEventsUC)
+	webhookHandler := http.NewWebhookHandler(
+		createWebhookUC, listWebhooksUC, getWebhookUC, updateWebhookUC, deleteWebhookUC,
+		listDeliveriesUC, redeliverUC,
+	)
+	invitationHandler := http.NewInvitationHandler(inviteMemberUC, listInvitationsUC, acceptInvitationUC, declineInvitationUC)
+	workspaceHandler := http.NewWorkspaceHandler(
+		createWorkspaceUC, listWorkspacesUC, resolveWorkspaceUC, updateWorkspaceUC, deleteWorkspaceUC,
+		listWorkspaceMembersUC, addWorkspaceMemberUC, updateWorkspaceMemberRoleUC, removeWorkspaceMemberUC,
+	)
+	labelHandler := http.NewLabelHandler(listLabelsUC, createLabelUC, updateLabelUC, deleteLabelUC, listProjectLabelsUC, searchUC)
 
-	authMiddleware := http.NewAuthMiddleware(tokenService)
+	authMiddleware := http.NewAuthMiddleware(tokenService, userRepo)
+	workspaceMiddleware := http.NewWorkspaceMiddleware(resolveWorkspaceUC)
+
+	// Background jobs
+	jobs := []struct {
+		name     string
+		interval time.Duration
+		run      func(ctx context.Context) error
+	}{
+		{"purge-accounts", time.Duration(cfg.Account.PurgeIntervalMinutes) * time.Minute, purgeAccountsUC.Execute},
+		{"notify-due-soon", time.Duration(cfg.Notification.DueSoonIntervalMinutes) * time.Minute, notifyDueSoonUC.Execute},
+		{"deliver-webhooks", time.Duration(cfg.Webhook.WorkerIntervalSeconds) * time.Second, deliverWebhooksUC.Execute},
+		{"compact-revisions", time.Duration(cfg.Revision.CompactIntervalMinutes) * time.Minute, compactRevisionsUC.Execute},
+		{"rebalance-task-ranks", time.Duration(cfg.Project.RankRebalanceIntervalMinutes) * time.Minute, rebalanceRanksUC.Execute},
+	}
+	for _, job := range jobs {
+		if err := scheduler.Every(context.Background(), job.name, job.interval, job.run); err != nil {
+			log.Fatal("Failed to start background job:", err)
+		}
+	}
 
 	// Setup Gin router
 	r := gin.Default()
@@ -112,12 +339,13 @@ func main() {
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:3000", "http://localhost:3001"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", http.WorkspaceHeader, http.RequestIDHeader},
+		ExposeHeaders:    []string{"Content-Length", http.RequestIDHeader},
 		AllowCredentials: true,
 	}))
 
-	// Add recovery middleware to catch panics
+	// Tag every request with an ID and add recovery middleware to catch panics
+	r.Use(http.RequestID())
 	r.Use(http.Recovery())
 
 	// Swagger documentation
@@ -135,29 +363,153 @@ func main() {
 			authGroup.POST("/activate", authHandler.ActivateAccount)
 			authGroup.POST("/forgot-password", authHandler.ForgotPassword)
 			authGroup.POST("/change-password", authHandler.ChangePassword)
+			authGroup.POST("/email/confirm", authHandler.ConfirmEmailChange)
+			authGroup.POST("/email/revert", authHandler.RevertEmailChange)
+			authGroup.POST("/magic-link", authHandler.RequestMagicLink)
+			authGroup.POST("/magic-link/verify", authHandler.VerifyMagicLink)
 
 			// Protected routes
 			authGroup.GET("/profile", authMiddleware.RequireAuth(), authHandler.GetProfile)
+			authGroup.PATCH("/profile", authMiddleware.RequireAuth(), authHandler.UpdateProfile)
 			authGroup.POST("/reset-password", authMiddleware.RequireAuth(), authHandler.ResetPassword)
+			authGroup.POST("/email/change", authMiddleware.RequireAuth(), authHandler.RequestEmailChange)
+			authGroup.GET("/me/export", authMiddleware.RequireAuth(), accountHandler.ExportData)
+			authGroup.GET("/me/security-log", authMiddleware.RequireAuth(), auditHandler.ListSecurityEvents)
+			authGroup.DELETE("/me", authMiddleware.RequireAuth(), accountHandler.DeleteAccount)
+			authGroup.POST("/me/cancel-deletion", authMiddleware.RequireAuth(), accountHandler.CancelDeletion)
 		}
 
 		// Project routes (all protected)
-		projectGroup := api.Group("/projects", authMiddleware.RequireAuth())
+		projectGroup := api.Group("/projects", authMiddleware.RequireAuth(), workspaceMiddleware.RequireWorkspace())
 		{
 			projectGroup.POST("", projectHandler.CreateProject)
 			projectGroup.GET("", projectHandler.ListProjects)
 			projectGroup.GET("/:id", projectHandler.GetProject)
 			projectGroup.PUT("/:id", projectHandler.UpdateProject)
 			projectGroup.DELETE("/:id", projectHandler.DeleteProject)
+			projectGroup.GET("/:id/activity", auditHandler.ListActivity)
+			projectGroup.GET("/:id/labels", labelHandler.ListProjectLabels)
+			projectGroup.PUT("/:id/workflow", workflowHandler.UpdateWorkflow)
+			projectGroup.GET("/:id/board", boardHandler.GetBoard)
+			projectGroup.PUT("/:id/progress", progressHandler.UpdateProgressSettings)
+			projectGroup.GET("/:id/revisions", revisionHandler.ListRevisions)
+			projectGroup.GET("/:id/revisions/diff", revisionHandler.DiffRevisions)
+			projectGroup.GET("/:id/revisions/:rev", revisionHandler.GetRevision)
+			projectGroup.POST("/:id/revisions/:rev/restore", revisionHandler.RestoreRevision)
 			// Task API
 			projectGroup.POST("/:id/tasks", projectHandler.AddTask)
 			projectGroup.PUT("/:id/tasks/order", projectHandler.ReorderTasks)
 			projectGroup.PUT("/:id/tasks/:taskId", projectHandler.UpdateTask)
+			projectGroup.PUT("/:id/tasks/:taskId/rank", projectHandler.RankTask)
 			projectGroup.DELETE("/:id/tasks/:taskId", projectHandler.DeleteTask)
+			projectGroup.PUT("/:id/tasks/:taskId/subtasks/order", projectHandler.ReorderSubtasks)
+			projectGroup.POST("/:id/tasks/:taskId/move", boardHandler.MoveTask)
+			projectGroup.POST("/:id/tasks/:taskId/checklist", checklistHandler.AddChecklistItem)
+			projectGroup.PUT("/:id/tasks/:taskId/checklist/order", checklistHandler.ReorderChecklist)
+			projectGroup.PUT("/:id/tasks/:taskId/checklist/:itemId", checklistHandler.UpdateChecklistItem)
+			projectGroup.DELETE("/:id/tasks/:taskId/checklist/:itemId", checklistHandler.DeleteChecklistItem)
+			projectGroup.POST("/:id/tasks/:taskId/dependencies", dependencyHandler.AddDependency)
+			projectGroup.DELETE("/:id/tasks/:taskId/dependencies/:dependsOn", dependencyHandler.RemoveDependency)
+			projectGroup.GET("/:id/critical-path", dependencyHandler.CriticalPath)
+			projectGroup.PUT("/:id/tasks/:taskId/occurrence", recurrenceHandler.UpdateOccurrence)
+			projectGroup.PUT("/:id/tasks/:taskId/series", recurrenceHandler.UpdateSeries)
+			projectGroup.DELETE("/:id/tasks/:taskId/recurrence", recurrenceHandler.StopRecurrence)
+			projectGroup.GET("/:id/tasks/:taskId/comments", commentHandler.ListComments)
+			projectGroup.POST("/:id/tasks/:taskId/comments", commentHandler.AddComment)
+			projectGroup.PUT("/:id/tasks/:taskId/comments/:commentId", commentHandler.UpdateComment)
+			projectGroup.DELETE("/:id/tasks/:taskId/comments/:commentId", commentHandler.DeleteComment)
+			projectGroup.GET("/:id/tasks/:taskId/comments/:commentId/history", commentHandler.CommentHistory)
 			// Document API
 			projectGroup.POST("/:id/documents", projectHandler.AddDocument)
 			projectGroup.PUT("/:id/documents/:docId", projectHandler.UpdateDocument)
 			projectGroup.DELETE("/:id/documents/:docId", projectHandler.DeleteDocument)
+			// Ticket for the event stream below
+			projectGroup.POST("/:id/events/ticket", projectEventsHandler.IssueStreamTicket)
+			// Member API
+			projectGroup.GET("/:id/members", memberHandler.ListMembers)
+			projectGroup.POST("/:id/members", memberHandler.AddMember)
+			projectGroup.PUT("/:id/members/:userId", memberHandler.UpdateMemberRole)
+			projectGroup.DELETE("/:id/members/:userId", memberHandler.RemoveMember)
+			// Invitation API
+			projectGroup.POST("/:id/invitations", invitationHandler.InviteMember)
+			projectGroup.GET("/:id/invitations", invitationHandler.ListInvitations)
+		}
+
+		// Live project events; EventSource clients cannot send headers, so this sits outside the
+		// project group and accepts a single-use stream ticket in the query string
+		api.GET("/projects/:id/events", authMiddleware.RequireStreamAuth(redeemStreamTicketUC), projectEventsHandler.StreamEvents)
+
+		// Routes about the signed-in user across projects
+		meGroup := api.Group("/me", authMiddleware.RequireAuth())
+		{
+			meGroup.GET("/tasks", projectHandler.ListMyTasks)
+		}
+
+		// Search across the projects of the current workspace
+		api.GET("/search", authMiddleware.RequireAuth(), workspaceMiddleware.RequireWorkspace(), labelHandler.Search)
+
+		// Workspace routes (all protected)
+		workspaceGroup := api.Group("/workspaces", authMiddleware.RequireAuth())
+		{
+			workspaceGroup.GET("", workspaceHandler.ListWorkspaces)
+			workspaceGroup.POST("", workspaceHandler.CreateWorkspace)
+			workspaceGroup.GET("/:id", workspaceHandler.GetWorkspace)
+			workspaceGroup.PATCH("/:id", workspaceHandler.UpdateWorkspace)
+			workspaceGroup.DELETE("/:id", workspaceHandler.DeleteWorkspace)
+			workspaceGroup.GET("/:id/members", workspaceHandler.ListMembers)
+			workspaceGroup.POST("/:id/members", workspaceHandler.AddMember)
+			workspaceGroup.PUT("/:id/members/:userId", workspaceHandler.UpdateMemberRole)
+			workspaceGroup.DELETE("/:id/members/:userId", workspaceHandler.RemoveMember)
+			workspaceGroup.GET("/:id/labels", labelHandler.ListLabels)
+			workspaceGroup.POST("/:id/labels", labelHandler.CreateLabel)
+			workspaceGroup.PUT("/:id/labels/:labelId", labelHandler.UpdateLabel)
+			workspaceGroup.DELETE("/:id/labels/:labelId", labelHandler.DeleteLabel)
+		}
+
+		// Invitation routes (declining only needs the emailed token)
+		invitationGroup := api.Group("/invitations")
+		{
+			invitationGroup.POST("/accept", authMiddleware.RequireAuth(), invitationHandler.AcceptInvitation)
+			invitationGroup.POST("/decline", invitationHandler.DeclineInvitation)
+		}
+
+		// Notification routes
+		notificationGroup := api.Group("/notifications", authMiddleware.RequireAuth())
+		{
+			notificationGroup.GET("", notificationHandler.ListNotifications)
+			notificationGroup.GET("/unread-count", notificationHandler.UnreadCount)
+			notificationGroup.POST("/read-all", notificationHandler.MarkAllRead)
+			notificationGroup.POST("/:id/read", notificationHandler.MarkRead)
+			notificationGroup.GET("/preferences", notificationHandler.GetPreferences)
+			notificationGroup.PUT("/preferences", notificationHandler.UpdatePreferences)
+		}
+
+		// Webhook routes
+		webhookGroup := api.Group("/webhooks", authMiddleware.RequireAuth())
+		{
+			webhookGroup.POST("", webhookHandler.CreateWebhook)
+			webhookGroup.GET("", webhookHandler.ListWebhooks)
+			webhookGroup.GET("/:id", webhookHandler.GetWebhook)
+			webhookGroup.PUT("/:id", webhookHandler.UpdateWebhook)
+			webhookGroup.DELETE("/:id", webhookHandler.DeleteWebhook)
+			webhookGroup.GET("/:id/deliveries", webhookHandler.ListDeliveries)
+			webhookGroup.POST("/:id/deliveries/:deliveryId/redeliver", webhookHandler.Redeliver)
+		}
+
+		// Admin routes
+		adminGroup := api.Group("/admin", authMiddleware.RequireAuth(), authMiddleware.RequireRole(user.RoleAdmin))
+		{
+			adminGroup.GET("/users", adminHandler.ListUsers)
+			adminGroup.GET("/users/:id", adminHandler.GetUser)
+			adminGroup.POST("/users/:id/activate", adminHandler.ActivateUser)
+			adminGroup.POST("/users/:id/deactivate", adminHandler.DeactivateUser)
+			adminGroup.POST("/users/:id/ban", adminHandler.BanUser)
+			adminGroup.POST("/users/:id/unban", adminHandler.UnbanUser)
+			adminGroup.POST("/users/:id/force-password-reset", adminHandler.ForcePasswordReset)
+			adminGroup.PUT("/users/:id/role", adminHandler.SetUserRole)
+			adminGroup.POST("/invite-codes", adminHandler.CreateInviteCode)
+			adminGroup.GET("/invite-codes", adminHandler.ListInviteCodes)
+			adminGroup.DELETE("/invite-codes/:id", adminHandler.RevokeInviteCode)
 		}
 	}
 
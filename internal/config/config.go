@@ -4,15 +4,26 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Database DatabaseConfig
-	JWT      JWTConfig
-	Server   ServerConfig
-	App      AppConfig
+	Database     DatabaseConfig
+	JWT          JWTConfig
+	Server       ServerConfig
+	App          AppConfig
+	Account      AccountConfig
+	Registration RegistrationConfig
+	Password     PasswordConfig
+	MagicLink    MagicLinkConfig
+	Invitation   InvitationConfig
+	Notification NotificationConfig
+	Realtime     RealtimeConfig
+	Webhook      WebhookConfig
+	Revision     RevisionConfig
+	Project      ProjectConfig
 }
 
 type DatabaseConfig struct {
@@ -37,6 +48,63 @@ type AppConfig struct {
 	BaseURL string
 }
 
+type RegistrationConfig struct {
+	Mode           string // open, invite_only or closed
+	AllowedDomains []string
+	DeniedDomains  []string
+}
+
+type PasswordConfig struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	RejectCommon  bool
+}
+
+type MagicLinkConfig struct {
+	TTLMinutes      int
+	MaxPerHour      int // per email address
+	MaxPerHourPerIP int
+}
+
+type InvitationConfig struct {
+	TTLHours int
+}
+
+type NotificationConfig struct {
+	DueSoonHours           int // how long before the due date assignees are reminded
+	DueSoonIntervalMinutes int
+}
+
+type RealtimeConfig struct {
+	Backend string // memory for a single instance, postgres to share events across instances
+}
+
+type WebhookConfig struct {
+	MaxAttempts           int // attempts per delivery before it is marked failed
+	DisableAfterFailures  int // consecutive failed attempts before a webhook is disabled
+	WorkerIntervalSeconds int
+	TimeoutSeconds        int
+	AllowPrivateTargets   bool // allow URLs on private networks, for local development
+}
+
+type RevisionConfig struct {
+	KeepAllHours           int // every revision is kept this long
+	KeepHourlyDays         int // then one per hour until this age, and one per day after that
+	CompactIntervalMinutes int
+}
+
+type ProjectConfig struct {
+	RankRebalanceIntervalMinutes int // how often task ranks that grew too long are spread out again
+}
+
+type AccountConfig struct {
+	DeletionGraceDays    int
+	PurgeIntervalMinutes int
+}
+
 func Load() (*Config, error) {
 	// Load .env file if it exists (ignore error if not found)
 	_ = godotenv.Load()
@@ -60,6 +128,82 @@ func Load() (*Config, error) {
 		App: AppConfig{
 			BaseURL: getEnv("APP_BASE_URL", "http://localhost:8080"),
 		},
+		Account: AccountConfig{
+			DeletionGraceDays:    getEnvAsInt("ACCOUNT_DELETION_GRACE_DAYS", 14),
+			PurgeIntervalMinutes: getEnvAsInt("ACCOUNT_PURGE_INTERVAL_MINUTES", 60),
+		},
+		Registration: RegistrationConfig{
+			Mode:           getEnv("REGISTRATION_MODE", "open"),
+			AllowedDomains: getEnvAsList("REGISTRATION_ALLOWED_DOMAINS"),
+			DeniedDomains:  getEnvAsList("REGISTRATION_DENIED_DOMAINS"),
+		},
+		Password: PasswordConfig{
+			MinLength:     getEnvAsInt("PASSWORD_MIN_LENGTH", 8),
+			RequireUpper:  getEnvAsBool("PASSWORD_REQUIRE_UPPER", true),
+			RequireLower:  getEnvAsBool("PASSWORD_REQUIRE_LOWER", true),
+			RequireDigit:  getEnvAsBool("PASSWORD_REQUIRE_DIGIT", true),
+			RequireSymbol: getEnvAsBool("PASSWORD_REQUIRE_SYMBOL", false),
+			RejectCommon:  getEnvAsBool("PASSWORD_REJECT_COMMON", true),
+		},
+		MagicLink: MagicLinkConfig{
+			TTLMinutes:      getEnvAsInt("MAGIC_LINK_TTL_MINUTES", 15),
+			MaxPerHour:      getEnvAsInt("MAGIC_LINK_MAX_PER_HOUR", 5),
+			MaxPerHourPerIP: getEnvAsInt("MAGIC_LINK_MAX_PER_HOUR_PER_IP", 20),
+		},
+		Invitation: InvitationConfig{
+			TTLHours: getEnvAsInt("PROJECT_INVITATION_TTL_HOURS", 7*24),
+		},
+		Notification: NotificationConfig{
+			DueSoonHours:           getEnvAsInt("NOTIFICATION_DUE_SOON_HOURS", 24),
+			DueSoonIntervalMinutes: getEnvAsInt("NOTIFICATION_DUE_SOON_INTERVAL_MINUTES", 15),
+		},
+		Realtime: RealtimeConfig{
+			Backend: getEnv("REALTIME_BACKEND", "postgres"),
+		},
+		Webhook: WebhookConfig{
+			MaxAttempts:           getEnvAsInt("WEBHOOK_MAX_ATTEMPTS", 8),
+			DisableAfterFailures:  getEnvAsInt("WEBHOOK_DISABLE_AFTER_FAILURES", 20),
+			WorkerIntervalSeconds: getEnvAsInt("WEBHOOK_WORKER_INTERVAL_SECONDS", 10),
+			TimeoutSeconds:        getEnvAsInt("WEBHOOK_TIMEOUT_SECONDS", 10),
+			AllowPrivateTargets:   getEnvAsBool("WEBHOOK_ALLOW_PRIVATE_TARGETS", false),
+		},
+		Revision: RevisionConfig{
+			KeepAllHours:           getEnvAsInt("REVISION_KEEP_ALL_HOURS", 24),
+			KeepHourlyDays:         getEnvAsInt("REVISION_KEEP_HOURLY_DAYS", 30),
+			CompactIntervalMinutes: getEnvAsInt("REVISION_COMPACT_INTERVAL_MINUTES", 60),
+		},
+		Project: ProjectConfig{
+			RankRebalanceIntervalMinutes: getEnvAsInt("PROJECT_RANK_REBALANCE_INTERVAL_MINUTES", 60),
+		},
+	}
+
+	switch cfg.Registration.Mode {
+	case "open", "invite_only", "closed":
+	default:
+		return nil, fmt.Errorf("invalid REGISTRATION_MODE %q, expected open, invite_only or closed", cfg.Registration.Mode)
+	}
+
+	switch cfg.Realtime.Backend {
+	case "memory", "postgres":
+	default:
+		return nil, fmt.Errorf("invalid REALTIME_BACKEND %q, expected memory or postgres", cfg.Realtime.Backend)
+	}
+
+	// Background job intervals, a ticker cannot run at zero or negative intervals
+	intervals := []struct {
+		key   string
+		value int
+	}{
+		{"ACCOUNT_PURGE_INTERVAL_MINUTES", cfg.Account.PurgeIntervalMinutes},
+		{"NOTIFICATION_DUE_SOON_INTERVAL_MINUTES", cfg.Notification.DueSoonIntervalMinutes},
+		{"WEBHOOK_WORKER_INTERVAL_SECONDS", cfg.Webhook.WorkerIntervalSeconds},
+		{"REVISION_COMPACT_INTERVAL_MINUTES", cfg.Revision.CompactIntervalMinutes},
+		{"PROJECT_RANK_REBALANCE_INTERVAL_MINUTES", cfg.Project.RankRebalanceIntervalMinutes},
+	}
+	for _, interval := range intervals {
+		if interval.value <= 0 {
+			return nil, fmt.Errorf("invalid %s %d, expected a positive number", interval.key, interval.value)
+		}
 	}
 
 	return cfg, nil
@@ -91,3 +235,22 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	valueStr := getEnv(key, "")
+	if value, err := strconv.ParseBool(valueStr); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+// getEnvAsList reads a comma-separated list, ignoring empty items
+func getEnvAsList(key string) []string {
+	var list []string
+	for _, item := range strings.Split(getEnv(key, ""), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,71 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EntityType is the kind of thing an activity changed
+type EntityType string
+
+const (
+	EntityProject  EntityType = "project"
+	EntityTask     EntityType = "task"
+	EntityDocument EntityType = "document"
+)
+
+func (t EntityType) IsValid() bool {
+	switch t {
+	case EntityProject, EntityTask, EntityDocument:
+		return true
+	}
+	return false
+}
+
+// Change is one field's value before and after an activity; Before is empty for
+// things that were created and After for things that were deleted
+type Change struct {
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+}
+
+// Activity is an entry in a project's activity log. Entries are never changed or removed,
+// and outlive the project and the actor's account.
+type Activity struct {
+	ID         uuid.UUID
+	ProjectID  uuid.UUID
+	ActorID    *uuid.UUID
+	ActorName  string
+	Action     string // the domain event name, e.g. task.updated
+	EntityType EntityType
+	EntityID   string
+	Changes    map[string]Change
+	RequestID  string
+	CreatedAt  time.Time
+}
+
+// ActivityFilter narrows a project's activity log; zero values match everything.
+// From is inclusive and To exclusive.
+type ActivityFilter struct {
+	ActorID    *uuid.UUID
+	EntityType EntityType
+	EntityID   string
+	Action     string
+	From       *time.Time
+	To         *time.Time
+}
+
+// SecurityEvent is an entry in an account's security log: sign-ins, password changes and
+// the tokens issued for the account
+type SecurityEvent struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Event     string
+	Details   map[string]string
+	IP        string
+	UserAgent string
+	RequestID string
+	CreatedAt time.Time
+}
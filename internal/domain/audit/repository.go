@@ -0,0 +1,18 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	AppendActivity(ctx context.Context, a *Activity) error
+	// ListActivity returns a page of a project's activity, newest first, and the total matching
+	ListActivity(ctx context.Context, projectID uuid.UUID, filter ActivityFilter, offset, limit int) ([]Activity, int, error)
+	// ListActivityByActor returns a page of everything the user did, across every project
+	// including deleted ones, newest first, and the total
+	ListActivityByActor(ctx context.Context, actorID uuid.UUID, offset, limit int) ([]Activity, int, error)
+	AppendSecurityEvent(ctx context.Context, e *SecurityEvent) error
+	ListSecurityEvents(ctx context.Context, userID uuid.UUID, offset, limit int) ([]SecurityEvent, int, error)
+}
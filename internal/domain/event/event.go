@@ -0,0 +1,51 @@
+// Package event defines the domain events use cases publish once a change is committed,
+// and the bus that hands them to the rest of the system.
+package event
+
+import "context"
+
+// All subscribes a handler to every event
+const All = "*"
+
+// Event is something that happened. Events are values; subscribers must not change them.
+type Event interface {
+	EventName() string
+}
+
+// Handler reacts to an event. Errors are logged and never reach the publisher.
+type Handler func(ctx context.Context, e Event) error
+
+// Publisher is what use cases publish events through
+type Publisher interface {
+	Publish(ctx context.Context, events ...Event)
+}
+
+// Bus delivers published events to subscribers. Synchronous subscribers run inside Publish,
+// in the order they subscribed, so their work is done before the use case returns.
+// Asynchronous subscribers each get events in publish order on their own goroutine.
+type Bus interface {
+	Publisher
+	Subscribe(name string, h Handler)
+	SubscribeAsync(name string, h Handler)
+}
+
+// On subscribes a typed handler to events of type T
+func On[T Event](bus Bus, h func(ctx context.Context, e T) error) {
+	var zero T
+	bus.Subscribe(zero.EventName(), typed(h))
+}
+
+// OnAsync subscribes a typed handler to events of type T, run in the background
+func OnAsync[T Event](bus Bus, h func(ctx context.Context, e T) error) {
+	var zero T
+	bus.SubscribeAsync(zero.EventName(), typed(h))
+}
+
+func typed[T Event](h func(ctx context.Context, e T) error) Handler {
+	return func(ctx context.Context, e Event) error {
+		if t, ok := e.(T); ok {
+			return h(ctx, t)
+		}
+		return nil
+	}
+}
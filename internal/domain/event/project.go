@@ -0,0 +1,287 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+)
+
+// ProjectEvent is implemented by the events project members can follow live and through webhooks
+type ProjectEvent interface {
+	Event
+	// ProjectEvent returns the event as members see it
+	ProjectEvent() project.Event
+}
+
+// ForwardProjectEvents hands project events to p and ignores everything else
+func ForwardProjectEvents(p project.EventPublisher) Handler {
+	return func(ctx context.Context, e Event) error {
+		if pe, ok := e.(ProjectEvent); ok {
+			p.Publish(ctx, pe.ProjectEvent())
+		}
+		return nil
+	}
+}
+
+// ProjectChange says who changed which project and when
+type ProjectChange struct {
+	ProjectID  uuid.UUID
+	ActorID    string
+	OccurredAt time.Time
+}
+
+// ChangeBy starts a change to a project made now by actorID
+func ChangeBy(projectID, actorID uuid.UUID) ProjectChange {
+	return ProjectChange{ProjectID: projectID, ActorID: actorID.String(), OccurredAt: time.Now()}
+}
+
+func (c ProjectChange) toProjectEvent(t project.EventType, data any) project.Event {
+	e := project.Event{Type: t, ProjectID: c.ProjectID, ActorID: c.ActorID, OccurredAt: c.OccurredAt}
+	if data != nil {
+		if raw, err := json.Marshal(data); err == nil {
+			e.Data = raw
+		}
+	}
+	return e
+}
+
+// ProjectSummary is a project without its tasks and documents, which have their own events
+type ProjectSummary struct {
+	ID               uuid.UUID                `json:"id"`
+	WorkspaceID      uuid.UUID                `json:"workspaceId"`
+	Name             string                   `json:"name"`
+	Description      string                   `json:"description"`
+	Status           project.ProjectStatus    `json:"status"`
+	Progress         int                      `json:"progress"`
+	StartDate        time.Time                `json:"startDate"`
+	EndDate          *time.Time               `json:"endDate,omitempty"`
+	ProgressSettings project.ProgressSettings `json:"progressSettings"`
+}
+
+func SummarizeProject(p *project.Project) ProjectSummary {
+	return ProjectSummary{
+		ID:               p.ID,
+		WorkspaceID:      p.WorkspaceID,
+		Name:             p.Name,
+		Description:      p.Description,
+		Status:           p.Status,
+		Progress:         p.Progress,
+		StartDate:        p.StartDate,
+		EndDate:          p.EndDate,
+		ProgressSettings: p.ProgressSettings,
+	}
+}
+
+type ProjectCreated struct {
+	ProjectChange
+	Project ProjectSummary
+}
+
+type ProjectUpdated struct {
+	ProjectChange
+	Project  ProjectSummary
+	Previous ProjectSummary
+}
+
+type ProjectDeleted struct {
+	ProjectChange
+	Project ProjectSummary
+}
+
+// ProjectRestored is published when a project is rolled back to an earlier revision.
+// The restore is saved as a new revision, Revision.
+type ProjectRestored struct {
+	ProjectChange
+	RestoredRevision int
+	Revision         int
+	Snapshot         project.Snapshot
+	Previous         project.Snapshot
+}
+
+type TaskAdded struct {
+	ProjectChange
+	Task       project.Task
+	Occurrence bool // the next occurrence of a recurring task, added when the last one was done
+}
+
+type TaskUpdated struct {
+	ProjectChange
+	Task     project.Task
+	Previous project.Task
+}
+
+// TaskCompleted is published alongside TaskUpdated when a task moves to completed
+type TaskCompleted struct {
+	ProjectChange
+	Task project.Task
+}
+
+type TaskDeleted struct {
+	ProjectChange
+	Task project.Task
+}
+
+type TasksReordered struct {
+	ProjectChange
+	TaskIDs  []string
+	Previous []string
+}
+
+// WorkflowUpdated is published when a project's statuses or transitions change. Tasks moved
+// off removed statuses get a TaskUpdated of their own.
+type WorkflowUpdated struct {
+	ProjectChange
+	Workflow project.Workflow
+	Previous project.Workflow
+}
+
+type DocumentAdded struct {
+	ProjectChange
+	Document project.Document
+}
+
+type DocumentUpdated struct {
+	ProjectChange
+	Document project.Document
+	Previous project.Document
+}
+
+type DocumentDeleted struct {
+	ProjectChange
+	Document project.Document
+}
+
+// MemberInvited is published when someone is invited to a project by email
+type MemberInvited struct {
+	ProjectChange
+	Invitation  project.Invitation
+	InviterName string
+}
+
+// MemberAdded is published when an owner gives an existing user access to a project
+type MemberAdded struct {
+	ProjectChange
+	Member      project.Member
+	ProjectName string
+	ActorName   string
+}
+
+type MemberRoleChanged struct {
+	ProjectChange
+	Member       project.Member
+	PreviousRole project.MemberRole
+	ProjectName  string
+	ActorName    string
+}
+
+// MemberRemoved is published when a member is removed from a project or leaves it
+type MemberRemoved struct {
+	ProjectChange
+	Member      project.Member
+	ProjectName string
+	ActorName   string
+}
+
+type CommentAdded struct {
+	ProjectChange
+	Comment     project.Comment
+	TaskTitle   string
+	ProjectName string
+}
+
+// CommentEdited is published when a comment's body changes; PreviousMentions are the
+// members the comment mentioned before
+type CommentEdited struct {
+	ProjectChange
+	Comment          project.Comment
+	PreviousMentions []string
+	TaskTitle        string
+	ProjectName      string
+}
+
+func (ProjectCreated) EventName() string  { return string(project.EventProjectCreated) }
+func (ProjectUpdated) EventName() string  { return string(project.EventProjectUpdated) }
+func (ProjectDeleted) EventName() string  { return string(project.EventProjectDeleted) }
+func (ProjectRestored) EventName() string { return string(project.EventProjectRestored) }
+func (TaskAdded) EventName() string       { return string(project.EventTaskAdded) }
+func (TaskUpdated) EventName() string     { return string(project.EventTaskUpdated) }
+func (TaskCompleted) EventName() string   { return "task.completed" }
+func (TaskDeleted) EventName() string     { return string(project.EventTaskDeleted) }
+func (TasksReordered) EventName() string  { return string(project.EventTasksReordered) }
+func (DocumentAdded) EventName() string   { return string(project.EventDocumentAdded) }
+func (DocumentUpdated) EventName() string { return string(project.EventDocumentUpdated) }
+func (DocumentDeleted) EventName() string { return string(project.EventDocumentDeleted) }
+func (MemberInvited) EventName() string   { return "project.member_invited" }
+func (WorkflowUpdated) EventName() string { return string(project.EventWorkflowUpdated) }
+
+func (MemberAdded) EventName() string       { return "project.member_added" }
+func (MemberRoleChanged) EventName() string { return "project.member_role_changed" }
+func (MemberRemoved) EventName() string     { return "project.member_removed" }
+func (CommentAdded) EventName() string      { return "comment.added" }
+func (CommentEdited) EventName() string     { return "comment.edited" }
+
+func (e ProjectCreated) ProjectEvent() project.Event {
+	return e.toProjectEvent(project.EventProjectCreated, e.Project)
+}
+
+func (e ProjectUpdated) ProjectEvent() project.Event {
+	return e.toProjectEvent(project.EventProjectUpdated, e.Project)
+}
+
+func (e ProjectDeleted) ProjectEvent() project.Event {
+	return e.toProjectEvent(project.EventProjectDeleted, e.Project)
+}
+
+func (e ProjectRestored) ProjectEvent() project.Event {
+	return e.toProjectEvent(project.EventProjectRestored, struct {
+		RestoredRevision int `json:"restoredRevision"`
+		Revision         int `json:"revision"`
+	}{e.RestoredRevision, e.Revision})
+}
+
+func (e TaskAdded) ProjectEvent() project.Event {
+	pe := e.toProjectEvent(project.EventTaskAdded, e.Task)
+	pe.TaskID = e.Task.ID
+	return pe
+}
+
+func (e TaskUpdated) ProjectEvent() project.Event {
+	pe := e.toProjectEvent(project.EventTaskUpdated, e.Task)
+	pe.TaskID = e.Task.ID
+	return pe
+}
+
+func (e TaskDeleted) ProjectEvent() project.Event {
+	pe := e.toProjectEvent(project.EventTaskDeleted, nil)
+	pe.TaskID = e.Task.ID
+	return pe
+}
+
+func (e TasksReordered) ProjectEvent() project.Event {
+	return e.toProjectEvent(project.EventTasksReordered, e.TaskIDs)
+}
+
+func (e WorkflowUpdated) ProjectEvent() project.Event {
+	return e.toProjectEvent(project.EventWorkflowUpdated, e.Workflow)
+}
+
+func (e DocumentAdded) ProjectEvent() project.Event {
+	pe := e.toProjectEvent(project.EventDocumentAdded, e.Document)
+	pe.DocumentID = e.Document.ID
+	return pe
+}
+
+func (e DocumentUpdated) ProjectEvent() project.Event {
+	pe := e.toProjectEvent(project.EventDocumentUpdated, e.Document)
+	pe.DocumentID = e.Document.ID
+	return pe
+}
+
+func (e DocumentDeleted) ProjectEvent() project.Event {
+	pe := e.toProjectEvent(project.EventDocumentDeleted, nil)
+	pe.DocumentID = e.Document.ID
+	return pe
+}
@@ -0,0 +1,102 @@
+package event
+
+import (
+	"time"
+)
+
+// UserRegistered is published when an account is created. ActivationToken is empty
+// when the account was active right away.
+type UserRegistered struct {
+	UserID          string
+	Email           string
+	ActivationToken string
+}
+
+// UserActivated is published when an account becomes usable, by activation link,
+// invitation or an admin
+type UserActivated struct {
+	UserID string
+	Email  string
+}
+
+// PasswordResetRequested is published when a reset link is issued, by the user or an admin
+type PasswordResetRequested struct {
+	UserID  string
+	Email   string
+	Token   string
+	ByAdmin bool
+}
+
+// EmailChangeRequested is published when a user asks to move to a new address,
+// which must confirm the change
+type EmailChangeRequested struct {
+	UserID   string
+	NewEmail string
+	Token    string
+}
+
+// EmailChanged is published when a new address is confirmed; the old address can undo it
+type EmailChanged struct {
+	UserID      string
+	OldEmail    string
+	NewEmail    string
+	RevertToken string
+}
+
+// EmailChangeReverted is published when the old address takes the account back
+type EmailChangeReverted struct {
+	UserID string
+	Email  string
+}
+
+// UserLoggedIn is published when a session token is issued. Method is password or magic_link.
+type UserLoggedIn struct {
+	UserID string
+	Email  string
+	Method string
+}
+
+// LoginFailed is published when a password login for an existing account is refused
+type LoginFailed struct {
+	UserID string
+	Email  string
+	Reason string
+}
+
+// PasswordChanged is published when a signed-in user changes their password
+type PasswordChanged struct {
+	UserID string
+	Email  string
+}
+
+// PasswordReset is published when a password is set through a reset link
+type PasswordReset struct {
+	UserID string
+	Email  string
+}
+
+type MagicLinkRequested struct {
+	UserID    string
+	Email     string
+	Token     string
+	ExpiresIn time.Duration
+}
+
+type AccountDeletionScheduled struct {
+	UserID   string
+	Email    string
+	DeleteAt time.Time
+}
+
+func (UserRegistered) EventName() string           { return "user.registered" }
+func (UserActivated) EventName() string            { return "user.activated" }
+func (PasswordResetRequested) EventName() string   { return "user.password_reset_requested" }
+func (EmailChangeRequested) EventName() string     { return "user.email_change_requested" }
+func (EmailChanged) EventName() string             { return "user.email_changed" }
+func (EmailChangeReverted) EventName() string      { return "user.email_change_reverted" }
+func (UserLoggedIn) EventName() string             { return "user.logged_in" }
+func (LoginFailed) EventName() string              { return "user.login_failed" }
+func (PasswordChanged) EventName() string          { return "user.password_changed" }
+func (PasswordReset) EventName() string            { return "user.password_reset" }
+func (MagicLinkRequested) EventName() string       { return "user.magic_link_requested" }
+func (AccountDeletionScheduled) EventName() string { return "user.deletion_scheduled" }
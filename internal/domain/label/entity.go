@@ -0,0 +1,54 @@
+package label
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxNameLength = 50
+	// MaxPerWorkspace keeps label pickers usable
+	MaxPerWorkspace = 200
+	// MaxPerItem is how many labels one task or project may carry
+	MaxPerItem = 20
+)
+
+var (
+	ErrNotFound  = errors.New("label not found")
+	ErrNameTaken = errors.New("a label with this name already exists in the workspace")
+	ErrUnknown   = errors.New("label does not belong to the project's workspace")
+)
+
+var colorPattern = regexp.MustCompile(`^#[0-9a-f]{6}$`)
+
+// Label groups tasks and projects across a workspace, e.g. "backend" or "blocked-on-client".
+// Tasks and projects refer to labels by ID, so renaming a label changes it everywhere.
+type Label struct {
+	ID          uuid.UUID `json:"id"`
+	WorkspaceID uuid.UUID `json:"workspaceId"`
+	Name        string    `json:"name"`
+	Color       string    `json:"color"` // #rrggbb
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// Normalize trims the name and lower-cases the color, then checks both
+func (l *Label) Normalize() error {
+	l.Name = strings.TrimSpace(l.Name)
+	l.Color = strings.ToLower(strings.TrimSpace(l.Color))
+	if l.Name == "" {
+		return errors.New("label name is required")
+	}
+	if utf8.RuneCountInString(l.Name) > MaxNameLength {
+		return errors.New("label name is too long")
+	}
+	if !colorPattern.MatchString(l.Color) {
+		return errors.New("label color must look like #1a2b3c")
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package label
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines the interface for label data access.
+// Label names are unique per workspace, ignoring case.
+type Repository interface {
+	Create(ctx context.Context, label *Label) error
+	Update(ctx context.Context, label *Label) error
+	// Delete also takes the label off every project and task in its workspace
+	Delete(ctx context.Context, id uuid.UUID) error
+	// FindByID returns nil when the label does not exist
+	FindByID(ctx context.Context, id uuid.UUID) (*Label, error)
+	// ListByWorkspace returns the workspace's labels by name
+	ListByWorkspace(ctx context.Context, workspaceID uuid.UUID) ([]Label, error)
+}
@@ -0,0 +1,103 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrNotFound = errors.New("notification not found")
+
+// Type tells what happened; users choose per type how they want to hear about it
+type Type string
+
+const (
+	TypeTaskAssigned Type = "task_assigned"
+	TypeMention      Type = "mention"
+	TypeTaskDueSoon  Type = "task_due_soon"
+	TypeMembership   Type = "project_membership"
+)
+
+// Types lists every notification type, in the order preferences are shown
+var Types = []Type{TypeTaskAssigned, TypeMention, TypeTaskDueSoon, TypeMembership}
+
+func (t Type) IsValid() bool {
+	for _, known := range Types {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// ChannelKind identifies a way of delivering notifications
+type ChannelKind string
+
+const (
+	ChannelInApp ChannelKind = "in_app"
+	ChannelEmail ChannelKind = "email"
+)
+
+// Data is a snapshot of the names involved, so old notifications still read well
+// after a task or project is renamed
+type Data struct {
+	ActorName   string `json:"actorName,omitempty"`
+	ProjectName string `json:"projectName,omitempty"`
+	TaskTitle   string `json:"taskTitle,omitempty"`
+	Excerpt     string `json:"excerpt,omitempty"`
+}
+
+type Notification struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"userId"`
+	Type      Type       `json:"type"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	ProjectID *uuid.UUID `json:"projectId,omitempty"`
+	TaskID    string     `json:"taskId,omitempty"`
+	Data      Data       `json:"data"`
+	ReadAt    *time.Time `json:"readAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// Recipient is who a notification goes to
+type Recipient struct {
+	UserID uuid.UUID
+	Email  string
+}
+
+// Preference is how a user wants to hear about one type of notification
+type Preference struct {
+	Type  Type `json:"type"`
+	InApp bool `json:"inApp"`
+	Email bool `json:"email"`
+}
+
+// DefaultPreference applies until the user changes it: everything is delivered
+func DefaultPreference(t Type) Preference {
+	return Preference{Type: t, InApp: true, Email: true}
+}
+
+func (p Preference) Allows(kind ChannelKind) bool {
+	switch kind {
+	case ChannelInApp:
+		return p.InApp
+	case ChannelEmail:
+		return p.Email
+	}
+	return false
+}
+
+// Channel delivers notifications one way, e.g. to the in-app inbox or by email
+type Channel interface {
+	Kind() ChannelKind
+	Deliver(ctx context.Context, to Recipient, n *Notification) error
+}
+
+// Notifier sends a notification through every channel the recipient has enabled for its type.
+// Delivery problems are logged and never fail the action that caused the notification.
+type Notifier interface {
+	Notify(ctx context.Context, to Recipient, n *Notification)
+}
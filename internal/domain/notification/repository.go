@@ -0,0 +1,27 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Create(ctx context.Context, n *Notification) error
+	// List returns a page of the user's notifications, newest first, and how many match in total
+	List(ctx context.Context, userID uuid.UUID, unreadOnly bool, offset, limit int) ([]Notification, int, error)
+	CountUnread(ctx context.Context, userID uuid.UUID) (int, error)
+	// MarkRead returns ErrNotFound unless the notification belongs to the user
+	MarkRead(ctx context.Context, userID, id uuid.UUID) error
+	// MarkAllRead returns how many notifications were unread
+	MarkAllRead(ctx context.Context, userID uuid.UUID) (int, error)
+
+	// Preferences returns only the preferences the user has changed
+	Preferences(ctx context.Context, userID uuid.UUID) ([]Preference, error)
+	SavePreferences(ctx context.Context, userID uuid.UUID, prefs []Preference) error
+
+	// ClaimDueSoon records a due-soon reminder for a task and its current due date. It returns
+	// false when that reminder was already sent, so rescheduled tasks get a fresh one.
+	ClaimDueSoon(ctx context.Context, userID, projectID uuid.UUID, taskID string, dueDate time.Time) (bool, error)
+}
@@ -0,0 +1,60 @@
+package project
+
+// BoardColumn is a workflow status with its tasks, top to bottom
+type BoardColumn struct {
+	Status WorkflowStatus
+	Tasks  []Task
+}
+
+// Board groups the project's tasks by status, one column per workflow status in workflow
+// order. A column's order is the order of its tasks in the project's task list; tasks whose
+// status the workflow no longer has go into the first column.
+func (p *Project) Board() []BoardColumn {
+	columns := make([]BoardColumn, len(p.Workflow.Statuses))
+	index := make(map[TaskStatus]int, len(p.Workflow.Statuses))
+	for i, s := range p.Workflow.Statuses {
+		columns[i] = BoardColumn{Status: s, Tasks: []Task{}}
+		index[s.Key] = i
+	}
+	if len(columns) == 0 {
+		return columns
+	}
+	for _, t := range p.Tasks {
+		columns[index[t.Status]].Tasks = append(columns[index[t.Status]].Tasks, t)
+	}
+	return columns
+}
+
+// PlaceInColumn moves the task with the given ID to position within the tasks sharing its
+// status, counted from zero; positions past the end put it last. The column's tasks trade
+// places among the slots they hold in the list, so tasks of other statuses keep theirs.
+func PlaceInColumn(tasks []Task, id string, position int) bool {
+	var moved *Task
+	for i := range tasks {
+		if tasks[i].ID == id {
+			moved = &tasks[i]
+			break
+		}
+	}
+	if moved == nil {
+		return false
+	}
+
+	var slots []int
+	var column []Task
+	for i := range tasks {
+		if tasks[i].Status != moved.Status {
+			continue
+		}
+		slots = append(slots, i)
+		if tasks[i].ID != id {
+			column = append(column, tasks[i])
+		}
+	}
+	position = max(0, min(position, len(column)))
+	column = append(column[:position], append([]Task{*moved}, column[position:]...)...)
+	for i, slot := range slots {
+		tasks[slot] = column[i]
+	}
+	return true
+}
@@ -0,0 +1,187 @@
+package project
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// tasksOf builds tasks from "id:status" pairs
+func tasksOf(specs ...string) []Task {
+	tasks := make([]Task, len(specs))
+	for i, spec := range specs {
+		id, status, _ := strings.Cut(spec, ":")
+		tasks[i] = Task{ID: id, Title: id, Status: TaskStatus(status)}
+	}
+	return tasks
+}
+
+func taskIDs(tasks []Task) []string {
+	ids := make([]string, len(tasks))
+	for i, t := range tasks {
+		ids[i] = t.ID
+	}
+	return ids
+}
+
+func TestPlaceInColumn(t *testing.T) {
+	tests := []struct {
+		name     string
+		tasks    []Task
+		id       string
+		position int
+		want     []string
+		wantOK   bool
+	}{
+		{
+			name:     "to the top of the column",
+			tasks:    tasksOf("a:todo", "x:in-progress", "b:todo", "c:todo"),
+			id:       "c",
+			position: 0,
+			want:     []string{"c", "x", "a", "b"},
+			wantOK:   true,
+		},
+		{
+			name:     "into the middle of the column",
+			tasks:    tasksOf("a:todo", "x:in-progress", "b:todo", "y:completed", "c:todo"),
+			id:       "a",
+			position: 1,
+			want:     []string{"b", "x", "a", "y", "c"},
+			wantOK:   true,
+		},
+		{
+			name:     "position past the end puts it last",
+			tasks:    tasksOf("a:todo", "x:in-progress", "b:todo", "c:todo"),
+			id:       "a",
+			position: 10,
+			want:     []string{"b", "x", "c", "a"},
+			wantOK:   true,
+		},
+		{
+			name:     "negative position puts it first",
+			tasks:    tasksOf("a:todo", "x:in-progress", "b:todo"),
+			id:       "b",
+			position: -3,
+			want:     []string{"b", "x", "a"},
+			wantOK:   true,
+		},
+		{
+			name:     "same position changes nothing",
+			tasks:    tasksOf("a:todo", "x:in-progress", "b:todo"),
+			id:       "b",
+			position: 1,
+			want:     []string{"a", "x", "b"},
+			wantOK:   true,
+		},
+		{
+			name:     "only task of its status",
+			tasks:    tasksOf("a:todo", "x:in-progress", "b:todo"),
+			id:       "x",
+			position: 5,
+			want:     []string{"a", "x", "b"},
+			wantOK:   true,
+		},
+		{
+			// A task that just changed status is placed among the tasks of its new status
+			name:     "task moved in from another column",
+			tasks:    tasksOf("a:in-progress", "b:todo", "x:in-progress", "y:in-progress"),
+			id:       "a",
+			position: 1,
+			want:     []string{"x", "b", "a", "y"},
+			wantOK:   true,
+		},
+		{
+			name:     "unknown task",
+			tasks:    tasksOf("a:todo", "b:todo"),
+			id:       "z",
+			position: 0,
+			want:     []string{"a", "b"},
+			wantOK:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := slices.Clone(tt.tasks)
+			ok := PlaceInColumn(tt.tasks, tt.id, tt.position)
+			if ok != tt.wantOK {
+				t.Fatalf("PlaceInColumn = %v, want %v", ok, tt.wantOK)
+			}
+			if got := taskIDs(tt.tasks); !slices.Equal(got, tt.want) {
+				t.Errorf("order = %v, want %v", got, tt.want)
+			}
+			// Tasks of other statuses keep their slots
+			moved := slices.IndexFunc(before, func(task Task) bool { return task.ID == tt.id })
+			for i, task := range before {
+				if moved >= 0 && task.Status == before[moved].Status {
+					continue
+				}
+				if tt.tasks[i].ID != task.ID {
+					t.Errorf("task %s of another column moved from slot %d", task.ID, i)
+				}
+			}
+		})
+	}
+}
+
+func TestBoard(t *testing.T) {
+	tests := []struct {
+		name     string
+		workflow Workflow
+		tasks    []Task
+		want     map[TaskStatus][]string
+	}{
+		{
+			name:     "tasks keep the list order within their column",
+			workflow: DefaultWorkflow(),
+			tasks:    tasksOf("a:completed", "b:todo", "c:in-progress", "d:todo"),
+			want: map[TaskStatus][]string{
+				TaskStatusTodo:       {"b", "d"},
+				TaskStatusInProgress: {"c"},
+				TaskStatusCompleted:  {"a"},
+			},
+		},
+		{
+			name:     "unknown statuses go into the first column",
+			workflow: WorkflowOf([]TaskStatus{TaskStatusInProgress, TaskStatusCompleted}),
+			tasks:    tasksOf("a:todo", "b:in-progress", "c:review"),
+			want: map[TaskStatus][]string{
+				TaskStatusInProgress: {"a", "b", "c"},
+				TaskStatusCompleted:  {},
+			},
+		},
+		{
+			name:     "empty columns",
+			workflow: DefaultWorkflow(),
+			want: map[TaskStatus][]string{
+				TaskStatusTodo:       {},
+				TaskStatusInProgress: {},
+				TaskStatusCompleted:  {},
+			},
+		},
+		{
+			name:     "no statuses",
+			workflow: Workflow{},
+			tasks:    tasksOf("a:todo"),
+			want:     map[TaskStatus][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Project{Workflow: tt.workflow, Tasks: tt.tasks}
+			columns := p.Board()
+			if len(columns) != len(tt.workflow.Statuses) {
+				t.Fatalf("got %d columns, want %d", len(columns), len(tt.workflow.Statuses))
+			}
+			for i, column := range columns {
+				if column.Status.Key != tt.workflow.Statuses[i].Key {
+					t.Errorf("column %d is %s, want %s", i, column.Status.Key, tt.workflow.Statuses[i].Key)
+				}
+				if got := taskIDs(column.Tasks); !slices.Equal(got, tt.want[column.Status.Key]) {
+					t.Errorf("column %s = %v, want %v", column.Status.Key, got, tt.want[column.Status.Key])
+				}
+			}
+		})
+	}
+}
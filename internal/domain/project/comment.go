@@ -0,0 +1,60 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrCommentNotFound = errors.New("comment not found")
+
+// Comment is a markdown message on a task. Top-level comments start a thread and
+// replies point at the comment that started it.
+type Comment struct {
+	ID         uuid.UUID  `json:"id"`
+	ProjectID  uuid.UUID  `json:"projectId"`
+	TaskID     string     `json:"taskId"`
+	ParentID   *uuid.UUID `json:"parentId,omitempty"`
+	AuthorID   *uuid.UUID `json:"authorId,omitempty"`
+	AuthorName string     `json:"authorName"`
+	Body       string     `json:"body"`
+	MentionIDs []string   `json:"mentionIds"`
+	EditedAt   *time.Time `json:"editedAt,omitempty"`
+	DeletedAt  *time.Time `json:"deletedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+	Replies    []Comment  `json:"replies,omitempty"`
+}
+
+// IsAuthoredBy reports whether userID wrote the comment
+func (c *Comment) IsAuthoredBy(userID uuid.UUID) bool {
+	return c.AuthorID != nil && *c.AuthorID == userID
+}
+
+// CommentEdit is an earlier version of a comment
+type CommentEdit struct {
+	ID        uuid.UUID  `json:"id"`
+	CommentID uuid.UUID  `json:"commentId"`
+	Body      string     `json:"body"`
+	EditedBy  *uuid.UUID `json:"editedBy,omitempty"`
+	EditedAt  time.Time  `json:"editedAt"`
+}
+
+type CommentRepository interface {
+	CreateComment(ctx context.Context, comment *Comment) error
+	// FindComment returns nil when the comment does not exist
+	FindComment(ctx context.Context, id uuid.UUID) (*Comment, error)
+	// ListThreads returns a page of top-level comments on a task, oldest first, each with its
+	// replies, and the total number of threads
+	ListThreads(ctx context.Context, projectID uuid.UUID, taskID string, offset, limit int) ([]Comment, int, error)
+	// UpdateComment saves the new body and keeps previousBody in the edit history
+	UpdateComment(ctx context.Context, comment *Comment, previousBody string, editedBy uuid.UUID) error
+	// DeleteComment blanks the comment and drops its history, keeping replies in place
+	DeleteComment(ctx context.Context, id uuid.UUID) error
+	DeleteTaskComments(ctx context.Context, projectID uuid.UUID, taskID string) error
+	ListEdits(ctx context.Context, commentID uuid.UUID) ([]CommentEdit, error)
+	// ListByAuthor returns every comment the user wrote that is not deleted, oldest first
+	ListByAuthor(ctx context.Context, authorID uuid.UUID) ([]Comment, error)
+}
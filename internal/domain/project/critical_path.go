@@ -0,0 +1,160 @@
+package project
+
+import (
+	"sort"
+	"time"
+)
+
+// ScheduledTask is where an unfinished task fits in the schedule. Slack is how long the
+// task can slip without missing its due date or the project's end; negative slack means
+// it is already late.
+type ScheduledTask struct {
+	TaskID         string
+	Title          string
+	Duration       time.Duration
+	EarliestStart  time.Time
+	EarliestFinish time.Time
+	LatestStart    time.Time
+	LatestFinish   time.Time
+	Slack          time.Duration
+	Critical       bool
+}
+
+// CriticalPath is the schedule of a project's unfinished tasks. Chain lists the tasks with
+// the least slack, in the order they have to be worked on.
+type CriticalPath struct {
+	Start    time.Time
+	Finish   time.Time // when the last task can be done at the earliest
+	Deadline time.Time
+	Slack    time.Duration
+	Tasks    []ScheduledTask
+	Chain    []string
+}
+
+// ComputeCriticalPath schedules the unfinished tasks from start, taking each task's estimate as
+// its duration. Tasks in a done status are already out of the way. The deadline is the project's
+// end date when it has one, otherwise the projected finish; due dates tighten it for single tasks.
+func ComputeCriticalPath(tasks []Task, w Workflow, start time.Time, end *time.Time) (*CriticalPath, error) {
+	order, err := scheduleOrder(tasks, w)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled := make(map[string]*ScheduledTask, len(order))
+	result := &CriticalPath{Start: start, Finish: start, Tasks: make([]ScheduledTask, len(order))}
+	for i, t := range order {
+		s := &result.Tasks[i]
+		s.TaskID, s.Title = t.ID, t.Title
+		s.Duration = time.Duration(t.EstimateHours * float64(time.Hour)).Round(time.Second)
+		s.EarliestStart = start
+		for _, d := range t.Dependencies {
+			before, ok := scheduled[d.TaskID]
+			if !ok {
+				continue
+			}
+			ready := before.EarliestFinish
+			if d.Type == StartToStart {
+				ready = before.EarliestStart
+			}
+			if ready.After(s.EarliestStart) {
+				s.EarliestStart = ready
+			}
+		}
+		s.EarliestFinish = s.EarliestStart.Add(s.Duration)
+		if s.EarliestFinish.After(result.Finish) {
+			result.Finish = s.EarliestFinish
+		}
+		scheduled[t.ID] = s
+	}
+
+	result.Deadline = result.Finish
+	if end != nil {
+		result.Deadline = *end
+	}
+
+	// Walk back from the deadline; every task has to leave room for the ones waiting on it
+	for i := len(order) - 1; i >= 0; i-- {
+		t, s := order[i], &result.Tasks[i]
+		s.LatestFinish = result.Deadline
+		if t.DueDate != nil && t.DueDate.Before(s.LatestFinish) {
+			s.LatestFinish = *t.DueDate
+		}
+		for j := i + 1; j < len(order); j++ {
+			for _, d := range order[j].Dependencies {
+				if d.TaskID != t.ID {
+					continue
+				}
+				after := &result.Tasks[j]
+				latest := after.LatestStart
+				if d.Type == StartToStart {
+					latest = after.LatestStart.Add(s.Duration)
+				}
+				if latest.Before(s.LatestFinish) {
+					s.LatestFinish = latest
+				}
+			}
+		}
+		s.LatestStart = s.LatestFinish.Add(-s.Duration)
+		s.Slack = s.LatestStart.Sub(s.EarliestStart)
+	}
+
+	for i, s := range result.Tasks {
+		if i == 0 || s.Slack < result.Slack {
+			result.Slack = s.Slack
+		}
+	}
+	critical := []*ScheduledTask{}
+	for i := range result.Tasks {
+		if s := &result.Tasks[i]; s.Slack == result.Slack {
+			s.Critical = true
+			critical = append(critical, s)
+		}
+	}
+	sort.SliceStable(critical, func(i, j int) bool {
+		return critical[i].EarliestStart.Before(critical[j].EarliestStart)
+	})
+	result.Chain = make([]string, len(critical))
+	for i, s := range critical {
+		result.Chain[i] = s.TaskID
+	}
+	return result, nil
+}
+
+// scheduleOrder lists the unfinished tasks so that every task comes after the tasks it
+// depends on, keeping the project's order where dependencies allow
+func scheduleOrder(tasks []Task, w Workflow) ([]*Task, error) {
+	open := make(map[string]bool)
+	for i := range tasks {
+		if !w.IsDone(tasks[i].Status) {
+			open[tasks[i].ID] = true
+		}
+	}
+
+	placed := make(map[string]bool, len(open))
+	order := make([]*Task, 0, len(open))
+	for len(order) < len(open) {
+		progressed := false
+		for i := range tasks {
+			t := &tasks[i]
+			if !open[t.ID] || placed[t.ID] {
+				continue
+			}
+			ready := true
+			for _, d := range t.Dependencies {
+				if open[d.TaskID] && !placed[d.TaskID] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				placed[t.ID] = true
+				order = append(order, t)
+				progressed = true
+			}
+		}
+		if !progressed {
+			return nil, ErrDependencyCycle
+		}
+	}
+	return order, nil
+}
@@ -0,0 +1,141 @@
+package project
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestComputeCriticalPath(t *testing.T) {
+	start := time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC)
+	at := func(hours int) *time.Time {
+		d := start.Add(time.Duration(hours) * time.Hour)
+		return &d
+	}
+	task := func(id string, hours float64, deps ...Dependency) Task {
+		return Task{ID: id, Title: id, Status: TaskStatusTodo, EstimateHours: hours, Dependencies: deps}
+	}
+	after := func(id string) Dependency { return Dependency{TaskID: id, Type: FinishToStart} }
+
+	tests := []struct {
+		name       string
+		tasks      []Task
+		end        *time.Time
+		wantChain  []string
+		wantSlack  map[string]time.Duration
+		wantFinish time.Duration
+		wantErr    error
+	}{
+		{
+			name:       "chain with a disconnected task",
+			tasks:      []Task{task("a", 8), task("b", 4, after("a")), task("c", 2)},
+			wantChain:  []string{"a", "b"},
+			wantSlack:  map[string]time.Duration{"a": 0, "b": 0, "c": 10 * time.Hour},
+			wantFinish: 12 * time.Hour,
+		},
+		{
+			name:       "disconnected tasks only",
+			tasks:      []Task{task("a", 3), task("b", 5)},
+			wantChain:  []string{"b"},
+			wantSlack:  map[string]time.Duration{"a": 2 * time.Hour, "b": 0},
+			wantFinish: 5 * time.Hour,
+		},
+		{
+			name:       "dependency listed after the task waiting on it",
+			tasks:      []Task{task("b", 4, after("a")), task("a", 8)},
+			wantChain:  []string{"a", "b"},
+			wantSlack:  map[string]time.Duration{"a": 0, "b": 0},
+			wantFinish: 12 * time.Hour,
+		},
+		{
+			name:       "start to start",
+			tasks:      []Task{task("a", 8), task("b", 4, Dependency{TaskID: "a", Type: StartToStart})},
+			wantChain:  []string{"a"},
+			wantSlack:  map[string]time.Duration{"a": 0, "b": 4 * time.Hour},
+			wantFinish: 8 * time.Hour,
+		},
+		{
+			name: "done tasks are out of the way",
+			tasks: []Task{
+				{ID: "a", Status: TaskStatusCompleted, EstimateHours: 8},
+				task("b", 4, after("a")),
+			},
+			wantChain:  []string{"b"},
+			wantSlack:  map[string]time.Duration{"b": 0},
+			wantFinish: 4 * time.Hour,
+		},
+		{
+			name:       "end date leaves slack",
+			tasks:      []Task{task("a", 8)},
+			end:        at(24),
+			wantChain:  []string{"a"},
+			wantSlack:  map[string]time.Duration{"a": 16 * time.Hour},
+			wantFinish: 8 * time.Hour,
+		},
+		{
+			name: "due date makes a task late",
+			tasks: []Task{
+				{ID: "a", Status: TaskStatusTodo, EstimateHours: 8, DueDate: at(4)},
+				task("b", 2),
+			},
+			wantChain:  []string{"a"},
+			wantSlack:  map[string]time.Duration{"a": -4 * time.Hour, "b": 6 * time.Hour},
+			wantFinish: 8 * time.Hour,
+		},
+		{
+			name:    "cycle",
+			tasks:   []Task{task("a", 1, after("b")), task("b", 1, after("a"))},
+			wantErr: ErrDependencyCycle,
+		},
+		{
+			name:    "cycle next to a task that can be scheduled",
+			tasks:   []Task{task("a", 1), task("b", 1, after("c")), task("c", 1, after("b"))},
+			wantErr: ErrDependencyCycle,
+		},
+		{
+			name:       "no open tasks",
+			tasks:      []Task{{ID: "a", Status: TaskStatusCompleted, EstimateHours: 8}},
+			wantChain:  []string{},
+			wantSlack:  map[string]time.Duration{},
+			wantFinish: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ComputeCriticalPath(tt.tasks, DefaultWorkflow(), start, tt.end)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(got.Chain, tt.wantChain) {
+				t.Errorf("Chain = %v, want %v", got.Chain, tt.wantChain)
+			}
+			if finish := got.Finish.Sub(start); finish != tt.wantFinish {
+				t.Errorf("Finish = start + %v, want start + %v", finish, tt.wantFinish)
+			}
+			if len(got.Tasks) != len(tt.wantSlack) {
+				t.Fatalf("scheduled %d tasks, want %d", len(got.Tasks), len(tt.wantSlack))
+			}
+			for _, s := range got.Tasks {
+				want, ok := tt.wantSlack[s.TaskID]
+				if !ok {
+					t.Errorf("task %s should not be scheduled", s.TaskID)
+					continue
+				}
+				if s.Slack != want {
+					t.Errorf("task %s: Slack = %v, want %v", s.TaskID, s.Slack, want)
+				}
+				if s.Critical != slices.Contains(tt.wantChain, s.TaskID) {
+					t.Errorf("task %s: Critical = %v", s.TaskID, s.Critical)
+				}
+			}
+		})
+	}
+}
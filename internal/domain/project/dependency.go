@@ -0,0 +1,130 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrDependencyCycle   = errors.New("dependency would create a cycle")
+	ErrDependencyInvalid = errors.New("a task cannot depend on itself, its parents or its subtasks")
+	ErrDependencyMissing = errors.New("dependency task not found")
+	// ErrBlocked is returned for status changes a task's dependencies do not allow yet
+	ErrBlocked = errors.New("task is blocked by its dependencies")
+)
+
+// DependencyType says which end of one task waits for which end of another
+type DependencyType string
+
+const (
+	// FinishToStart tasks cannot start until the other task is completed
+	FinishToStart DependencyType = "finish_to_start"
+	// StartToStart tasks cannot start until the other task has started
+	StartToStart DependencyType = "start_to_start"
+)
+
+func (t DependencyType) IsValid() bool {
+	return t == FinishToStart || t == StartToStart
+}
+
+// Dependency is kept on the task that waits
+type Dependency struct {
+	TaskID string         `json:"taskId"`
+	Type   DependencyType `json:"type"`
+}
+
+// CheckDependency makes sure task id may depend on dependsOn without creating a cycle
+func CheckDependency(tasks []Task, id, dependsOn string) error {
+	byID := indexTasks(tasks)
+	if _, ok := byID[dependsOn]; !ok {
+		return ErrDependencyMissing
+	}
+	if id == dependsOn || Descendants(tasks, id)[dependsOn] || Descendants(tasks, dependsOn)[id] {
+		return ErrDependencyInvalid
+	}
+
+	// A cycle exists if id can already be reached by following dependsOn's own dependencies
+	seen := map[string]bool{dependsOn: true}
+	queue := []string{dependsOn}
+	for len(queue) > 0 {
+		t, ok := byID[queue[0]]
+		queue = queue[1:]
+		if !ok {
+			continue
+		}
+		for _, d := range t.Dependencies {
+			if d.TaskID == id {
+				return ErrDependencyCycle
+			}
+			if !seen[d.TaskID] {
+				seen[d.TaskID] = true
+				queue = append(queue, d.TaskID)
+			}
+		}
+	}
+	return nil
+}
+
+// CheckStatus makes sure t's status fits its dependencies, and that the tasks waiting for t can
+// stay where they are. Dependencies on tasks that no longer exist are ignored.
+func CheckStatus(tasks []Task, t *Task, w Workflow) error {
+	byID := indexTasks(tasks)
+	if w.Started(t.Status) {
+		for _, d := range t.Dependencies {
+			if other, ok := byID[d.TaskID]; ok && !d.allows(other, w) {
+				return blocked(d, other)
+			}
+		}
+	}
+	for i := range tasks {
+		waiting := &tasks[i]
+		if waiting.ID == t.ID || !w.Started(waiting.Status) {
+			continue
+		}
+		for _, d := range waiting.Dependencies {
+			if d.TaskID == t.ID && !d.allows(t, w) {
+				return fmt.Errorf("%w: %q depends on it", ErrBlocked, waiting.Title)
+			}
+		}
+	}
+	return nil
+}
+
+// allows reports whether a started task may depend on other
+func (d Dependency) allows(other *Task, w Workflow) bool {
+	if d.Type == StartToStart {
+		return w.Started(other.Status)
+	}
+	return w.IsDone(other.Status)
+}
+
+func blocked(d Dependency, other *Task) error {
+	if d.Type == StartToStart {
+		return fmt.Errorf("%w: %q has not started yet", ErrBlocked, other.Title)
+	}
+	return fmt.Errorf("%w: %q is not completed yet", ErrBlocked, other.Title)
+}
+
+// DropDependencies removes dependencies on tasks that were removed and returns the tasks
+// it changed as they were before
+func DropDependencies(tasks []Task, removed []Task) []Task {
+	gone := make(map[string]bool, len(removed))
+	for _, t := range removed {
+		gone[t.ID] = true
+	}
+	var changed []Task
+	for i := range tasks {
+		t := &tasks[i]
+		kept := make([]Dependency, 0, len(t.Dependencies))
+		for _, d := range t.Dependencies {
+			if !gone[d.TaskID] {
+				kept = append(kept, d)
+			}
+		}
+		if len(kept) < len(t.Dependencies) {
+			changed = append(changed, *t)
+			t.Dependencies = kept
+		}
+	}
+	return changed
+}
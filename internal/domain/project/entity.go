@@ -15,7 +15,8 @@ const (
 	StatusCompleted ProjectStatus = "completed"
 )
 
-// TaskStatus represents the status of a task
+// TaskStatus represents the status of a task, one of the keys of its project's Workflow.
+// The constants are the statuses of the default workflow.
 type TaskStatus string
 
 const (
@@ -33,13 +34,40 @@ const (
 	PriorityHigh   TaskPriority = "high"
 )
 
-// Task represents a single task within a project
+// Task represents a single task within a project. Subtasks point to their parent and are
+// kept in the same list, so every task of a project can be found by ID.
 type Task struct {
-	ID       string       `json:"id"`
-	Title    string       `json:"title"`
-	Status   TaskStatus   `json:"status"`
-	Priority TaskPriority `json:"priority"`
-	DueDate  *time.Time   `json:"dueDate,omitempty"`
+	ID            string          `json:"id"`
+	ParentID      string          `json:"parentId,omitempty"`
+	Title         string          `json:"title"`
+	Status        TaskStatus      `json:"status"`
+	Priority      TaskPriority    `json:"priority"`
+	DueDate       *time.Time      `json:"dueDate,omitempty"`
+	EstimateHours float64         `json:"estimateHours,omitempty"`
+	StoryPoints   float64         `json:"storyPoints,omitempty"`
+	AssigneeIDs   []string        `json:"assigneeIds,omitempty"`
+	Checklist     []ChecklistItem `json:"checklist,omitempty"`
+	Dependencies  []Dependency    `json:"dependencies,omitempty"`
+	Recurrence    *Recurrence     `json:"recurrence,omitempty"`
+	LabelIDs      []string        `json:"labelIds,omitempty"`
+	Rank          string          `json:"rank,omitempty"` // sorts the task list, see RankTasks
+}
+
+// ChecklistItem is a lightweight step within a task, without status, assignees or comments
+type ChecklistItem struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+	Done bool   `json:"done"`
+}
+
+// IsAssignedTo reports whether userID is one of the task's assignees
+func (t *Task) IsAssignedTo(userID string) bool {
+	for _, id := range t.AssigneeIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
 }
 
 // Document represents a document associated with a project
@@ -54,15 +82,24 @@ type Document struct {
 // Project represents a project entity
 type Project struct {
 	ID          uuid.UUID     `json:"id"`
+	WorkspaceID uuid.UUID     `json:"workspaceId"`
 	UserID      uuid.UUID     `json:"userId"`
 	Name        string        `json:"name"`
 	Description string        `json:"description"`
 	Status      ProjectStatus `json:"status"`
-	Progress    int           `json:"progress"`
+	Progress    int           `json:"progress"` // see ProgressFromTasks
 	StartDate   time.Time     `json:"startDate"`
 	EndDate     *time.Time    `json:"endDate,omitempty"`
 	Tasks       []Task        `json:"tasks"`
 	Documents   []Document    `json:"documents"`
-	CreatedAt   time.Time     `json:"createdAt"`
-	UpdatedAt   time.Time     `json:"updatedAt"`
+	LabelIDs    []string      `json:"labelIds"` // kept outside revisions, like members
+	Workflow    Workflow      `json:"workflow"` // kept outside revisions as well
+	// ProgressSettings says how Progress is worked out; also kept outside revisions
+	ProgressSettings ProgressSettings `json:"progressSettings"`
+	Revision         int              `json:"revision"` // number of the latest revision, see Revision
+	CreatedAt        time.Time        `json:"createdAt"`
+	UpdatedAt        time.Time        `json:"updatedAt"`
+
+	// Role is the requesting user's role, filled in when the project is loaded for them
+	Role MemberRole `json:"role,omitempty"`
 }
@@ -0,0 +1,68 @@
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType names a change members of a project can follow live or through webhooks
+type EventType string
+
+const (
+	EventProjectCreated  EventType = "project.created"
+	EventProjectUpdated  EventType = "project.updated"
+	EventProjectDeleted  EventType = "project.deleted"
+	EventProjectRestored EventType = "project.restored"
+	EventTaskAdded       EventType = "task.added"
+	EventTaskUpdated     EventType = "task.updated"
+	EventTaskDeleted     EventType = "task.deleted"
+	EventTasksReordered  EventType = "tasks.reordered"
+	EventDocumentAdded   EventType = "document.added"
+	EventDocumentUpdated EventType = "document.updated"
+	EventDocumentDeleted EventType = "document.deleted"
+	EventWorkflowUpdated EventType = "workflow.updated"
+)
+
+// EventTypes lists every project event type
+var EventTypes = []EventType{
+	EventProjectCreated, EventProjectUpdated, EventProjectDeleted, EventProjectRestored,
+	EventTaskAdded, EventTaskUpdated, EventTaskDeleted, EventTasksReordered,
+	EventDocumentAdded, EventDocumentUpdated, EventDocumentDeleted,
+	EventWorkflowUpdated,
+}
+
+func (t EventType) IsValid() bool {
+	for _, known := range EventTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Event describes a change to a project. Data holds the project, task or document as it is
+// now, the new task order or the restored revision; it is left out when too large and
+// clients should refetch.
+type Event struct {
+	Type       EventType       `json:"type"`
+	ProjectID  uuid.UUID       `json:"projectId"`
+	ActorID    string          `json:"actorId"`
+	TaskID     string          `json:"taskId,omitempty"`
+	DocumentID string          `json:"documentId,omitempty"`
+	Data       json.RawMessage `json:"data,omitempty"`
+	OccurredAt time.Time       `json:"occurredAt"`
+}
+
+// EventPublisher announces project changes. Publishing is best effort and never
+// fails the change itself.
+type EventPublisher interface {
+	Publish(ctx context.Context, event Event)
+}
+
+// EventStream delivers the events of one project until ctx is done, then closes the channel
+type EventStream interface {
+	Subscribe(ctx context.Context, projectID uuid.UUID) <-chan Event
+}
@@ -0,0 +1,46 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidInvitation = errors.New("invitation is invalid or has expired")
+
+// Invitation asks someone, registered or not, to join a project by email
+type Invitation struct {
+	ID          uuid.UUID  `json:"id"`
+	ProjectID   uuid.UUID  `json:"projectId"`
+	ProjectName string     `json:"projectName"`
+	Email       string     `json:"email"`
+	Role        MemberRole `json:"role"`
+	Token       string     `json:"-"`
+	InvitedBy   *uuid.UUID `json:"invitedBy,omitempty"`
+	ExpiresAt   time.Time  `json:"expiresAt"`
+	AcceptedAt  *time.Time `json:"acceptedAt,omitempty"`
+	DeclinedAt  *time.Time `json:"declinedAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// IsPending reports whether the invitation can still be accepted or declined
+func (i *Invitation) IsPending(now time.Time) bool {
+	return i.AcceptedAt == nil && i.DeclinedAt == nil && now.Before(i.ExpiresAt)
+}
+
+// IsFor reports whether the invitation was addressed to email
+func (i *Invitation) IsFor(email string) bool {
+	return strings.EqualFold(strings.TrimSpace(i.Email), strings.TrimSpace(email))
+}
+
+type InvitationRepository interface {
+	Create(ctx context.Context, invitation *Invitation) error
+	// FindByToken returns nil when no invitation has the token
+	FindByToken(ctx context.Context, token string) (*Invitation, error)
+	ListByProject(ctx context.Context, projectID uuid.UUID) ([]Invitation, error)
+	MarkAccepted(ctx context.Context, id uuid.UUID) error
+	MarkDeclined(ctx context.Context, id uuid.UUID) error
+}
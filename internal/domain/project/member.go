@@ -0,0 +1,54 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrForbidden = errors.New("you do not have permission to perform this action")
+
+// MemberRole is what a member may do in a project
+type MemberRole string
+
+const (
+	RoleOwner  MemberRole = "owner"
+	RoleEditor MemberRole = "editor"
+	RoleViewer MemberRole = "viewer"
+)
+
+func (r MemberRole) IsValid() bool {
+	return r == RoleOwner || r == RoleEditor || r == RoleViewer
+}
+
+// CanEdit reports whether the role may change tasks, documents and project details
+func (r MemberRole) CanEdit() bool {
+	return r == RoleOwner || r == RoleEditor
+}
+
+// CanManage reports whether the role may manage members and delete the project
+func (r MemberRole) CanManage() bool {
+	return r == RoleOwner
+}
+
+// Member is a user with access to a project
+type Member struct {
+	ProjectID   uuid.UUID  `json:"projectId"`
+	UserID      uuid.UUID  `json:"userId"`
+	Email       string     `json:"email"`
+	DisplayName string     `json:"displayName"`
+	Role        MemberRole `json:"role"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+type MemberRepository interface {
+	ListMembers(ctx context.Context, projectID uuid.UUID) ([]Member, error)
+	FindMember(ctx context.Context, projectID, userID uuid.UUID) (*Member, error)
+	AddMember(ctx context.Context, member *Member) error
+	UpdateMemberRole(ctx context.Context, projectID, userID uuid.UUID, role MemberRole) error
+	// RemoveMember takes the user out of the project and off its tasks in one transaction,
+	// saving a new revision by actorID if any task was assigned to them
+	RemoveMember(ctx context.Context, projectID, userID, actorID uuid.UUID) error
+}
@@ -1,16 +1,169 @@
 package project
 
-// ProgressFromTasks calculates progress percentage from tasks (completed / total * 100).
+import (
+	"errors"
+	"math"
+)
+
+// ProgressMode decides how much each task counts towards a project's progress
+type ProgressMode string
+
+const (
+	ProgressByCount    ProgressMode = "count"    // every task counts the same
+	ProgressByEstimate ProgressMode = "estimate" // tasks count by their estimated hours
+	ProgressByPoints   ProgressMode = "points"   // tasks count by their story points
+	ProgressByPriority ProgressMode = "priority" // high priority tasks count three times, medium ones twice as much as low ones
+	ProgressManual     ProgressMode = "manual"   // progress is set by hand
+)
+
+func (m ProgressMode) IsValid() bool {
+	switch m {
+	case ProgressByCount, ProgressByEstimate, ProgressByPoints, ProgressByPriority, ProgressManual:
+		return true
+	}
+	return false
+}
+
+var priorityWeights = map[TaskPriority]float64{PriorityLow: 1, PriorityMedium: 2, PriorityHigh: 3}
+
+// ProgressSettings is how a project works out its progress
+type ProgressSettings struct {
+	Mode ProgressMode `json:"mode"`
+	// InProgressCredit is the percentage a task in a doing status counts as done, unless its
+	// subtasks or checklist say it is further along; 0 counts it as not done at all
+	InProgressCredit int `json:"inProgressCredit,omitempty"`
+	// Manual is the progress in manual mode, as a percentage
+	Manual int `json:"manual,omitempty"`
+}
+
+// Normalize defaults the mode to counting tasks, then checks the percentages
+func (s *ProgressSettings) Normalize() error {
+	if s.Mode == "" {
+		s.Mode = ProgressByCount
+	}
+	if !s.Mode.IsValid() {
+		return errors.New("progress mode must be count, estimate, points, priority or manual")
+	}
+	if s.InProgressCredit < 0 || s.InProgressCredit > 100 {
+		return errors.New("in progress credit must be between 0 and 100")
+	}
+	if s.Manual < 0 || s.Manual > 100 {
+		return errors.New("manual progress must be between 0 and 100")
+	}
+	if s.Mode != ProgressManual {
+		s.Manual = 0
+	}
+	return nil
+}
+
+// weight is how much t counts in the settings' mode on its own; 0 means it has no estimate
+// or points
+func (s ProgressSettings) weight(t *Task) float64 {
+	switch s.Mode {
+	case ProgressByEstimate:
+		return t.EstimateHours
+	case ProgressByPoints:
+		return t.StoryPoints
+	case ProgressByPriority:
+		return priorityWeights[t.Priority]
+	}
+	return 1
+}
+
+// ProgressFromTasks calculates progress as the percentage of top-level tasks done, each
+// counting as much as the settings' mode says. A task with subtasks counts as done as far
+// as its subtasks are, a task with a checklist as far as its checklist is, and a task in a
+// done status of the workflow always counts in full. In the estimate and points modes a task
+// without a value of its own weighs what its subtasks do together, or else as much as the
+// average of its siblings that have one. Manual mode returns the progress set by hand.
 // Returns 0 if there are no tasks.
-func ProgressFromTasks(tasks []Task) int {
+func ProgressFromTasks(tasks []Task, w Workflow, s ProgressSettings) int {
+	if s.Mode == ProgressManual {
+		return s.Manual
+	}
 	if len(tasks) == 0 {
 		return 0
 	}
-	completed := 0
-	for _, t := range tasks {
-		if t.Status == TaskStatusCompleted {
-			completed++
+
+	byID := indexTasks(tasks)
+	children := make(map[string][]*Task)
+	var roots []*Task
+	for i := range tasks {
+		t := &tasks[i]
+		if _, ok := byID[t.ParentID]; t.ParentID != "" && ok {
+			children[t.ParentID] = append(children[t.ParentID], t)
+		} else {
+			roots = append(roots, t)
 		}
 	}
-	return completed * 100 / len(tasks)
+
+	if len(roots) == 0 {
+		return 0
+	}
+
+	credit := float64(s.InProgressCredit) / 100
+	var measure func(t *Task, depth int) (done, weight float64)
+	var combine func(siblings []*Task, depth int) (done, weight float64)
+
+	// measure returns how far along t is and how much it counts
+	measure = func(t *Task, depth int) (float64, float64) {
+		weight := s.weight(t)
+		done := 0.0
+		if subtasks := children[t.ID]; len(subtasks) > 0 && depth < len(tasks) {
+			var total float64
+			done, total = combine(subtasks, depth+1)
+			if weight == 0 {
+				weight = total
+			}
+		} else {
+			if len(t.Checklist) > 0 {
+				checked := 0
+				for _, item := range t.Checklist {
+					if item.Done {
+						checked++
+					}
+				}
+				done = float64(checked) / float64(len(t.Checklist))
+			}
+			if w.Category(t.Status) == CategoryDoing {
+				done = max(done, credit)
+			}
+		}
+		if w.IsDone(t.Status) {
+			done = 1
+		}
+		return done, weight
+	}
+
+	// combine averages siblings by weight and returns the sum of the weights they have
+	combine = func(siblings []*Task, depth int) (float64, float64) {
+		dones := make([]float64, len(siblings))
+		weights := make([]float64, len(siblings))
+		known, weighted := 0.0, 0
+		for i, t := range siblings {
+			dones[i], weights[i] = measure(t, depth)
+			if weights[i] > 0 {
+				known += weights[i]
+				weighted++
+			}
+		}
+		average := 1.0
+		if weighted > 0 {
+			average = known / float64(weighted)
+		}
+		done, total := 0.0, 0.0
+		for i := range siblings {
+			weight := weights[i]
+			if weight <= 0 {
+				weight = average
+			}
+			done += dones[i] * weight
+			total += weight
+		}
+		return done / total, known
+	}
+
+	done, _ := combine(roots, 0)
+	// The small margin keeps whole percentages from rounding down through float error
+	return int(math.Floor(done*100 + 1e-9))
 }
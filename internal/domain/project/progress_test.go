@@ -0,0 +1,236 @@
+package project
+
+import "testing"
+
+func TestProgressFromTasks(t *testing.T) {
+	task := func(id string, status TaskStatus) Task {
+		return Task{ID: id, Title: id, Status: status}
+	}
+	hours := func(id string, status TaskStatus, estimate float64) Task {
+		t := task(id, status)
+		t.EstimateHours = estimate
+		return t
+	}
+	subtask := func(t Task, parentID string) Task {
+		t.ParentID = parentID
+		return t
+	}
+	checklist := func(t Task, done, total int) Task {
+		for i := range total {
+			t.Checklist = append(t.Checklist, ChecklistItem{Text: "item", Done: i < done})
+		}
+		return t
+	}
+	byCount := ProgressSettings{Mode: ProgressByCount}
+	byEstimate := ProgressSettings{Mode: ProgressByEstimate}
+	withCredit := ProgressSettings{Mode: ProgressByCount, InProgressCredit: 50}
+
+	tests := []struct {
+		name     string
+		tasks    []Task
+		settings ProgressSettings
+		want     int
+	}{
+		{
+			name:     "no tasks",
+			settings: byCount,
+			want:     0,
+		},
+		{
+			name:     "share of tasks done",
+			tasks:    []Task{task("a", TaskStatusCompleted), task("b", TaskStatusTodo)},
+			settings: byCount,
+			want:     50,
+		},
+		{
+			name:     "rounds down",
+			tasks:    []Task{task("a", TaskStatusCompleted), task("b", TaskStatusCompleted), task("c", TaskStatusTodo)},
+			settings: byCount,
+			want:     66,
+		},
+		{
+			name:     "task in progress counts nothing without credit",
+			tasks:    []Task{task("a", TaskStatusInProgress), task("b", TaskStatusTodo)},
+			settings: byCount,
+			want:     0,
+		},
+		{
+			name:     "task in progress counts its credit",
+			tasks:    []Task{task("a", TaskStatusInProgress), task("b", TaskStatusTodo)},
+			settings: withCredit,
+			want:     25,
+		},
+		{
+			name:     "checklist further along than the credit",
+			tasks:    []Task{checklist(task("a", TaskStatusInProgress), 3, 4), task("b", TaskStatusTodo)},
+			settings: withCredit,
+			want:     37,
+		},
+		{
+			name:     "credit further along than the checklist",
+			tasks:    []Task{checklist(task("a", TaskStatusInProgress), 1, 4), task("b", TaskStatusTodo)},
+			settings: withCredit,
+			want:     25,
+		},
+		{
+			name:     "credit only applies in a doing status",
+			tasks:    []Task{checklist(task("a", TaskStatusTodo), 1, 4), task("b", TaskStatusTodo)},
+			settings: withCredit,
+			want:     12,
+		},
+		{
+			name:     "done status counts in full whatever the checklist says",
+			tasks:    []Task{checklist(task("a", TaskStatusCompleted), 0, 2), task("b", TaskStatusTodo)},
+			settings: byCount,
+			want:     50,
+		},
+		{
+			name:     "tasks count by their estimate",
+			tasks:    []Task{hours("a", TaskStatusCompleted, 6), hours("b", TaskStatusTodo, 2)},
+			settings: byEstimate,
+			want:     75,
+		},
+		{
+			name:     "zero estimate weighs the average of its siblings",
+			tasks:    []Task{hours("a", TaskStatusCompleted, 6), hours("b", TaskStatusTodo, 2), hours("c", TaskStatusCompleted, 0)},
+			settings: byEstimate,
+			want:     83,
+		},
+		{
+			name:     "no points anywhere counts every task the same",
+			tasks:    []Task{task("a", TaskStatusCompleted), task("b", TaskStatusTodo), task("c", TaskStatusTodo)},
+			settings: ProgressSettings{Mode: ProgressByPoints},
+			want:     33,
+		},
+		{
+			name: "parent without an estimate weighs what its subtasks do",
+			tasks: []Task{
+				hours("p", TaskStatusTodo, 0),
+				subtask(hours("p1", TaskStatusCompleted, 3), "p"),
+				subtask(hours("p2", TaskStatusTodo, 1), "p"),
+				hours("q", TaskStatusTodo, 4),
+			},
+			settings: byEstimate,
+			want:     37,
+		},
+		{
+			name: "parent estimate wins over its subtasks",
+			tasks: []Task{
+				hours("p", TaskStatusTodo, 12),
+				subtask(hours("p1", TaskStatusCompleted, 3), "p"),
+				subtask(hours("p2", TaskStatusTodo, 1), "p"),
+				hours("q", TaskStatusTodo, 4),
+			},
+			settings: byEstimate,
+			want:     56,
+		},
+		{
+			name: "subtasks nested two levels deep",
+			tasks: []Task{
+				task("p", TaskStatusTodo),
+				subtask(task("p1", TaskStatusTodo), "p"),
+				subtask(task("p1a", TaskStatusCompleted), "p1"),
+				subtask(task("p1b", TaskStatusTodo), "p1"),
+				subtask(task("p2", TaskStatusCompleted), "p"),
+			},
+			settings: byCount,
+			want:     75,
+		},
+		{
+			name: "credit reaches the parent through its subtasks",
+			tasks: []Task{
+				task("p", TaskStatusTodo),
+				subtask(task("p1", TaskStatusInProgress), "p"),
+				subtask(task("p2", TaskStatusTodo), "p"),
+				task("q", TaskStatusCompleted),
+			},
+			settings: withCredit,
+			want:     62,
+		},
+		{
+			name: "done parent counts in full with open subtasks",
+			tasks: []Task{
+				task("p", TaskStatusCompleted),
+				subtask(task("p1", TaskStatusTodo), "p"),
+			},
+			settings: byCount,
+			want:     100,
+		},
+		{
+			name: "subtask of a missing parent counts as top-level",
+			tasks: []Task{
+				subtask(task("a", TaskStatusCompleted), "gone"),
+				task("b", TaskStatusTodo),
+			},
+			settings: byCount,
+			want:     50,
+		},
+		{
+			name: "tasks count by their priority",
+			tasks: []Task{
+				{ID: "a", Status: TaskStatusCompleted, Priority: PriorityHigh},
+				{ID: "b", Status: TaskStatusTodo, Priority: PriorityMedium},
+				{ID: "c", Status: TaskStatusTodo, Priority: PriorityLow},
+			},
+			settings: ProgressSettings{Mode: ProgressByPriority},
+			want:     50,
+		},
+		{
+			name:     "manual progress ignores the tasks",
+			tasks:    []Task{task("a", TaskStatusCompleted)},
+			settings: ProgressSettings{Mode: ProgressManual, Manual: 42},
+			want:     42,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ProgressFromTasks(tt.tasks, DefaultWorkflow(), tt.settings); got != tt.want {
+				t.Errorf("ProgressFromTasks = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProgressSettingsNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings ProgressSettings
+		want     ProgressSettings
+		wantErr  bool
+	}{
+		{name: "defaults to counting tasks", want: ProgressSettings{Mode: ProgressByCount}},
+		{
+			name:     "manual progress dropped outside manual mode",
+			settings: ProgressSettings{Mode: ProgressByEstimate, InProgressCredit: 50, Manual: 30},
+			want:     ProgressSettings{Mode: ProgressByEstimate, InProgressCredit: 50},
+		},
+		{
+			name:     "manual progress kept in manual mode",
+			settings: ProgressSettings{Mode: ProgressManual, Manual: 30},
+			want:     ProgressSettings{Mode: ProgressManual, Manual: 30},
+		},
+		{name: "unknown mode", settings: ProgressSettings{Mode: "velocity"}, wantErr: true},
+		{name: "credit above 100", settings: ProgressSettings{InProgressCredit: 101}, wantErr: true},
+		{name: "negative credit", settings: ProgressSettings{InProgressCredit: -1}, wantErr: true},
+		{name: "manual above 100", settings: ProgressSettings{Mode: ProgressManual, Manual: 101}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.settings.Normalize()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Normalize succeeded with %+v, want an error", tt.settings)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.settings != tt.want {
+				t.Errorf("settings = %+v, want %+v", tt.settings, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package project
+
+import (
+	"errors"
+	"slices"
+	"sort"
+
+	"github.com/tomtom2k/kairo-anchor-server/pkg/lexorank"
+)
+
+// MaxRankLength is how long task ranks may grow before the project's ranks are spread out again
+const MaxRankLength = 12
+
+var ErrRankConflict = errors.New("the tasks to move between are no longer next to each other; reload the project and try again")
+
+// SortByRank puts tasks in the order of their ranks. Tasks without a rank keep their place
+// after the ranked ones.
+func SortByRank(tasks []Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		a, b := tasks[i].Rank, tasks[j].Rank
+		return a != "" && (b == "" || a < b)
+	})
+}
+
+// RankTasks gives tasks ranks that increase along the list, which is their order. As many
+// tasks as possible keep the rank they have, so a task moved or added is usually the only
+// one to get a new rank. When none of the ranks can be kept or one grows past MaxRankLength,
+// every task gets a new one.
+func RankTasks(tasks []Task) {
+	keep := increasingRanks(tasks)
+	if !slices.Contains(keep, true) {
+		SpreadRanks(tasks)
+		return
+	}
+	next := make([]string, len(tasks)+1)
+	for i := len(tasks) - 1; i >= 0; i-- {
+		next[i] = next[i+1]
+		if keep[i] {
+			next[i] = tasks[i].Rank
+		}
+	}
+
+	previous := ""
+	for i := range tasks {
+		if !keep[i] {
+			rank, err := lexorank.Between(previous, next[i+1])
+			if err != nil {
+				SpreadRanks(tasks)
+				return
+			}
+			tasks[i].Rank = rank
+		}
+		previous = tasks[i].Rank
+	}
+	if slices.ContainsFunc(tasks, func(t Task) bool { return len(t.Rank) > MaxRankLength }) {
+		SpreadRanks(tasks)
+	}
+}
+
+// SpreadRanks gives tasks new, short ranks in their current order
+func SpreadRanks(tasks []Task) {
+	for i, rank := range lexorank.Spread(len(tasks)) {
+		tasks[i].Rank = rank
+	}
+}
+
+// increasingRanks marks the longest run of valid, strictly increasing ranks along tasks,
+// not necessarily next to each other
+func increasingRanks(tasks []Task) []bool {
+	var tails []int // tails[k] is the task ending the best run of length k+1
+	previous := make([]int, len(tasks))
+	for i, t := range tasks {
+		previous[i] = -1
+		if !lexorank.Valid(t.Rank) {
+			continue
+		}
+		k := sort.Search(len(tails), func(k int) bool { return tasks[tails[k]].Rank >= t.Rank })
+		if k > 0 {
+			previous[i] = tails[k-1]
+		}
+		if k == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[k] = i
+		}
+	}
+
+	keep := make([]bool, len(tasks))
+	if len(tails) > 0 {
+		for i := tails[len(tails)-1]; i >= 0; i = previous[i] {
+			keep[i] = true
+		}
+	}
+	return keep
+}
+
+// PlaceBetween gives the task id the rank that puts it right after afterID and before beforeID,
+// as RankBetween works out, and sorts tasks again. Tasks sharing a rank first get ranks of their
+// own in their current order instead of the move failing. tasks must be sorted by rank.
+func PlaceBetween(tasks []Task, id, afterID, beforeID string) error {
+	i := slices.IndexFunc(tasks, func(t Task) bool { return t.ID == id })
+	if i < 0 {
+		return errors.New("task not found")
+	}
+	if !ranksIncrease(tasks) {
+		RankTasks(tasks)
+	}
+	rank, err := RankBetween(tasks, id, afterID, beforeID)
+	if err != nil {
+		return err
+	}
+	tasks[i].Rank = rank
+	SortByRank(tasks)
+	return nil
+}
+
+// ranksIncrease reports whether every task has a valid rank greater than the one before
+func ranksIncrease(tasks []Task) bool {
+	for i, t := range tasks {
+		if !lexorank.Valid(t.Rank) || i > 0 && tasks[i-1].Rank >= t.Rank {
+			return false
+		}
+	}
+	return true
+}
+
+// RankBetween returns the rank that places a task right after the task afterID and before
+// the task beforeID, which must be neighbours. With only one of them given the task goes
+// right next to it, with neither to the end of the list. tasks must be sorted by rank.
+func RankBetween(tasks []Task, id, afterID, beforeID string) (string, error) {
+	if afterID == id || beforeID == id {
+		return "", errors.New("a task cannot be moved next to itself")
+	}
+	// The list without the task being moved
+	others := slices.DeleteFunc(slices.Clone(tasks), func(t Task) bool { return t.ID == id })
+	find := func(taskID string) (int, error) {
+		i := slices.IndexFunc(others, func(t Task) bool { return t.ID == taskID })
+		if i < 0 {
+			return 0, errors.New("task not found: " + taskID)
+		}
+		return i, nil
+	}
+
+	var after, before string
+	switch {
+	case afterID != "" && beforeID != "":
+		a, err := find(afterID)
+		if err != nil {
+			return "", err
+		}
+		b, err := find(beforeID)
+		if err != nil {
+			return "", err
+		}
+		if b != a+1 {
+			return "", ErrRankConflict
+		}
+		after, before = others[a].Rank, others[b].Rank
+	case afterID != "":
+		a, err := find(afterID)
+		if err != nil {
+			return "", err
+		}
+		after = others[a].Rank
+		if a+1 < len(others) {
+			before = others[a+1].Rank
+		}
+	case beforeID != "":
+		b, err := find(beforeID)
+		if err != nil {
+			return "", err
+		}
+		before = others[b].Rank
+		if b > 0 {
+			after = others[b-1].Rank
+		}
+	case len(others) > 0:
+		// Neither given: the task goes to the end of the list
+		after = others[len(others)-1].Rank
+	}
+
+	rank, err := lexorank.Between(after, before)
+	if err != nil {
+		return "", ErrRankConflict
+	}
+	return rank, nil
+}
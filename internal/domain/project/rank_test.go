@@ -0,0 +1,209 @@
+package project
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// rankedTasks builds tasks from "id=rank" pairs
+func rankedTasks(specs ...string) []Task {
+	tasks := make([]Task, len(specs))
+	for i, spec := range specs {
+		id, rank, _ := strings.Cut(spec, "=")
+		tasks[i] = Task{ID: id, Title: id, Rank: rank}
+	}
+	return tasks
+}
+
+func taskRanks(tasks []Task) []string {
+	ranks := make([]string, len(tasks))
+	for i, t := range tasks {
+		ranks[i] = t.ID + "=" + t.Rank
+	}
+	return ranks
+}
+
+func TestRankBetween(t *testing.T) {
+	list := rankedTasks("a=c", "b=i", "c=o", "m=x")
+
+	tests := []struct {
+		name              string
+		tasks             []Task
+		id                string
+		afterID, beforeID string
+		want              string
+		wantErr           error // nil with want empty means any error
+	}{
+		{name: "between neighbours", tasks: list, id: "m", afterID: "a", beforeID: "b", want: "f"},
+		{name: "after a task", tasks: list, id: "m", afterID: "a", want: "f"},
+		{name: "before a task", tasks: list, id: "m", beforeID: "b", want: "f"},
+		{name: "after the last task", tasks: list, id: "m", afterID: "c", want: "p"},
+		{name: "before the first task", tasks: list, id: "m", beforeID: "a", want: "b"},
+		{name: "neither goes to the end", tasks: list, id: "a", want: "y"},
+		{name: "neighbours once the task is taken out", tasks: list, id: "b", afterID: "a", beforeID: "c", want: "i"},
+		{name: "only task", tasks: rankedTasks("m=x"), id: "m", want: "i"},
+		{name: "not neighbours", tasks: list, id: "m", afterID: "a", beforeID: "c", wantErr: ErrRankConflict},
+		{name: "neighbours the wrong way round", tasks: list, id: "m", afterID: "b", beforeID: "a", wantErr: ErrRankConflict},
+		{name: "neighbours sharing a rank", tasks: rankedTasks("a=c", "b=i", "c=i", "m=x"), id: "m", afterID: "b", beforeID: "c", wantErr: ErrRankConflict},
+		{name: "next to itself", tasks: list, id: "m", afterID: "m"},
+		{name: "unknown neighbour", tasks: list, id: "m", afterID: "z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RankBetween(tt.tasks, tt.id, tt.afterID, tt.beforeID)
+			if tt.want == "" {
+				if err == nil || tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Fatalf("RankBetween = %q, %v; want error %v", got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RankBetween = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaceBetween(t *testing.T) {
+	tests := []struct {
+		name              string
+		tasks             []Task
+		id                string
+		afterID, beforeID string
+		wantOrder         []string
+		wantErr           error
+	}{
+		{
+			name:      "between neighbours",
+			tasks:     rankedTasks("a=c", "b=i", "c=o", "m=x"),
+			id:        "m",
+			afterID:   "a",
+			beforeID:  "b",
+			wantOrder: []string{"a", "m", "b", "c"},
+		},
+		{
+			name:      "to the front",
+			tasks:     rankedTasks("a=c", "b=i", "m=x"),
+			id:        "m",
+			beforeID:  "a",
+			wantOrder: []string{"m", "a", "b"},
+		},
+		{
+			// Two concurrent moves into the same spot can leave tasks sharing a rank
+			name:      "between tasks sharing a rank",
+			tasks:     rankedTasks("a=c", "b=i", "c=i", "m=x"),
+			id:        "m",
+			afterID:   "b",
+			beforeID:  "c",
+			wantOrder: []string{"a", "b", "m", "c"},
+		},
+		{
+			name:      "tasks without ranks",
+			tasks:     rankedTasks("a=", "b=", "m="),
+			id:        "m",
+			afterID:   "a",
+			beforeID:  "b",
+			wantOrder: []string{"a", "m", "b"},
+		},
+		{
+			name:     "not neighbours",
+			tasks:    rankedTasks("a=c", "b=i", "c=o", "m=x"),
+			id:       "m",
+			afterID:  "a",
+			beforeID: "c",
+			wantErr:  ErrRankConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := PlaceBetween(tt.tasks, tt.id, tt.afterID, tt.beforeID)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := taskIDs(tt.tasks); !slices.Equal(got, tt.wantOrder) {
+				t.Errorf("order = %v, want %v", got, tt.wantOrder)
+			}
+			if !ranksIncrease(tt.tasks) {
+				t.Errorf("ranks do not increase: %v", taskRanks(tt.tasks))
+			}
+		})
+	}
+
+	if err := PlaceBetween(rankedTasks("a=c"), "z", "a", ""); err == nil {
+		t.Error("PlaceBetween with an unknown task succeeded")
+	}
+}
+
+func TestRankTasks(t *testing.T) {
+	tests := []struct {
+		name  string
+		tasks []Task
+		want  []string
+	}{
+		{
+			name:  "increasing ranks are kept",
+			tasks: rankedTasks("a=c", "b=i", "c=o"),
+			want:  []string{"a=c", "b=i", "c=o"},
+		},
+		{
+			name:  "new task gets a rank between its neighbours",
+			tasks: rankedTasks("a=c", "n=", "b=i"),
+			want:  []string{"a=c", "n=f", "b=i"},
+		},
+		{
+			name:  "moved task is the only one to change",
+			tasks: rankedTasks("b=i", "a=c", "c=o"),
+			want:  []string{"b=b", "a=c", "c=o"},
+		},
+		{
+			name:  "shared rank is split",
+			tasks: rankedTasks("a=c", "b=i", "c=i", "d=o"),
+			want:  []string{"a=c", "b=f", "c=i", "d=o"},
+		},
+		{
+			name:  "no usable ranks are spread out",
+			tasks: rankedTasks("a=", "b=I", "c=i0"),
+			want:  []string{"a=9", "b=i", "c=r"},
+		},
+		{
+			name:  "ranks that grew too long are spread out",
+			tasks: rankedTasks("a=c", "b=czzzzzzzzzzzz1"),
+			want:  []string{"a=c", "b=o"},
+		},
+		{
+			name:  "no tasks",
+			tasks: []Task{},
+			want:  []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			RankTasks(tt.tasks)
+			if got := taskRanks(tt.tasks); !slices.Equal(got, tt.want) {
+				t.Errorf("ranks = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortByRank(t *testing.T) {
+	tasks := rankedTasks("a=o", "b=", "c=c", "d=i", "e=")
+	SortByRank(tasks)
+	if got, want := taskIDs(tasks), []string{"c", "d", "a", "b", "e"}; !slices.Equal(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,199 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/pkg/rrule"
+)
+
+var (
+	ErrRecurrenceNeedsDueDate = errors.New("recurring tasks need a due date")
+	ErrNotRecurring           = errors.New("task does not recur")
+)
+
+// Recurrence makes a task one occurrence of a series. Completing it adds the next
+// occurrence, due at the rule's next slot in the series' time zone.
+type Recurrence struct {
+	Rule     string `json:"rule"` // RFC 5545 RRULE, e.g. FREQ=WEEKLY;BYDAY=MO
+	Timezone string `json:"timezone"`
+	SeriesID string `json:"seriesId"`
+	// Start is when the series' first occurrence was due (DTSTART)
+	Start time.Time `json:"start"`
+	// Occurrence is the slot this task stands for; its due date may have been moved
+	Occurrence time.Time    `json:"occurrence"`
+	Template   TaskTemplate `json:"template"`
+}
+
+// TaskTemplate is what the next occurrence of a series starts out as. Editing a single
+// occurrence leaves it alone; editing all future occurrences changes it.
+type TaskTemplate struct {
+	Title         string       `json:"title"`
+	Priority      TaskPriority `json:"priority"`
+	EstimateHours float64      `json:"estimateHours,omitempty"`
+	StoryPoints   float64      `json:"storyPoints,omitempty"`
+	AssigneeIDs   []string     `json:"assigneeIds,omitempty"`
+	Checklist     []string     `json:"checklist,omitempty"`
+}
+
+// TemplateOf captures a task as the template for its series
+func TemplateOf(t *Task) TaskTemplate {
+	template := TaskTemplate{
+		Title:         t.Title,
+		Priority:      t.Priority,
+		EstimateHours: t.EstimateHours,
+		StoryPoints:   t.StoryPoints,
+		AssigneeIDs:   append([]string(nil), t.AssigneeIDs...),
+	}
+	for _, item := range t.Checklist {
+		template.Checklist = append(template.Checklist, item.Text)
+	}
+	return template
+}
+
+// KeepAssignees drops the assignees of a task, and of its series' next occurrences, that
+// keep rejects. It reports whether anyone was dropped.
+func (t *Task) KeepAssignees(keep func(userID string) bool) bool {
+	filter := func(ids []string) []string {
+		kept := make([]string, 0, len(ids))
+		for _, id := range ids {
+			if keep(id) {
+				kept = append(kept, id)
+			}
+		}
+		return kept
+	}
+	changed := false
+	if kept := filter(t.AssigneeIDs); len(kept) < len(t.AssigneeIDs) {
+		t.AssigneeIDs, changed = kept, true
+	}
+	if t.Recurrence != nil {
+		if kept := filter(t.Recurrence.Template.AssigneeIDs); len(kept) < len(t.Recurrence.Template.AssigneeIDs) {
+			recurrence := *t.Recurrence
+			recurrence.Template.AssigneeIDs = kept
+			t.Recurrence, changed = &recurrence, true
+		}
+	}
+	return changed
+}
+
+// NewRecurrence starts a series at t's due date
+func NewRecurrence(rule, timezone string, t *Task) (*Recurrence, error) {
+	if t.DueDate == nil {
+		return nil, ErrRecurrenceNeedsDueDate
+	}
+	r := &Recurrence{SeriesID: uuid.New().String(), Template: TemplateOf(t)}
+	if err := r.reset(rule, timezone, *t.DueDate); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Restart applies a new rule or time zone from start onwards, keeping the series. When the
+// rule stays the same, occurrences before this one still count towards its COUNT.
+func (r *Recurrence) Restart(rule, timezone string, start time.Time) error {
+	parsed, err := rrule.Parse(rule)
+	if err != nil {
+		return fmt.Errorf("invalid recurrence rule: %w", err)
+	}
+	if parsed.String() == r.Rule && parsed.Count > 0 {
+		old, _ := r.rule()
+		it := old.Iterate(r.Start.In(r.Location()))
+		for {
+			next, ok := it.Next()
+			if !ok || !next.Before(r.Occurrence) {
+				break
+			}
+			parsed.Count--
+		}
+		if parsed.Count < 1 {
+			return errors.New("the series has no occurrences left")
+		}
+	}
+	return r.reset(parsed.String(), timezone, start)
+}
+
+func (r *Recurrence) reset(rule, timezone string, start time.Time) error {
+	parsed, err := rrule.Parse(rule)
+	if err != nil {
+		return fmt.Errorf("invalid recurrence rule: %w", err)
+	}
+	if _, err := time.LoadLocation(timezone); err != nil || timezone == "" || timezone == "Local" {
+		return errors.New("unknown timezone: " + timezone)
+	}
+	r.Rule, r.Timezone = parsed.String(), timezone
+	r.Start, r.Occurrence = start.UTC(), start.UTC()
+	return nil
+}
+
+func (r *Recurrence) rule() (*rrule.Rule, error) {
+	return rrule.Parse(r.Rule)
+}
+
+// Location is the series' time zone; slots keep their wall-clock time in it across DST changes
+func (r *Recurrence) Location() *time.Location {
+	loc, err := time.LoadLocation(r.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Next returns the first slot after both this occurrence and now. Slots missed while the
+// task was overdue are skipped. It reports false once the series is over.
+func (r *Recurrence) Next(now time.Time) (time.Time, bool) {
+	rule, err := r.rule()
+	if err != nil {
+		return time.Time{}, false
+	}
+	after := r.Occurrence
+	if now.After(after) {
+		after = now
+	}
+	next, ok := rule.After(r.Start.In(r.Location()), after)
+	return next.UTC(), ok
+}
+
+// NextOccurrence builds the task that follows a completed occurrence. It returns nil when
+// t does not recur, its series is over, or a later occurrence already exists.
+func NextOccurrence(tasks []Task, t *Task, status TaskStatus, now time.Time) *Task {
+	if t.Recurrence == nil {
+		return nil
+	}
+	for i := range tasks {
+		other := tasks[i].Recurrence
+		if other != nil && other.SeriesID == t.Recurrence.SeriesID && other.Occurrence.After(t.Recurrence.Occurrence) {
+			return nil
+		}
+	}
+	due, ok := t.Recurrence.Next(now)
+	if !ok {
+		return nil
+	}
+
+	recurrence := *t.Recurrence
+	recurrence.Occurrence = due
+	template := recurrence.Template
+	recurrence.Template.AssigneeIDs = append([]string(nil), template.AssigneeIDs...)
+	recurrence.Template.Checklist = append([]string(nil), template.Checklist...)
+
+	next := &Task{
+		ID:            uuid.New().String(),
+		ParentID:      t.ParentID,
+		Title:         template.Title,
+		Status:        status,
+		Priority:      template.Priority,
+		DueDate:       &due,
+		EstimateHours: template.EstimateHours,
+		StoryPoints:   template.StoryPoints,
+		AssigneeIDs:   append([]string(nil), template.AssigneeIDs...),
+		Recurrence:    &recurrence,
+		LabelIDs:      append([]string(nil), t.LabelIDs...),
+	}
+	for _, text := range template.Checklist {
+		next.Checklist = append(next.Checklist, ChecklistItem{ID: uuid.New().String(), Text: text})
+	}
+	return next
+}
@@ -2,15 +2,61 @@ package project
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
 
-// Repository defines the interface for project data access
+// ErrConflict means the project was saved by someone else after it was loaded
+var ErrConflict = errors.New("the project was changed in the meantime; reload it and try again")
+
+// Repository defines the interface for project data access.
+// Lookups taking a userID only return projects the user is a member of, with Project.Role set.
 type Repository interface {
+	// Create stores the project and makes its UserID the owner. It is saved as revision 1.
 	Create(ctx context.Context, project *Project) error
-	Update(ctx context.Context, project *Project) error
+	// Update saves the project as a new revision by actorID; callers must check the member's role first.
+	// Like Create, it ranks the tasks in the order of the list. It fails with ErrConflict unless
+	// project.Revision is still the latest revision, so concurrent changes are never overwritten.
+	Update(ctx context.Context, project *Project, actorID uuid.UUID) error
+	// RankTask moves one task between two others, as PlaceBetween does, working on the project as
+	// it is in the database while holding a lock on it, so concurrent changes to other tasks
+	// survive and concurrent moves cannot pick the same rank. It saves a new revision by actorID
+	// and returns the project as saved.
+	RankTask(ctx context.Context, projectID uuid.UUID, taskID, afterID, beforeID string, actorID uuid.UUID) (*Project, error)
+	// SpreadLongRanks gives the tasks of every project with a rank longer than MaxRankLength new,
+	// short ranks in the same order. The order does not change, so no revision is saved.
+	// It returns the number of projects changed.
+	SpreadLongRanks(ctx context.Context) (int, error)
+	// Delete only succeeds for owners of the project
 	Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
 	FindByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*Project, error)
+	// FindAllByUserID returns owned and shared projects across all workspaces
 	FindAllByUserID(ctx context.Context, userID uuid.UUID) ([]Project, error)
+	// FindAllInWorkspace returns the user's projects within one workspace. The personal workspace
+	// also lists projects shared with the user from workspaces they do not belong to.
+	FindAllInWorkspace(ctx context.Context, workspaceID uuid.UUID, userID uuid.UUID) ([]Project, error)
+	// FindAllOwnedBy returns the projects the user is an owner of, in every workspace
+	FindAllOwnedBy(ctx context.Context, userID uuid.UUID) ([]Project, error)
+	CountByStatusForUser(ctx context.Context, userID uuid.UUID) (map[ProjectStatus]int, error)
+	// FindTasksAssignedTo returns tasks assigned to the user in projects they can still access
+	FindTasksAssignedTo(ctx context.Context, userID uuid.UUID, filter TaskFilter) ([]AssignedTask, error)
+	// FindOpenTasksDueBetween returns unfinished tasks with assignees, due in [from, to), across all projects
+	FindOpenTasksDueBetween(ctx context.Context, from, to time.Time) ([]AssignedTask, error)
+}
+
+// TaskFilter narrows down a task listing; zero values match everything
+type TaskFilter struct {
+	Statuses  []TaskStatus
+	DueBefore *time.Time
+	DueAfter  *time.Time
+	LabelIDs  []string // tasks must carry all of them
+}
+
+// AssignedTask is a task together with the project it belongs to
+type AssignedTask struct {
+	Task
+	ProjectID   uuid.UUID `json:"projectId"`
+	ProjectName string    `json:"projectName"`
 }
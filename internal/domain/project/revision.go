@@ -0,0 +1,193 @@
+package project
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// Snapshot is the state of a project that revisions keep and restores bring back.
+// Ownership, membership and the workspace are not part of it.
+type Snapshot struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Status      ProjectStatus `json:"status"`
+	Progress    int           `json:"progress"`
+	StartDate   time.Time     `json:"startDate"`
+	EndDate     *time.Time    `json:"endDate,omitempty"`
+	Tasks       []Task        `json:"tasks"`
+	Documents   []Document    `json:"documents"`
+}
+
+func (p *Project) Snapshot() Snapshot {
+	return Snapshot{
+		Name:        p.Name,
+		Description: p.Description,
+		Status:      p.Status,
+		Progress:    p.Progress,
+		StartDate:   p.StartDate,
+		EndDate:     p.EndDate,
+		Tasks:       p.Tasks,
+		Documents:   p.Documents,
+	}
+}
+
+// Restore puts the project back into the state of a snapshot
+func (p *Project) Restore(s Snapshot) {
+	p.Name = s.Name
+	p.Description = s.Description
+	p.Status = s.Status
+	p.Progress = s.Progress
+	p.StartDate = s.StartDate
+	p.EndDate = s.EndDate
+	p.Tasks = s.Tasks
+	p.Documents = s.Documents
+	if p.Tasks == nil {
+		p.Tasks = []Task{}
+	}
+	if p.Documents == nil {
+		p.Documents = []Document{}
+	}
+}
+
+// Revision is a project as it was saved. Every save adds one; older ones are thinned out
+// over time, so numbers have gaps.
+type Revision struct {
+	ProjectID  uuid.UUID
+	Number     int
+	AuthorID   *uuid.UUID
+	AuthorName string
+	Snapshot   Snapshot
+	CreatedAt  time.Time
+}
+
+// RevisionRepository reads the revisions that Repository.Create and Update write
+type RevisionRepository interface {
+	// ListRevisions returns a page of a project's revisions, newest first, without their snapshots
+	ListRevisions(ctx context.Context, projectID uuid.UUID, offset, limit int) ([]Revision, int, error)
+	FindRevision(ctx context.Context, projectID uuid.UUID, number int) (*Revision, error)
+	// CompactRevisions keeps every revision younger than keepAll, the last one of each hour
+	// until keepHourly and the last one of each day after that. A project's latest revision
+	// is always kept. It returns the number of revisions removed.
+	CompactRevisions(ctx context.Context, keepAll, keepHourly time.Duration) (int, error)
+}
+
+// FieldChange is one field's value in two revisions; Before is empty when the field was
+// added and After when it was removed
+type FieldChange struct {
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+}
+
+// ItemChange lists the fields that changed on a task or document present in both revisions
+type ItemChange struct {
+	ID      string                 `json:"id"`
+	Changes map[string]FieldChange `json:"changes"`
+}
+
+type TaskDiff struct {
+	Added     []Task       `json:"added"`
+	Removed   []Task       `json:"removed"`
+	Changed   []ItemChange `json:"changed"`
+	Reordered bool         `json:"reordered"`
+}
+
+type DocumentDiff struct {
+	Added     []Document   `json:"added"`
+	Removed   []Document   `json:"removed"`
+	Changed   []ItemChange `json:"changed"`
+	Reordered bool         `json:"reordered"`
+}
+
+// SnapshotDiff is what changed between two snapshots
+type SnapshotDiff struct {
+	Fields    map[string]FieldChange `json:"fields"`
+	Tasks     TaskDiff               `json:"tasks"`
+	Documents DocumentDiff           `json:"documents"`
+}
+
+// DiffSnapshots compares two snapshots field by field, and tasks and documents by ID
+func DiffSnapshots(before, after Snapshot) SnapshotDiff {
+	fields := diffFields(before, after)
+	delete(fields, "tasks")
+	delete(fields, "documents")
+
+	var d SnapshotDiff
+	d.Fields = fields
+	d.Tasks.Added, d.Tasks.Removed, d.Tasks.Changed, d.Tasks.Reordered = diffItems(before.Tasks, after.Tasks,
+		func(t Task) string { return t.ID })
+	d.Documents.Added, d.Documents.Removed, d.Documents.Changed, d.Documents.Reordered = diffItems(before.Documents, after.Documents,
+		func(doc Document) string { return doc.ID })
+	return d
+}
+
+func diffItems[T any](before, after []T, id func(T) string) (added, removed []T, changed []ItemChange, reordered bool) {
+	added, removed, changed = []T{}, []T{}, []ItemChange{}
+
+	old := make(map[string]T, len(before))
+	for _, item := range before {
+		old[id(item)] = item
+	}
+	kept := make(map[string]bool, len(after))
+	var keptOrder []string
+	for _, item := range after {
+		prev, ok := old[id(item)]
+		if !ok {
+			added = append(added, item)
+			continue
+		}
+		kept[id(item)] = true
+		keptOrder = append(keptOrder, id(item))
+		if fields := diffFields(prev, item); len(fields) > 0 {
+			changed = append(changed, ItemChange{ID: id(item), Changes: fields})
+		}
+	}
+
+	i := 0
+	for _, item := range before {
+		if !kept[id(item)] {
+			removed = append(removed, item)
+			continue
+		}
+		if keptOrder[i] != id(item) {
+			reordered = true
+		}
+		i++
+	}
+	return added, removed, changed, reordered
+}
+
+// diffFields compares the JSON fields of two values
+func diffFields(before, after any) map[string]FieldChange {
+	b, a := jsonFields(before), jsonFields(after)
+	changes := make(map[string]FieldChange)
+	for name, value := range a {
+		if !bytes.Equal(b[name], value) {
+			changes[name] = FieldChange{Before: b[name], After: value}
+		}
+	}
+	for name, value := range b {
+		if _, ok := a[name]; !ok {
+			changes[name] = FieldChange{Before: value}
+		}
+	}
+	return changes
+}
+
+func jsonFields(v any) map[string]json.RawMessage {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil
+	}
+	return m
+}
@@ -0,0 +1,30 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidStreamTicket = errors.New("stream ticket is invalid or has expired")
+
+// StreamTicket lets a browser open a project's event stream once, shortly after asking for it,
+// since EventSource cannot send the access token in a header. Only a hash of it is stored.
+type StreamTicket struct {
+	TokenHash string
+	UserID    uuid.UUID
+	ProjectID uuid.UUID
+	ExpiresAt time.Time
+	// AccessExpiresAt is when the access token the ticket was issued for expires; the stream ends then
+	AccessExpiresAt time.Time
+	// TokenIssuedAt is when that access token was issued, so revoking it also revokes the ticket
+	TokenIssuedAt time.Time
+}
+
+type StreamTicketRepository interface {
+	CreateStreamTicket(ctx context.Context, ticket *StreamTicket) error
+	// RedeemStreamTicket deletes the unexpired ticket with the hash and returns it, or nil if there is none
+	RedeemStreamTicket(ctx context.Context, tokenHash string) (*StreamTicket, error)
+}
@@ -0,0 +1,191 @@
+package project
+
+import "errors"
+
+// MaxTaskDepth is how deep tasks nest: a task, its subtasks and their subtasks
+const MaxTaskDepth = 3
+
+var (
+	ErrOpenSubtasks  = errors.New("task has unfinished subtasks")
+	ErrTaskTooDeep   = errors.New("subtasks cannot be nested this deep")
+	ErrTaskCycle     = errors.New("a task cannot be moved under itself or its own subtasks")
+	ErrParentMissing = errors.New("parent task not found")
+)
+
+// ParentCompletion decides how a task's status follows its subtasks
+type ParentCompletion string
+
+const (
+	// ParentCompletionManual leaves parent tasks alone
+	ParentCompletionManual ParentCompletion = "manual"
+	// ParentCompletionRequireSubtasks refuses to complete a task while a subtask is unfinished
+	ParentCompletionRequireSubtasks ParentCompletion = "require_subtasks"
+	// ParentCompletionAuto also completes a task once its last subtask is completed, and
+	// reopens it when a subtask is reopened or added
+	ParentCompletionAuto ParentCompletion = "auto"
+)
+
+func (c ParentCompletion) IsValid() bool {
+	switch c {
+	case ParentCompletionManual, ParentCompletionRequireSubtasks, ParentCompletionAuto:
+		return true
+	}
+	return false
+}
+
+// Subtasks returns the indexes of a task's direct subtasks, in order
+func Subtasks(tasks []Task, parentID string) []int {
+	var indexes []int
+	for i := range tasks {
+		if tasks[i].ParentID == parentID {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// Descendants returns the IDs of every task below a task
+func Descendants(tasks []Task, id string) map[string]bool {
+	below := make(map[string]bool)
+	queue := []string{id}
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+		for _, i := range Subtasks(tasks, parent) {
+			if child := tasks[i].ID; !below[child] && child != id {
+				below[child] = true
+				queue = append(queue, child)
+			}
+		}
+	}
+	return below
+}
+
+// taskDepth is 1 for top-level tasks, 2 for their subtasks and so on
+func taskDepth(tasks []Task, id string) int {
+	byID := indexTasks(tasks)
+	depth := 0
+	for seen := 0; id != "" && seen <= len(tasks); seen++ {
+		t, ok := byID[id]
+		if !ok {
+			break
+		}
+		depth++
+		id = t.ParentID
+	}
+	return depth
+}
+
+// subtreeHeight is 1 for a task without subtasks, 2 when its subtasks have none, and so on
+func subtreeHeight(tasks []Task, id string) int {
+	byID := indexTasks(tasks)
+	height := 0
+	for below := range Descendants(tasks, id) {
+		depth := 0
+		for cur := below; cur != id && depth <= len(tasks); depth++ {
+			cur = byID[cur].ParentID
+		}
+		height = max(height, depth)
+	}
+	return height + 1
+}
+
+// CheckParent makes sure task id may be placed under parentID; id is empty for new tasks
+func CheckParent(tasks []Task, id, parentID string) error {
+	if parentID == "" {
+		return nil
+	}
+	if _, ok := indexTasks(tasks)[parentID]; !ok {
+		return ErrParentMissing
+	}
+	height := 1
+	if id != "" {
+		if parentID == id || Descendants(tasks, id)[parentID] {
+			return ErrTaskCycle
+		}
+		height = subtreeHeight(tasks, id)
+	}
+	if taskDepth(tasks, parentID)+height > MaxTaskDepth {
+		return ErrTaskTooDeep
+	}
+	return nil
+}
+
+// CheckCompletion returns ErrOpenSubtasks when the rule does not let t be completed yet
+func (c ParentCompletion) CheckCompletion(tasks []Task, t *Task, w Workflow) error {
+	if c == ParentCompletionManual || c == "" || !w.IsDone(t.Status) {
+		return nil
+	}
+	for _, i := range Subtasks(tasks, t.ID) {
+		if !w.IsDone(tasks[i].Status) {
+			return ErrOpenSubtasks
+		}
+	}
+	return nil
+}
+
+// Cascade applies the auto rule from parentID up: a task whose subtasks are all done is
+// completed with the workflow's first done status, and a done task with an unfinished
+// subtask is reopened. It returns the tasks it changed as they were before.
+func (c ParentCompletion) Cascade(tasks []Task, parentID string, w Workflow) []Task {
+	doneStatus, ok := w.Done()
+	if c != ParentCompletionAuto || !ok {
+		return nil
+	}
+	var changed []Task
+	for seen := 0; parentID != "" && seen <= len(tasks); seen++ {
+		i := taskIndex(tasks, parentID)
+		subtasks := Subtasks(tasks, parentID)
+		if i < 0 || len(subtasks) == 0 {
+			break
+		}
+		done := true
+		for _, j := range subtasks {
+			done = done && w.IsDone(tasks[j].Status)
+		}
+
+		previous := tasks[i]
+		switch {
+		case done && !w.IsDone(tasks[i].Status):
+			tasks[i].Status = doneStatus
+		case !done && w.IsDone(tasks[i].Status):
+			tasks[i].Status = w.Reopen()
+		default:
+			return changed
+		}
+		changed = append(changed, previous)
+		parentID = tasks[i].ParentID
+	}
+	return changed
+}
+
+// RemoveTask takes a task and everything below it out of the list and returns what was removed
+func RemoveTask(tasks []Task, id string) (kept, removed []Task) {
+	below := Descendants(tasks, id)
+	kept = make([]Task, 0, len(tasks))
+	for _, t := range tasks {
+		if t.ID == id || below[t.ID] {
+			removed = append(removed, t)
+		} else {
+			kept = append(kept, t)
+		}
+	}
+	return kept, removed
+}
+
+func indexTasks(tasks []Task) map[string]*Task {
+	byID := make(map[string]*Task, len(tasks))
+	for i := range tasks {
+		byID[tasks[i].ID] = &tasks[i]
+	}
+	return byID
+}
+
+func taskIndex(tasks []Task, id string) int {
+	for i := range tasks {
+		if tasks[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
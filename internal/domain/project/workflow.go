@@ -0,0 +1,229 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	MaxWorkflowStatuses = 20
+	maxStatusNameLength = 50
+	maxWIPLimit         = 1000
+)
+
+var (
+	ErrTransitionNotAllowed = errors.New("the project's workflow does not allow this status change")
+	ErrWIPLimit             = errors.New("status has reached its work in progress limit")
+	ErrUnknownStatus        = errors.New("task status is not part of the project's workflow")
+)
+
+var statusKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9-]{0,29}$`)
+
+// StatusCategory is what a workflow status means for progress, dependencies and reports,
+// whatever the project calls it
+type StatusCategory string
+
+const (
+	CategoryTodo  StatusCategory = "todo"
+	CategoryDoing StatusCategory = "doing"
+	CategoryDone  StatusCategory = "done"
+)
+
+func (c StatusCategory) IsValid() bool {
+	return c == CategoryTodo || c == CategoryDoing || c == CategoryDone
+}
+
+// WorkflowStatus is one column of a project's board
+type WorkflowStatus struct {
+	Key      TaskStatus     `json:"key"` // stored on tasks, e.g. "review"
+	Name     string         `json:"name"`
+	Category StatusCategory `json:"category"`
+	// WIPLimit caps how many tasks may have the status at once; 0 means no limit
+	WIPLimit int `json:"wipLimit,omitempty"`
+}
+
+// Transition lets tasks move from one status to another
+type Transition struct {
+	From TaskStatus `json:"from"`
+	To   TaskStatus `json:"to"`
+}
+
+// Workflow is a project's ordered task statuses. Without transitions, tasks may move
+// between any two statuses.
+type Workflow struct {
+	Statuses    []WorkflowStatus `json:"statuses"`
+	Transitions []Transition     `json:"transitions,omitempty"`
+}
+
+// DefaultWorkflow is todo, in progress and completed, with every move allowed
+func DefaultWorkflow() Workflow {
+	return WorkflowOf([]TaskStatus{TaskStatusTodo, TaskStatusInProgress, TaskStatusCompleted})
+}
+
+// WorkflowOf builds a workflow from some of the built-in statuses, in the given order
+func WorkflowOf(statuses []TaskStatus) Workflow {
+	builtIn := map[TaskStatus]WorkflowStatus{
+		TaskStatusTodo:       {Key: TaskStatusTodo, Name: "To do", Category: CategoryTodo},
+		TaskStatusInProgress: {Key: TaskStatusInProgress, Name: "In progress", Category: CategoryDoing},
+		TaskStatusCompleted:  {Key: TaskStatusCompleted, Name: "Completed", Category: CategoryDone},
+	}
+	w := Workflow{Statuses: make([]WorkflowStatus, 0, len(statuses))}
+	for _, s := range statuses {
+		if status, ok := builtIn[s]; ok {
+			w.Statuses = append(w.Statuses, status)
+		}
+	}
+	return w
+}
+
+// Normalize trims status names, then checks the statuses and transitions
+func (w *Workflow) Normalize() error {
+	if len(w.Statuses) == 0 {
+		return errors.New("a workflow needs at least one status")
+	}
+	if len(w.Statuses) > MaxWorkflowStatuses {
+		return fmt.Errorf("a workflow can have at most %d statuses", MaxWorkflowStatuses)
+	}
+	seen := make(map[TaskStatus]bool, len(w.Statuses))
+	hasDone := false
+	for i := range w.Statuses {
+		s := &w.Statuses[i]
+		s.Name = strings.TrimSpace(s.Name)
+		if !statusKeyPattern.MatchString(string(s.Key)) {
+			return fmt.Errorf("invalid status key %q: use lowercase letters, digits and dashes", s.Key)
+		}
+		if seen[s.Key] {
+			return fmt.Errorf("duplicate status: %s", s.Key)
+		}
+		seen[s.Key] = true
+		if s.Name == "" {
+			s.Name = string(s.Key)
+		}
+		if utf8.RuneCountInString(s.Name) > maxStatusNameLength {
+			return fmt.Errorf("status name is too long: %s", s.Key)
+		}
+		if !s.Category.IsValid() {
+			return fmt.Errorf("status %s needs a category of todo, doing or done", s.Key)
+		}
+		if s.WIPLimit < 0 || s.WIPLimit > maxWIPLimit {
+			return fmt.Errorf("WIP limit of %s must be between 0 and %d", s.Key, maxWIPLimit)
+		}
+		hasDone = hasDone || s.Category == CategoryDone
+	}
+	if !hasDone {
+		return errors.New("a workflow needs a status in the done category")
+	}
+
+	transitions := make(map[Transition]bool, len(w.Transitions))
+	for _, t := range w.Transitions {
+		if !seen[t.From] || !seen[t.To] {
+			return fmt.Errorf("transition from %s to %s uses an unknown status", t.From, t.To)
+		}
+		if t.From == t.To || transitions[t] {
+			return fmt.Errorf("invalid transition from %s to %s", t.From, t.To)
+		}
+		transitions[t] = true
+	}
+	return nil
+}
+
+// Status returns the workflow status with the given key, or nil
+func (w Workflow) Status(key TaskStatus) *WorkflowStatus {
+	for i := range w.Statuses {
+		if w.Statuses[i].Key == key {
+			return &w.Statuses[i]
+		}
+	}
+	return nil
+}
+
+// Allows reports whether tasks may use status
+func (w Workflow) Allows(status TaskStatus) bool {
+	return w.Status(status) != nil
+}
+
+// Category is the category of status; statuses the workflow no longer has count as todo
+func (w Workflow) Category(status TaskStatus) StatusCategory {
+	if s := w.Status(status); s != nil {
+		return s.Category
+	}
+	return CategoryTodo
+}
+
+// IsDone reports whether status finishes a task
+func (w Workflow) IsDone(status TaskStatus) bool {
+	return w.Category(status) == CategoryDone
+}
+
+// Started reports whether work on a task in status has begun
+func (w Workflow) Started(status TaskStatus) bool {
+	return w.Category(status) != CategoryTodo
+}
+
+// Initial is the status given to tasks created without one
+func (w Workflow) Initial() TaskStatus {
+	if len(w.Statuses) == 0 {
+		return TaskStatusTodo
+	}
+	return w.Statuses[0].Key
+}
+
+// Done is the first status in the done category, which parents are completed with
+func (w Workflow) Done() (TaskStatus, bool) {
+	return w.first(CategoryDone)
+}
+
+// Reopen is the status a completed parent task goes back to when the auto completion rule
+// reopens it: the first doing status, or the initial one
+func (w Workflow) Reopen() TaskStatus {
+	if status, ok := w.first(CategoryDoing); ok {
+		return status
+	}
+	return w.Initial()
+}
+
+func (w Workflow) first(category StatusCategory) (TaskStatus, bool) {
+	for _, s := range w.Statuses {
+		if s.Category == category {
+			return s.Key, true
+		}
+	}
+	return "", false
+}
+
+// CheckTransition makes sure a task may move from one status to another
+func (w Workflow) CheckTransition(from, to TaskStatus) error {
+	if !w.Allows(to) {
+		return ErrUnknownStatus
+	}
+	if from == to || len(w.Transitions) == 0 || !w.Allows(from) {
+		return nil
+	}
+	for _, t := range w.Transitions {
+		if t.From == from && t.To == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s to %s", ErrTransitionNotAllowed, from, to)
+}
+
+// CheckWIP makes sure t fits into its status's WIP limit alongside the other tasks
+func (w Workflow) CheckWIP(tasks []Task, t *Task) error {
+	s := w.Status(t.Status)
+	if s == nil || s.WIPLimit == 0 {
+		return nil
+	}
+	count := 0
+	for i := range tasks {
+		if tasks[i].ID != t.ID && tasks[i].Status == t.Status {
+			count++
+		}
+	}
+	if count >= s.WIPLimit {
+		return fmt.Errorf("%w: %s allows %d tasks", ErrWIPLimit, s.Name, s.WIPLimit)
+	}
+	return nil
+}
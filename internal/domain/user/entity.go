@@ -7,9 +7,39 @@ type User struct {
 	Email             string
 	Password          string
 	IsActive          bool
+	Role              Role
 	ActivationToken   *string
 	ResetToken        *string
 	ResetTokenExpires *time.Time
-	CreatedAt         time.Time
-	UpdatedAt         time.Time
-}
\ No newline at end of file
+
+	// Profile
+	DisplayName string
+	AvatarURL   *string
+	Timezone    string // IANA name, e.g. "Asia/Ho_Chi_Minh"
+	Locale      string // BCP 47 tag, e.g. "vi-VN"
+	WeekStart   time.Weekday
+
+	// Email change: the new address waits in PendingEmail until it is confirmed,
+	// after which the old address is kept in PreviousEmail so the change can be undone.
+	PendingEmail            *string
+	EmailChangeToken        *string
+	EmailChangeTokenExpires *time.Time
+	PreviousEmail           *string
+	EmailRevertToken        *string
+	EmailRevertTokenExpires *time.Time
+
+	// Moderation: banned users can't sign in or use existing tokens;
+	// PasswordResetRequired blocks password login until the reset link is used.
+	BannedAt              *time.Time
+	BanReason             string
+	PasswordResetRequired bool
+	// TokensValidAfter revokes every access token issued at or before it
+	TokensValidAfter *time.Time
+
+	// DeletionScheduledAt is set when the user asked to delete the account;
+	// the account and all its data are purged once this time has passed.
+	DeletionScheduledAt *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
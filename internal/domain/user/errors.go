@@ -0,0 +1,14 @@
+package user
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrEmailTaken = errors.New("email address is already in use")
+
+// SameEmail reports whether two addresses refer to the same mailbox.
+// Email addresses are compared case-insensitively everywhere in the system.
+func SameEmail(a, b string) bool {
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
@@ -0,0 +1,36 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrTooManyRequests  = errors.New("too many requests, please try again later")
+	ErrInvalidMagicLink = errors.New("sign-in link is invalid or has expired")
+)
+
+// MagicLink is a single-use passwordless sign-in link.
+// Only hashes of the token and the optional device secret are stored.
+type MagicLink struct {
+	ID         string
+	UserID     string
+	Email      string
+	TokenHash  string
+	DeviceHash *string // when set, the link only works in the browser that requested it
+	ExpiresAt  time.Time
+	UsedAt     *time.Time
+	CreatedAt  time.Time
+}
+
+type MagicLinkRepository interface {
+	Create(ctx context.Context, link *MagicLink) error
+	FindByTokenHash(ctx context.Context, tokenHash string) (*MagicLink, error)
+	// MarkUsed fails with ErrInvalidMagicLink if the link was already used
+	MarkUsed(ctx context.Context, id string) error
+	// RecordAttempt notes a request for a link, whether or not the email has an account
+	RecordAttempt(ctx context.Context, email, ip string) error
+	// CountRecentAttempts counts the requests since the given time for the email and from the IP
+	CountRecentAttempts(ctx context.Context, email, ip string, since time.Time) (byEmail, byIP int, err error)
+}
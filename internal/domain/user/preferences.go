@@ -0,0 +1,102 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultTimezone  = "UTC"
+	DefaultLocale    = "en"
+	DefaultWeekStart = time.Monday
+)
+
+var localePattern = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+
+// Preferences are the personal settings other features (reminders, emails, calendars...)
+// should honor when acting on behalf of a user.
+type Preferences struct {
+	Timezone  string
+	Locale    string
+	WeekStart time.Weekday
+}
+
+// PreferencesReader gives other domains read access to a user's preferences.
+type PreferencesReader interface {
+	PreferencesFor(ctx context.Context, userID string) (Preferences, error)
+}
+
+// DefaultPreferences are used for users who never changed their settings.
+func DefaultPreferences() Preferences {
+	return Preferences{Timezone: DefaultTimezone, Locale: DefaultLocale, WeekStart: DefaultWeekStart}
+}
+
+func (u *User) Preferences() Preferences {
+	p := DefaultPreferences()
+	if u.Timezone != "" {
+		p.Timezone = u.Timezone
+	}
+	if u.Locale != "" {
+		p.Locale = u.Locale
+	}
+	p.WeekStart = u.WeekStart
+	return p
+}
+
+// Name returns the display name, falling back to the email address.
+func (u *User) Name() string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	return u.Email
+}
+
+// Location returns the user's time zone, or UTC if it can't be loaded.
+func (p Preferences) Location() *time.Location {
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func ValidateTimezone(tz string) error {
+	if tz == "" || tz == "Local" {
+		return errors.New("timezone must be an IANA time zone name")
+	}
+	if _, err := time.LoadLocation(tz); err != nil {
+		return errors.New("unknown timezone: " + tz)
+	}
+	return nil
+}
+
+func ValidateLocale(locale string) error {
+	if !localePattern.MatchString(locale) {
+		return errors.New("locale must be a BCP 47 language tag, e.g. en or vi-VN")
+	}
+	return nil
+}
+
+// ValidateAvatarURL accepts absolute http and https URLs only, so an avatar can never be
+// a javascript: or data: link
+func ValidateAvatarURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("avatar URL must be an http or https URL")
+	}
+	return nil
+}
+
+// ParseWeekday parses a lower-case English weekday name such as "monday".
+func ParseWeekday(s string) (time.Weekday, error) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(d.String(), s) {
+			return d, nil
+		}
+	}
+	return 0, errors.New("invalid weekday: " + s)
+}
@@ -0,0 +1,98 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// RegistrationMode controls who may create an account
+type RegistrationMode string
+
+const (
+	RegistrationOpen       RegistrationMode = "open"
+	RegistrationInviteOnly RegistrationMode = "invite_only"
+	RegistrationClosed     RegistrationMode = "closed"
+)
+
+var (
+	ErrRegistrationClosed    = errors.New("registration is closed")
+	ErrInviteCodeRequired    = errors.New("an invite code is required to register")
+	ErrInvalidInviteCode     = errors.New("invite code is invalid or has expired")
+	ErrEmailDomainNotAllowed = errors.New("email domain is not allowed to register")
+)
+
+// RegistrationPolicy decides whether an email address may sign up.
+// Domains match exactly or as a parent domain ("example.com" also matches "mail.example.com").
+type RegistrationPolicy struct {
+	Mode           RegistrationMode
+	AllowedDomains []string // empty means every domain not denied
+	DeniedDomains  []string
+}
+
+// PasswordPolicy validates new passwords; implementations return a descriptive error
+type PasswordPolicy interface {
+	Validate(password string) error
+}
+
+func (p RegistrationPolicy) CheckEmail(email string) error {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ErrEmailDomainNotAllowed
+	}
+	domain := strings.ToLower(email[at+1:])
+
+	for _, d := range p.DeniedDomains {
+		if domainMatches(domain, d) {
+			return ErrEmailDomainNotAllowed
+		}
+	}
+	if len(p.AllowedDomains) == 0 {
+		return nil
+	}
+	for _, d := range p.AllowedDomains {
+		if domainMatches(domain, d) {
+			return nil
+		}
+	}
+	return ErrEmailDomainNotAllowed
+}
+
+func domainMatches(domain, rule string) bool {
+	rule = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(rule), "@"))
+	return rule != "" && (domain == rule || strings.HasSuffix(domain, "."+rule))
+}
+
+// InviteCode lets someone register while registration is invite-only
+type InviteCode struct {
+	ID        string
+	Code      string
+	Email     *string // when set, only this address may use the code
+	MaxUses   int
+	Uses      int
+	ExpiresAt *time.Time
+	RevokedAt *time.Time
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+// UsableBy reports whether the code can still be redeemed by the given address
+func (c *InviteCode) UsableBy(email string, now time.Time) bool {
+	if c.RevokedAt != nil || c.Uses >= c.MaxUses {
+		return false
+	}
+	if c.ExpiresAt != nil && c.ExpiresAt.Before(now) {
+		return false
+	}
+	return c.Email == nil || SameEmail(*c.Email, email)
+}
+
+type InviteCodeRepository interface {
+	Create(ctx context.Context, code *InviteCode) error
+	FindByCode(ctx context.Context, code string) (*InviteCode, error)
+	List(ctx context.Context) ([]InviteCode, error)
+	// Redeem consumes one use of the code, failing with ErrInvalidInviteCode if none are left
+	Redeem(ctx context.Context, id string) error
+	Revoke(ctx context.Context, id string) error
+}
@@ -1,12 +1,37 @@
 package user
 
-import "context"
+import (
+	"context"
+	"time"
+)
+
+// SearchFilter narrows down user searches; zero values mean "any"
+type SearchFilter struct {
+	Query  string // matched against email and display name
+	Role   Role
+	Active *bool
+	Banned *bool
+	Offset int
+	Limit  int
+}
 
 type Repository interface {
 	Create(ctx context.Context, user *User) error
 	Update(ctx context.Context, user *User) error
 	FindByID(ctx context.Context, id string) (*User, error)
+	// FindByEmail matches the address case-insensitively.
 	FindByEmail(ctx context.Context, email string) (*User, error)
 	FindByActivationToken(ctx context.Context, token string) (*User, error)
 	FindByResetToken(ctx context.Context, token string) (*User, error)
-}
\ No newline at end of file
+	FindByEmailChangeToken(ctx context.Context, token string) (*User, error)
+	FindByEmailRevertToken(ctx context.Context, token string) (*User, error)
+	// FindDueForDeletion returns users whose scheduled deletion time is at or before the given time.
+	FindDueForDeletion(ctx context.Context, before time.Time) ([]User, error)
+	Delete(ctx context.Context, id string) error
+	// Purge deletes the account for good together with everything keyed by its ID, all in one
+	// transaction. Projects and shared workspaces no one else is a member of go with it; the
+	// others pass to the remaining members, and the user is taken off the tasks that stay.
+	Purge(ctx context.Context, id string) error
+	// Search returns one page of matching users and the total number of matches.
+	Search(ctx context.Context, filter SearchFilter) ([]User, int, error)
+}
@@ -0,0 +1,25 @@
+package user
+
+import "time"
+
+// Role controls what a user may do outside of their own data
+type Role string
+
+const (
+	RoleUser  Role = "user"
+	RoleAdmin Role = "admin"
+)
+
+func (r Role) IsValid() bool {
+	return r == RoleUser || r == RoleAdmin
+}
+
+func (u *User) IsBanned() bool {
+	return u.BannedAt != nil
+}
+
+// AcceptsTokenIssuedAt reports whether an access token issued at issuedAt has not been revoked.
+// Token times are whole seconds, so tokens from the second of the cut-off are refused as well.
+func (u *User) AcceptsTokenIssuedAt(issuedAt time.Time) bool {
+	return u.TokensValidAfter == nil || issuedAt.After(u.TokensValidAfter.Truncate(time.Second))
+}
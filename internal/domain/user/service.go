@@ -1,5 +1,7 @@
 package user
 
+import "time"
+
 type PasswordHasher interface {
 	Hash(password string) (string, error)
 	Compare(hash, password string) bool
@@ -13,4 +15,13 @@ type TokenService interface {
 type EmailService interface {
 	SendActivationEmail(email, token string) error
 	SendPasswordResetEmail(email, token string) error
-}
\ No newline at end of file
+	SendEmailChangeConfirmation(newEmail, token string) error
+	SendEmailChangedNotice(oldEmail, newEmail, revertToken string) error
+	SendAccountDeletionScheduled(email string, deleteAt time.Time) error
+	SendMagicLinkEmail(email, token string, expiresIn time.Duration) error
+	SendProjectInvitation(email, inviterName, projectName, role, token string, expiresAt time.Time) error
+	SendTaskAssigned(email, assignerName, projectName, taskTitle string) error
+	SendCommentMention(email, authorName, projectName, taskTitle, excerpt string) error
+	// SendNotification emails a notification that has no dedicated template
+	SendNotification(email, subject, body string) error
+}
@@ -0,0 +1,78 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNotFound         = errors.New("webhook not found")
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+)
+
+// Webhook sends project events to an external URL. Project-scoped webhooks only see one
+// project; user-scoped ones see every project their owner is a member of.
+type Webhook struct {
+	ID                  uuid.UUID  `json:"id"`
+	UserID              uuid.UUID  `json:"userId"`
+	ProjectID           *uuid.UUID `json:"projectId,omitempty"`
+	URL                 string     `json:"url"`
+	Secret              string     `json:"-"`
+	Events              []string   `json:"events"`
+	Description         string     `json:"description"`
+	Active              bool       `json:"active"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"` // failed attempts since the last success
+	DisabledAt          *time.Time `json:"disabledAt,omitempty"`
+	CreatedAt           time.Time  `json:"createdAt"`
+	UpdatedAt           time.Time  `json:"updatedAt"`
+}
+
+// Wants reports whether the webhook subscribes to an event type; no filter means every event
+func (w *Webhook) Wants(eventType string) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+// Delivery is one event on its way to one webhook, with the outcome of the latest attempt
+type Delivery struct {
+	ID             uuid.UUID      `json:"id"`
+	WebhookID      uuid.UUID      `json:"webhookId"`
+	EventID        uuid.UUID      `json:"eventId"`
+	EventType      string         `json:"eventType"`
+	Payload        string         `json:"payload"`
+	Status         DeliveryStatus `json:"status"`
+	Attempts       int            `json:"attempts"`
+	NextAttemptAt  *time.Time     `json:"nextAttemptAt,omitempty"`
+	ResponseStatus *int           `json:"responseStatus,omitempty"`
+	ResponseBody   string         `json:"responseBody,omitempty"`
+	LastError      string         `json:"lastError,omitempty"`
+	DurationMs     *int           `json:"durationMs,omitempty"`
+	CreatedAt      time.Time      `json:"createdAt"`
+	DeliveredAt    *time.Time     `json:"deliveredAt,omitempty"`
+}
+
+// Sender posts signed payloads to webhook endpoints
+type Sender interface {
+	// CheckURL rejects URLs deliveries could never be sent to
+	CheckURL(ctx context.Context, rawURL string) error
+	// Send posts the payload and returns the response status and the start of the response body
+	Send(ctx context.Context, url, secret, deliveryID, eventType string, payload []byte) (status int, body string, err error)
+}
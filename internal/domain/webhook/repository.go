@@ -0,0 +1,34 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Create(ctx context.Context, w *Webhook) error
+	Update(ctx context.Context, w *Webhook) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// FindByID returns nil when the webhook does not exist or belongs to someone else
+	FindByID(ctx context.Context, id, userID uuid.UUID) (*Webhook, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]Webhook, error)
+	// FindSubscribed returns the active webhooks that should hear about an event in a project:
+	// those scoped to it or to no project, whose owner is a member or caused the event
+	FindSubscribed(ctx context.Context, projectID uuid.UUID, actorID string) ([]Webhook, error)
+
+	// Enqueue stores pending deliveries, due right away
+	Enqueue(ctx context.Context, deliveries []Delivery) error
+	// ClaimDue returns up to limit due deliveries and postpones them by lease, so a crashed
+	// worker's deliveries are picked up again once the lease runs out
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]Delivery, error)
+	// FindForDelivery returns the webhook a delivery goes to, whoever owns it
+	FindForDelivery(ctx context.Context, delivery *Delivery) (*Webhook, error)
+	// RecordAttempt saves the delivery's new state. A failed attempt adds to the webhook's
+	// consecutive failures and disables it once they reach disableAfter; a successful delivery
+	// resets them.
+	RecordAttempt(ctx context.Context, delivery *Delivery, failed bool, disableAfter int) (disabled bool, err error)
+	ListDeliveries(ctx context.Context, webhookID uuid.UUID, offset, limit int) ([]Delivery, int, error)
+	FindDelivery(ctx context.Context, webhookID, id uuid.UUID) (*Delivery, error)
+}
@@ -0,0 +1,125 @@
+package workspace
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+)
+
+var (
+	ErrForbidden = errors.New("you do not have permission to perform this action in this workspace")
+	ErrNotMember = errors.New("workspace not found or you are not a member")
+)
+
+// Role is what a member may do in a workspace
+type Role string
+
+const (
+	RoleOwner  Role = "owner"
+	RoleAdmin  Role = "admin"
+	RoleMember Role = "member"
+)
+
+func (r Role) IsValid() bool {
+	return r == RoleOwner || r == RoleAdmin || r == RoleMember
+}
+
+// CanManage reports whether the role may change settings and members
+func (r Role) CanManage() bool {
+	return r == RoleOwner || r == RoleAdmin
+}
+
+// ProjectCreationPolicy controls who may create projects in a workspace
+type ProjectCreationPolicy string
+
+const (
+	ProjectCreationMembers ProjectCreationPolicy = "members"
+	ProjectCreationAdmins  ProjectCreationPolicy = "admins"
+)
+
+func (p ProjectCreationPolicy) IsValid() bool {
+	return p == ProjectCreationMembers || p == ProjectCreationAdmins
+}
+
+// Settings apply to every project in the workspace
+type Settings struct {
+	// DefaultTaskStatuses are the built-in statuses new projects start with; each project
+	// can change its own workflow afterwards
+	DefaultTaskStatuses []project.TaskStatus     `json:"defaultTaskStatuses"`
+	ProjectCreation     ProjectCreationPolicy    `json:"projectCreation"`
+	ParentCompletion    project.ParentCompletion `json:"parentCompletion"`
+}
+
+func DefaultSettings() Settings {
+	return Settings{
+		DefaultTaskStatuses: []project.TaskStatus{
+			project.TaskStatusTodo, project.TaskStatusInProgress, project.TaskStatusCompleted,
+		},
+		ProjectCreation:  ProjectCreationMembers,
+		ParentCompletion: project.ParentCompletionManual,
+	}
+}
+
+func (s Settings) Validate() error {
+	if !s.ProjectCreation.IsValid() {
+		return errors.New("project creation policy must be members or admins")
+	}
+	if !s.ParentCompletion.IsValid() {
+		return errors.New("parent completion must be manual, require_subtasks or auto")
+	}
+	if len(s.DefaultTaskStatuses) == 0 {
+		return errors.New("at least one default task status is required")
+	}
+	seen := make(map[project.TaskStatus]bool)
+	for _, st := range s.DefaultTaskStatuses {
+		switch st {
+		case project.TaskStatusTodo, project.TaskStatusInProgress, project.TaskStatusCompleted:
+		default:
+			return errors.New("unknown task status: " + string(st))
+		}
+		if seen[st] {
+			return errors.New("duplicate task status: " + string(st))
+		}
+		seen[st] = true
+	}
+	return nil
+}
+
+// Workflow is the workflow new projects in the workspace start with
+func (s Settings) Workflow() project.Workflow {
+	return project.WorkflowOf(s.DefaultTaskStatuses)
+}
+
+// Workspace groups projects and the people working on them.
+// Personal workspaces are created for every user and cannot be shared or deleted.
+type Workspace struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Personal  bool      `json:"personal"`
+	Settings  Settings  `json:"settings"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	// Role is the requesting user's role, filled in when the workspace is loaded for them
+	Role Role `json:"role,omitempty"`
+}
+
+// CanCreateProjects reports whether the requesting user may add projects
+func (w *Workspace) CanCreateProjects() bool {
+	if w.Settings.ProjectCreation == ProjectCreationAdmins {
+		return w.Role.CanManage()
+	}
+	return w.Role.IsValid()
+}
+
+// Member is a user belonging to a workspace
+type Member struct {
+	WorkspaceID uuid.UUID `json:"workspaceId"`
+	UserID      uuid.UUID `json:"userId"`
+	Email       string    `json:"email"`
+	DisplayName string    `json:"displayName"`
+	Role        Role      `json:"role"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
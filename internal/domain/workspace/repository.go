@@ -0,0 +1,29 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines the interface for workspace data access.
+// Lookups taking a userID only return workspaces the user belongs to, with Workspace.Role set.
+type Repository interface {
+	// Create stores the workspace and makes ownerID its owner
+	Create(ctx context.Context, workspace *Workspace, ownerID uuid.UUID) error
+	Update(ctx context.Context, workspace *Workspace) error
+	// Delete fails while the workspace still holds projects
+	Delete(ctx context.Context, id uuid.UUID) error
+	FindByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*Workspace, error)
+	// FindPersonal returns nil when the user has no personal workspace yet
+	FindPersonal(ctx context.Context, userID uuid.UUID) (*Workspace, error)
+	FindAllByUserID(ctx context.Context, userID uuid.UUID) ([]Workspace, error)
+	// SettingsFor returns the settings of a workspace regardless of who is asking
+	SettingsFor(ctx context.Context, id uuid.UUID) (Settings, error)
+
+	ListMembers(ctx context.Context, workspaceID uuid.UUID) ([]Member, error)
+	FindMember(ctx context.Context, workspaceID, userID uuid.UUID) (*Member, error)
+	AddMember(ctx context.Context, member *Member) error
+	UpdateMemberRole(ctx context.Context, workspaceID, userID uuid.UUID, role Role) error
+	RemoveMember(ctx context.Context, workspaceID, userID uuid.UUID) error
+}
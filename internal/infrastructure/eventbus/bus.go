@@ -0,0 +1,116 @@
+// Package eventbus delivers domain events to subscribers inside the process.
+package eventbus
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+)
+
+// queueSize is how many events an asynchronous subscriber may fall behind before
+// publishers wait for it
+const queueSize = 256
+
+type subscriber struct {
+	name    string
+	handler event.Handler
+	queue   chan delivery // nil for synchronous subscribers
+}
+
+type delivery struct {
+	ctx   context.Context
+	event event.Event
+}
+
+// Bus is an in-process event.Bus. Subscribe everything before publishing.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers []*subscriber
+	closed      bool
+	wg          sync.WaitGroup
+}
+
+func New() *Bus {
+	return &Bus{}
+}
+
+func (b *Bus) Subscribe(name string, h event.Handler) {
+	b.add(&subscriber{name: name, handler: h})
+}
+
+func (b *Bus) SubscribeAsync(name string, h event.Handler) {
+	s := &subscriber{name: name, handler: h, queue: make(chan delivery, queueSize)}
+	b.add(s)
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		for d := range s.queue {
+			run(d.ctx, s, d.event)
+		}
+	}()
+}
+
+func (b *Bus) add(s *subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers = append(b.subscribers, s)
+}
+
+// Publish runs synchronous subscribers and queues the events for asynchronous ones.
+// Asynchronous subscribers keep the context's values but not its cancellation, so they
+// finish even when the request that published the event has ended.
+func (b *Bus) Publish(ctx context.Context, events ...event.Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		log.Printf("Dropped %d events published after the event bus closed", len(events))
+		return
+	}
+
+	background := context.WithoutCancel(ctx)
+	for _, e := range events {
+		name := e.EventName()
+		for _, s := range b.subscribers {
+			if s.name != name && s.name != event.All {
+				continue
+			}
+			if s.queue == nil {
+				run(ctx, s, e)
+			} else {
+				s.queue <- delivery{ctx: background, event: e}
+			}
+		}
+	}
+}
+
+// Close stops accepting events and waits for asynchronous subscribers to catch up
+func (b *Bus) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	for _, s := range b.subscribers {
+		if s.queue != nil {
+			close(s.queue)
+		}
+	}
+	b.mu.Unlock()
+
+	b.wg.Wait()
+}
+
+func run(ctx context.Context, s *subscriber, e event.Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Event handler for %s panicked on %s: %v", s.name, e.EventName(), r)
+		}
+	}()
+	if err := s.handler(ctx, e); err != nil {
+		log.Printf("Event handler for %s failed on %s: %v", s.name, e.EventName(), err)
+	}
+}
@@ -0,0 +1,201 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/audit"
+)
+
+const activityColumns = `
+	a.id, a.project_id, a.actor_id, COALESCE(NULLIF(u.display_name, ''), u.email, ''),
+	a.action, a.entity_type, a.entity_id, a.changes, a.request_id, a.created_at
+`
+
+const securityEventColumns = `
+	id, user_id, event, details, ip, user_agent, request_id, created_at
+`
+
+type AuditRepository struct {
+	db *sql.DB
+}
+
+func NewAuditRepository(db *sql.DB) *AuditRepository {
+	return &AuditRepository{db}
+}
+
+func (r *AuditRepository) AppendActivity(ctx context.Context, a *audit.Activity) error {
+	changesJSON, err := json.Marshal(a.Changes)
+	if err != nil {
+		return err
+	}
+	query := `
+		INSERT INTO project_activity (project_id, actor_id, action, entity_type, entity_id, changes, request_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		RETURNING id, created_at
+	`
+	return r.db.QueryRowContext(ctx, query,
+		a.ProjectID, a.ActorID, a.Action, a.EntityType, a.EntityID, changesJSON, a.RequestID,
+	).Scan(&a.ID, &a.CreatedAt)
+}
+
+func (r *AuditRepository) ListActivity(ctx context.Context, projectID uuid.UUID, filter audit.ActivityFilter, offset, limit int) ([]audit.Activity, int, error) {
+	conditions := []string{"a.project_id = $1"}
+	args := []any{projectID}
+	add := func(condition string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.ActorID != nil {
+		add("a.actor_id = $%d", *filter.ActorID)
+	}
+	if filter.EntityType != "" {
+		add("a.entity_type = $%d", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		add("a.entity_id = $%d", filter.EntityID)
+	}
+	if filter.Action != "" {
+		add("a.action = $%d", filter.Action)
+	}
+	if filter.From != nil {
+		add("a.created_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		add("a.created_at < $%d", *filter.To)
+	}
+	where := strings.Join(conditions, " AND ")
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM project_activity a WHERE `+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT `+activityColumns+`
+		FROM project_activity a
+		LEFT JOIN users u ON u.id = a.actor_id
+		WHERE %s
+		ORDER BY a.created_at DESC, a.id
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)+1, len(args)+2)
+	rows, err := r.db.QueryContext(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var activities []audit.Activity
+	for rows.Next() {
+		a, err := scanActivity(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		activities = append(activities, *a)
+	}
+	return activities, total, rows.Err()
+}
+
+func (r *AuditRepository) ListActivityByActor(ctx context.Context, actorID uuid.UUID, offset, limit int) ([]audit.Activity, int, error) {
+	var total int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM project_activity WHERE actor_id = $1`, actorID).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT ` + activityColumns + `
+		FROM project_activity a
+		LEFT JOIN users u ON u.id = a.actor_id
+		WHERE a.actor_id = $1
+		ORDER BY a.created_at DESC, a.id
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.QueryContext(ctx, query, actorID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var activities []audit.Activity
+	for rows.Next() {
+		a, err := scanActivity(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		activities = append(activities, *a)
+	}
+	return activities, total, rows.Err()
+}
+
+func (r *AuditRepository) AppendSecurityEvent(ctx context.Context, e *audit.SecurityEvent) error {
+	detailsJSON, err := json.Marshal(e.Details)
+	if err != nil {
+		return err
+	}
+	query := `
+		INSERT INTO security_events (user_id, event, details, ip, user_agent, request_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		RETURNING id, created_at
+	`
+	return r.db.QueryRowContext(ctx, query,
+		e.UserID, e.Event, detailsJSON, e.IP, e.UserAgent, e.RequestID,
+	).Scan(&e.ID, &e.CreatedAt)
+}
+
+func (r *AuditRepository) ListSecurityEvents(ctx context.Context, userID uuid.UUID, offset, limit int) ([]audit.SecurityEvent, int, error) {
+	var total int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM security_events WHERE user_id = $1`, userID).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT ` + securityEventColumns + `
+		FROM security_events
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var events []audit.SecurityEvent
+	for rows.Next() {
+		var e audit.SecurityEvent
+		var detailsJSON []byte
+		if err := rows.Scan(
+			&e.ID, &e.UserID, &e.Event, &detailsJSON, &e.IP, &e.UserAgent, &e.RequestID, &e.CreatedAt,
+		); err != nil {
+			return nil, 0, err
+		}
+		if err := json.Unmarshal(detailsJSON, &e.Details); err != nil {
+			return nil, 0, err
+		}
+		events = append(events, e)
+	}
+	return events, total, rows.Err()
+}
+
+func scanActivity(row rowScanner) (*audit.Activity, error) {
+	var a audit.Activity
+	var changesJSON []byte
+	err := row.Scan(
+		&a.ID, &a.ProjectID, &a.ActorID, &a.ActorName,
+		&a.Action, &a.EntityType, &a.EntityID, &changesJSON, &a.RequestID, &a.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(changesJSON, &a.Changes); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
@@ -0,0 +1,244 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+)
+
+const commentColumns = `
+	c.id, c.project_id, c.task_id, c.parent_id, c.author_id,
+	COALESCE(NULLIF(u.display_name, ''), u.email, ''), c.body, c.mentions,
+	c.edited_at, c.deleted_at, c.created_at, c.updated_at
+`
+
+type CommentRepository struct {
+	db *sql.DB
+}
+
+func NewCommentRepository(db *sql.DB) *CommentRepository {
+	return &CommentRepository{db}
+}
+
+func (r *CommentRepository) CreateComment(ctx context.Context, c *project.Comment) error {
+	mentionsJSON, err := json.Marshal(mentionList(c.MentionIDs))
+	if err != nil {
+		return err
+	}
+	query := `
+		INSERT INTO task_comments (project_id, task_id, parent_id, author_id, body, mentions, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+		RETURNING id, created_at, updated_at
+	`
+	return r.db.QueryRowContext(ctx, query,
+		c.ProjectID, c.TaskID, c.ParentID, c.AuthorID, c.Body, mentionsJSON,
+	).Scan(&c.ID, &c.CreatedAt, &c.UpdatedAt)
+}
+
+func (r *CommentRepository) FindComment(ctx context.Context, id uuid.UUID) (*project.Comment, error) {
+	query := `
+		SELECT ` + commentColumns + `
+		FROM task_comments c
+		LEFT JOIN users u ON u.id = c.author_id
+		WHERE c.id = $1
+	`
+	c, err := scanComment(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return c, err
+}
+
+func (r *CommentRepository) ListThreads(ctx context.Context, projectID uuid.UUID, taskID string, offset, limit int) ([]project.Comment, int, error) {
+	var total int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM task_comments
+		WHERE project_id = $1 AND task_id = $2 AND parent_id IS NULL
+	`, projectID, taskID).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT ` + commentColumns + `
+		FROM task_comments c
+		LEFT JOIN users u ON u.id = c.author_id
+		WHERE c.project_id = $1 AND c.task_id = $2 AND c.parent_id IS NULL
+		ORDER BY c.created_at, c.id
+		LIMIT $3 OFFSET $4
+	`
+	threads, err := r.queryComments(ctx, query, projectID, taskID, limit, offset)
+	if err != nil || len(threads) == 0 {
+		return threads, total, err
+	}
+
+	index := make(map[uuid.UUID]int, len(threads))
+	ids := make([]string, len(threads))
+	for i, t := range threads {
+		index[t.ID] = i
+		ids[i] = t.ID.String()
+	}
+
+	query = `
+		SELECT ` + commentColumns + `
+		FROM task_comments c
+		LEFT JOIN users u ON u.id = c.author_id
+		WHERE c.parent_id = ANY($1::uuid[])
+		ORDER BY c.created_at, c.id
+	`
+	replies, err := r.queryComments(ctx, query, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, reply := range replies {
+		i := index[*reply.ParentID]
+		threads[i].Replies = append(threads[i].Replies, reply)
+	}
+	return threads, total, nil
+}
+
+func (r *CommentRepository) UpdateComment(ctx context.Context, c *project.Comment, previousBody string, editedBy uuid.UUID) error {
+	mentionsJSON, err := json.Marshal(mentionList(c.MentionIDs))
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO task_comment_edits (comment_id, body, edited_by, edited_at)
+		VALUES ($1, $2, $3, NOW())
+	`, c.ID, previousBody, editedBy)
+	if err != nil {
+		return err
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		UPDATE task_comments SET body = $2, mentions = $3, edited_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING edited_at, updated_at
+	`, c.ID, c.Body, mentionsJSON).Scan(&c.EditedAt, &c.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return project.ErrCommentNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *CommentRepository) DeleteComment(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE task_comments SET body = '', mentions = '[]', deleted_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+	`, id)
+	if err != nil {
+		return err
+	}
+	if err := expectOneRow(result, project.ErrCommentNotFound); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM task_comment_edits WHERE comment_id = $1`, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *CommentRepository) DeleteTaskComments(ctx context.Context, projectID uuid.UUID, taskID string) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM task_comments WHERE project_id = $1 AND task_id = $2
+	`, projectID, taskID)
+	return err
+}
+
+func (r *CommentRepository) ListEdits(ctx context.Context, commentID uuid.UUID) ([]project.CommentEdit, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, comment_id, body, edited_by, edited_at
+		FROM task_comment_edits
+		WHERE comment_id = $1
+		ORDER BY edited_at DESC
+	`, commentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var edits []project.CommentEdit
+	for rows.Next() {
+		var e project.CommentEdit
+		if err := rows.Scan(&e.ID, &e.CommentID, &e.Body, &e.EditedBy, &e.EditedAt); err != nil {
+			return nil, err
+		}
+		edits = append(edits, e)
+	}
+	return edits, rows.Err()
+}
+
+func (r *CommentRepository) ListByAuthor(ctx context.Context, authorID uuid.UUID) ([]project.Comment, error) {
+	query := `
+		SELECT ` + commentColumns + `
+		FROM task_comments c
+		LEFT JOIN users u ON u.id = c.author_id
+		WHERE c.author_id = $1 AND c.deleted_at IS NULL
+		ORDER BY c.created_at, c.id
+	`
+	return r.queryComments(ctx, query, authorID)
+}
+
+func (r *CommentRepository) queryComments(ctx context.Context, query string, args ...any) ([]project.Comment, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var comments []project.Comment
+	for rows.Next() {
+		c, err := scanComment(rows)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, *c)
+	}
+	return comments, rows.Err()
+}
+
+func scanComment(row rowScanner) (*project.Comment, error) {
+	var c project.Comment
+	var mentionsJSON []byte
+	err := row.Scan(
+		&c.ID, &c.ProjectID, &c.TaskID, &c.ParentID, &c.AuthorID,
+		&c.AuthorName, &c.Body, &mentionsJSON,
+		&c.EditedAt, &c.DeletedAt, &c.CreatedAt, &c.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(mentionsJSON, &c.MentionIDs); err != nil {
+		return nil, err
+	}
+	c.MentionIDs = mentionList(c.MentionIDs)
+	return &c, nil
+}
+
+// mentionList keeps the mentions a JSON array rather than null
+func mentionList(ids []string) []string {
+	if ids == nil {
+		return []string{}
+	}
+	return ids
+}
@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+)
+
+const invitationColumns = `
+	i.id, i.project_id, p.name, i.email, i.role, i.token, i.invited_by,
+	i.expires_at, i.accepted_at, i.declined_at, i.created_at
+`
+
+type InvitationRepository struct {
+	db *sql.DB
+}
+
+func NewInvitationRepository(db *sql.DB) *InvitationRepository {
+	return &InvitationRepository{db}
+}
+
+func (r *InvitationRepository) Create(ctx context.Context, i *project.Invitation) error {
+	query := `
+		INSERT INTO project_invitations (project_id, email, role, token, invited_by, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		RETURNING id, created_at
+	`
+	return r.db.QueryRowContext(ctx, query,
+		i.ProjectID, i.Email, i.Role, i.Token, i.InvitedBy, i.ExpiresAt,
+	).Scan(&i.ID, &i.CreatedAt)
+}
+
+func (r *InvitationRepository) FindByToken(ctx context.Context, token string) (*project.Invitation, error) {
+	query := `
+		SELECT ` + invitationColumns + `
+		FROM project_invitations i
+		JOIN projects p ON p.id = i.project_id
+		WHERE i.token = $1
+	`
+	i, err := scanInvitation(r.db.QueryRowContext(ctx, query, token))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return i, err
+}
+
+func (r *InvitationRepository) ListByProject(ctx context.Context, projectID uuid.UUID) ([]project.Invitation, error) {
+	query := `
+		SELECT ` + invitationColumns + `
+		FROM project_invitations i
+		JOIN projects p ON p.id = i.project_id
+		WHERE i.project_id = $1
+		ORDER BY i.created_at DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invitations []project.Invitation
+	for rows.Next() {
+		i, err := scanInvitation(rows)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, *i)
+	}
+	return invitations, rows.Err()
+}
+
+// MarkAccepted only succeeds for an invitation that has not been answered yet
+func (r *InvitationRepository) MarkAccepted(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE project_invitations SET accepted_at = NOW()
+		WHERE id = $1 AND accepted_at IS NULL AND declined_at IS NULL
+	`, id)
+	if err != nil {
+		return err
+	}
+	return expectOneRow(result, project.ErrInvalidInvitation)
+}
+
+func (r *InvitationRepository) MarkDeclined(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE project_invitations SET declined_at = NOW()
+		WHERE id = $1 AND accepted_at IS NULL AND declined_at IS NULL
+	`, id)
+	if err != nil {
+		return err
+	}
+	return expectOneRow(result, project.ErrInvalidInvitation)
+}
+
+func scanInvitation(row rowScanner) (*project.Invitation, error) {
+	var i project.Invitation
+	err := row.Scan(
+		&i.ID, &i.ProjectID, &i.ProjectName, &i.Email, &i.Role, &i.Token, &i.InvitedBy,
+		&i.ExpiresAt, &i.AcceptedAt, &i.DeclinedAt, &i.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
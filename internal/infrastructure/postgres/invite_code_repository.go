@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+)
+
+type InviteCodeRepository struct {
+	db *sql.DB
+}
+
+func NewInviteCodeRepository(db *sql.DB) *InviteCodeRepository {
+	return &InviteCodeRepository{db}
+}
+
+func (r *InviteCodeRepository) Create(ctx context.Context, c *user.InviteCode) error {
+	query := `
+		INSERT INTO invite_codes (code, email, max_uses, expires_at, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		RETURNING id, uses, created_at
+	`
+	return r.db.QueryRowContext(ctx, query,
+		c.Code, c.Email, c.MaxUses, c.ExpiresAt, c.CreatedBy,
+	).Scan(&c.ID, &c.Uses, &c.CreatedAt)
+}
+
+func (r *InviteCodeRepository) FindByCode(ctx context.Context, code string) (*user.InviteCode, error) {
+	var c user.InviteCode
+	var createdBy sql.NullString
+	query := `
+		SELECT id, code, email, max_uses, uses, expires_at, revoked_at, created_by, created_at
+		FROM invite_codes WHERE code = $1
+	`
+	err := r.db.QueryRowContext(ctx, query, code).Scan(
+		&c.ID, &c.Code, &c.Email, &c.MaxUses, &c.Uses, &c.ExpiresAt, &c.RevokedAt, &createdBy, &c.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.CreatedBy = createdBy.String
+	return &c, nil
+}
+
+func (r *InviteCodeRepository) List(ctx context.Context) ([]user.InviteCode, error) {
+	query := `
+		SELECT id, code, email, max_uses, uses, expires_at, revoked_at, created_by, created_at
+		FROM invite_codes ORDER BY created_at DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var codes []user.InviteCode
+	for rows.Next() {
+		var c user.InviteCode
+		var createdBy sql.NullString
+		if err := rows.Scan(
+			&c.ID, &c.Code, &c.Email, &c.MaxUses, &c.Uses, &c.ExpiresAt, &c.RevokedAt, &createdBy, &c.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		c.CreatedBy = createdBy.String
+		codes = append(codes, c)
+	}
+	return codes, rows.Err()
+}
+
+func (r *InviteCodeRepository) Redeem(ctx context.Context, id string) error {
+	// Checked and incremented in one statement so concurrent sign-ups can't overuse a code
+	query := `
+		UPDATE invite_codes SET uses = uses + 1
+		WHERE id = $1 AND uses < max_uses AND revoked_at IS NULL
+		  AND (expires_at IS NULL OR expires_at > NOW())
+	`
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return user.ErrInvalidInviteCode
+	}
+	return nil
+}
+
+func (r *InviteCodeRepository) Revoke(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE invite_codes SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL`, id)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.New("invite code not found or already revoked")
+	}
+	return nil
+}
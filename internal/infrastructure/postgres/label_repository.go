@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/label"
+)
+
+const labelColumns = `id, workspace_id, name, color, created_at, updated_at`
+
+type LabelRepository struct {
+	db *sql.DB
+}
+
+func NewLabelRepository(db *sql.DB) *LabelRepository {
+	return &LabelRepository{db}
+}
+
+func (r *LabelRepository) Create(ctx context.Context, l *label.Label) error {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO labels (workspace_id, name, color, created_at, updated_at)
+		VALUES ($1, $2, $3, NOW(), NOW())
+		RETURNING id, created_at, updated_at
+	`, l.WorkspaceID, l.Name, l.Color).Scan(&l.ID, &l.CreatedAt, &l.UpdatedAt)
+	return mapLabelWriteError(err)
+}
+
+func (r *LabelRepository) Update(ctx context.Context, l *label.Label) error {
+	err := r.db.QueryRowContext(ctx, `
+		UPDATE labels SET name = $1, color = $2, updated_at = NOW()
+		WHERE id = $3
+		RETURNING updated_at
+	`, l.Name, l.Color, l.ID).Scan(&l.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return label.ErrNotFound
+	}
+	return mapLabelWriteError(err)
+}
+
+// Delete removes the label; project labels go with it, and it is taken out of the labelIds
+// of every task in the workspace without starting a new project revision
+func (r *LabelRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var workspaceID uuid.UUID
+	err = tx.QueryRowContext(ctx, `DELETE FROM labels WHERE id = $1 RETURNING workspace_id`, id).Scan(&workspaceID)
+	if err == sql.ErrNoRows {
+		return label.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	// Label IDs are stored as JSON strings, so the ID is passed as text
+	_, err = tx.ExecContext(ctx, `
+		UPDATE projects p
+		SET tasks = (
+		    SELECT jsonb_agg(
+		        CASE WHEN t.task ? 'labelIds'
+		             THEN jsonb_set(t.task, '{labelIds}', (t.task->'labelIds') - $1::text)
+		             ELSE t.task
+		        END ORDER BY t.ord)
+		    FROM jsonb_array_elements(p.tasks) WITH ORDINALITY AS t(task, ord)
+		)
+		WHERE p.workspace_id = $2
+		  AND EXISTS (
+		      SELECT 1 FROM jsonb_array_elements(p.tasks) AS t(task)
+		      WHERE t.task->'labelIds' ? $1::text
+		  )
+	`, id.String(), workspaceID)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *LabelRepository) FindByID(ctx context.Context, id uuid.UUID) (*label.Label, error) {
+	l, err := scanLabel(r.db.QueryRowContext(ctx, `SELECT `+labelColumns+` FROM labels WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return l, err
+}
+
+func (r *LabelRepository) ListByWorkspace(ctx context.Context, workspaceID uuid.UUID) ([]label.Label, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+labelColumns+`
+		FROM labels
+		WHERE workspace_id = $1
+		ORDER BY LOWER(name), id
+	`, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var labels []label.Label
+	for rows.Next() {
+		l, err := scanLabel(rows)
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, *l)
+	}
+	return labels, rows.Err()
+}
+
+func scanLabel(row rowScanner) (*label.Label, error) {
+	var l label.Label
+	if err := row.Scan(&l.ID, &l.WorkspaceID, &l.Name, &l.Color, &l.CreatedAt, &l.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// mapLabelWriteError turns a violation of the per-workspace name index into label.ErrNameTaken
+func mapLabelWriteError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return label.ErrNameTaken
+	}
+	return err
+}
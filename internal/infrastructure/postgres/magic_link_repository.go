@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+)
+
+type MagicLinkRepository struct {
+	db *sql.DB
+}
+
+func NewMagicLinkRepository(db *sql.DB) *MagicLinkRepository {
+	return &MagicLinkRepository{db}
+}
+
+func (r *MagicLinkRepository) Create(ctx context.Context, l *user.MagicLink) error {
+	query := `
+		INSERT INTO magic_links (user_id, email, token_hash, device_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		RETURNING id, created_at
+	`
+	return r.db.QueryRowContext(ctx, query,
+		l.UserID, l.Email, l.TokenHash, l.DeviceHash, l.ExpiresAt,
+	).Scan(&l.ID, &l.CreatedAt)
+}
+
+func (r *MagicLinkRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*user.MagicLink, error) {
+	var l user.MagicLink
+	query := `
+		SELECT id, user_id, email, token_hash, device_hash, expires_at, used_at, created_at
+		FROM magic_links WHERE token_hash = $1
+	`
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(
+		&l.ID, &l.UserID, &l.Email, &l.TokenHash, &l.DeviceHash, &l.ExpiresAt, &l.UsedAt, &l.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+func (r *MagicLinkRepository) MarkUsed(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE magic_links SET used_at = NOW() WHERE id = $1 AND used_at IS NULL`, id)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return user.ErrInvalidMagicLink
+	}
+	return nil
+}
+
+func (r *MagicLinkRepository) RecordAttempt(ctx context.Context, email, ip string) error {
+	// Attempts only matter for an hour, so day-old ones are cleared out on the way
+	query := `
+		WITH pruned AS (
+			DELETE FROM magic_link_attempts WHERE created_at < NOW() - INTERVAL '1 day'
+		)
+		INSERT INTO magic_link_attempts (email, ip, created_at) VALUES ($1, $2, NOW())
+	`
+	_, err := r.db.ExecContext(ctx, query, email, ip)
+	return err
+}
+
+func (r *MagicLinkRepository) CountRecentAttempts(ctx context.Context, email, ip string, since time.Time) (int, int, error) {
+	var byEmail, byIP int
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM magic_link_attempts WHERE LOWER(email) = LOWER($1) AND created_at >= $3),
+			(SELECT COUNT(*) FROM magic_link_attempts WHERE ip = $2 AND created_at >= $3)
+	`
+	err := r.db.QueryRowContext(ctx, query, email, ip, since).Scan(&byEmail, &byIP)
+	return byEmail, byIP, err
+}
@@ -0,0 +1,179 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/notification"
+)
+
+const notificationColumns = `
+	id, user_id, type, title, body, project_id, task_id, data, read_at, created_at
+`
+
+type NotificationRepository struct {
+	db *sql.DB
+}
+
+func NewNotificationRepository(db *sql.DB) *NotificationRepository {
+	return &NotificationRepository{db}
+}
+
+func (r *NotificationRepository) Create(ctx context.Context, n *notification.Notification) error {
+	dataJSON, err := json.Marshal(n.Data)
+	if err != nil {
+		return err
+	}
+	var taskID *string
+	if n.TaskID != "" {
+		taskID = &n.TaskID
+	}
+	query := `
+		INSERT INTO notifications (user_id, type, title, body, project_id, task_id, data, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		RETURNING id, created_at
+	`
+	return r.db.QueryRowContext(ctx, query,
+		n.UserID, n.Type, n.Title, n.Body, n.ProjectID, taskID, dataJSON,
+	).Scan(&n.ID, &n.CreatedAt)
+}
+
+func (r *NotificationRepository) List(ctx context.Context, userID uuid.UUID, unreadOnly bool, offset, limit int) ([]notification.Notification, int, error) {
+	where := "user_id = $1"
+	if unreadOnly {
+		where += " AND read_at IS NULL"
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM notifications WHERE `+where, userID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT ` + notificationColumns + `
+		FROM notifications
+		WHERE ` + where + `
+		ORDER BY created_at DESC, id
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var notifications []notification.Notification
+	for rows.Next() {
+		n, err := scanNotification(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		notifications = append(notifications, *n)
+	}
+	return notifications, total, rows.Err()
+}
+
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL
+	`, userID).Scan(&count)
+	return count, err
+}
+
+func (r *NotificationRepository) MarkRead(ctx context.Context, userID, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE notifications SET read_at = COALESCE(read_at, NOW())
+		WHERE id = $1 AND user_id = $2
+	`, id, userID)
+	if err != nil {
+		return err
+	}
+	return expectOneRow(result, notification.ErrNotFound)
+}
+
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID uuid.UUID) (int, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE notifications SET read_at = NOW()
+		WHERE user_id = $1 AND read_at IS NULL
+	`, userID)
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	return int(n), err
+}
+
+func (r *NotificationRepository) Preferences(ctx context.Context, userID uuid.UUID) ([]notification.Preference, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT type, in_app, email FROM notification_preferences WHERE user_id = $1
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var prefs []notification.Preference
+	for rows.Next() {
+		var p notification.Preference
+		if err := rows.Scan(&p.Type, &p.InApp, &p.Email); err != nil {
+			return nil, err
+		}
+		prefs = append(prefs, p)
+	}
+	return prefs, rows.Err()
+}
+
+func (r *NotificationRepository) SavePreferences(ctx context.Context, userID uuid.UUID, prefs []notification.Preference) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, p := range prefs {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO notification_preferences (user_id, type, in_app, email, updated_at)
+			VALUES ($1, $2, $3, $4, NOW())
+			ON CONFLICT (user_id, type) DO UPDATE
+			SET in_app = EXCLUDED.in_app, email = EXCLUDED.email, updated_at = NOW()
+		`, userID, p.Type, p.InApp, p.Email)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *NotificationRepository) ClaimDueSoon(ctx context.Context, userID, projectID uuid.UUID, taskID string, dueDate time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO task_due_reminders (user_id, project_id, task_id, due_date, sent_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT DO NOTHING
+	`, userID, projectID, taskID, dueDate)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n == 1, err
+}
+
+func scanNotification(row rowScanner) (*notification.Notification, error) {
+	var n notification.Notification
+	var taskID sql.NullString
+	var dataJSON []byte
+	err := row.Scan(
+		&n.ID, &n.UserID, &n.Type, &n.Title, &n.Body, &n.ProjectID, &taskID, &dataJSON, &n.ReadAt, &n.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	n.TaskID = taskID.String
+	if err := json.Unmarshal(dataJSON, &n.Data); err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
@@ -5,11 +5,28 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
 )
 
+// projectColumns are selected from "projects p" joined with the caller's membership "m"
+const projectColumns = `
+	p.id, p.workspace_id, p.user_id, p.name, p.description, p.status, p.progress,
+	p.start_date, p.end_date, p.tasks, p.documents, p.workflow, p.progress_settings,
+	p.revision, p.created_at, p.updated_at,
+	COALESCE((
+		SELECT jsonb_agg(pl.label_id ORDER BY LOWER(l.name), l.id)
+		FROM project_labels pl
+		JOIN labels l ON l.id = pl.label_id
+		WHERE pl.project_id = p.id
+	), '[]'),
+	m.role
+`
+
 type ProjectRepository struct {
 	db *sql.DB
 }
@@ -19,6 +36,7 @@ func NewProjectRepository(db *sql.DB) *ProjectRepository {
 }
 
 func (r *ProjectRepository) Create(ctx context.Context, p *project.Project) error {
+	project.RankTasks(p.Tasks)
 	tasksJSON, err := json.Marshal(p.Tasks)
 	if err != nil {
 		return err
@@ -29,19 +47,59 @@ func (r *ProjectRepository) Create(ctx context.Context, p *project.Project) erro
 		return err
 	}
 
+	workflowJSON, err := json.Marshal(p.Workflow)
+	if err != nil {
+		return err
+	}
+
+	progressJSON, err := json.Marshal(p.ProgressSettings)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	query := `
-		INSERT INTO projects (user_id, name, description, status, progress, start_date, end_date, tasks, documents, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW())
-		RETURNING id, created_at, updated_at
+		INSERT INTO projects (workspace_id, user_id, name, description, status, progress, start_date, end_date, tasks, documents, workflow, progress_settings, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NOW(), NOW())
+		RETURNING id, revision, created_at, updated_at
 	`
 
-	return r.db.QueryRowContext(ctx, query,
-		p.UserID, p.Name, p.Description, p.Status, p.Progress,
-		p.StartDate, p.EndDate, tasksJSON, documentsJSON,
-	).Scan(&p.ID, &p.CreatedAt, &p.UpdatedAt)
+	err = tx.QueryRowContext(ctx, query,
+		p.WorkspaceID, p.UserID, p.Name, p.Description, p.Status, p.Progress,
+		p.StartDate, p.EndDate, tasksJSON, documentsJSON, workflowJSON, progressJSON,
+	).Scan(&p.ID, &p.Revision, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if err := saveRevision(ctx, tx, p, p.UserID); err != nil {
+		return err
+	}
+	if err := saveProjectLabels(ctx, tx, p); err != nil {
+		return err
+	}
+
+	// The creator is the first owner
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO project_members (project_id, user_id, role, created_at) VALUES ($1, $2, $3, NOW())`,
+		p.ID, p.UserID, project.RoleOwner,
+	)
+	if err != nil {
+		return err
+	}
+	p.Role = project.RoleOwner
+
+	return tx.Commit()
 }
 
-func (r *ProjectRepository) Update(ctx context.Context, p *project.Project) error {
+// Update saves the project and keeps the new state as its next revision
+func (r *ProjectRepository) Update(ctx context.Context, p *project.Project, actorID uuid.UUID) error {
+	// The list order is what callers changed; ranks follow it
+	project.RankTasks(p.Tasks)
 	tasksJSON, err := json.Marshal(p.Tasks)
 	if err != nil {
 		return err
@@ -52,30 +110,247 @@ func (r *ProjectRepository) Update(ctx context.Context, p *project.Project) erro
 		return err
 	}
 
+	workflowJSON, err := json.Marshal(p.Workflow)
+	if err != nil {
+		return err
+	}
+
+	progressJSON, err := json.Marshal(p.ProgressSettings)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	query := `
 		UPDATE projects
 		SET name = $1, description = $2, status = $3, progress = $4,
-		    start_date = $5, end_date = $6, tasks = $7, documents = $8, updated_at = NOW()
-		WHERE id = $9 AND user_id = $10
-		RETURNING updated_at
+		    start_date = $5, end_date = $6, tasks = $7, documents = $8, workflow = $9,
+		    progress_settings = $10, revision = revision + 1, updated_at = NOW()
+		WHERE id = $11 AND revision = $12
+		RETURNING revision, updated_at
 	`
 
-	result := r.db.QueryRowContext(ctx, query,
+	result := tx.QueryRowContext(ctx, query,
 		p.Name, p.Description, p.Status, p.Progress,
-		p.StartDate, p.EndDate, tasksJSON, documentsJSON,
-		p.ID, p.UserID,
+		p.StartDate, p.EndDate, tasksJSON, documentsJSON, workflowJSON, progressJSON,
+		p.ID, p.Revision,
 	)
 
-	err = result.Scan(&p.UpdatedAt)
+	err = result.Scan(&p.Revision, &p.UpdatedAt)
 	if err == sql.ErrNoRows {
-		return errors.New("project not found or access denied")
+		var exists bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM projects WHERE id = $1)`, p.ID).Scan(&exists); err != nil {
+			return err
+		}
+		if exists {
+			return project.ErrConflict
+		}
+		return errors.New("project not found")
+	}
+	if err != nil {
+		return err
+	}
+	if err := saveRevision(ctx, tx, p, actorID); err != nil {
+		return err
+	}
+	if err := saveProjectLabels(ctx, tx, p); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *ProjectRepository) RankTask(ctx context.Context, projectID uuid.UUID, taskID, afterID, beforeID string, actorID uuid.UUID) (*project.Project, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// The lock makes concurrent moves wait for each other, so each sees the ranks the one
+	// before it gave out
+	var tasksJSON []byte
+	err = tx.QueryRowContext(ctx, `SELECT tasks FROM projects WHERE id = $1 FOR UPDATE`, projectID).Scan(&tasksJSON)
+	if err == sql.ErrNoRows {
+		return nil, errors.New("project not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	var tasks []project.Task
+	if err := json.Unmarshal(tasksJSON, &tasks); err != nil {
+		return nil, err
+	}
+	project.SortByRank(tasks)
+	if err := project.PlaceBetween(tasks, taskID, afterID, beforeID); err != nil {
+		return nil, err
+	}
+	p, err := saveTasks(ctx, tx, projectID, tasks, actorID)
+	if err != nil {
+		return nil, err
+	}
+	return p, tx.Commit()
+}
+
+// saveTasks replaces the tasks of a project locked by tx and saves it as a new revision by
+// actorID, who must be a member. It returns the project as saved.
+func saveTasks(ctx context.Context, tx *sql.Tx, projectID uuid.UUID, tasks []project.Task, actorID uuid.UUID) (*project.Project, error) {
+	tasksJSON, err := json.Marshal(tasks)
+	if err != nil {
+		return nil, err
+	}
+	_, err = tx.ExecContext(ctx, `
+		UPDATE projects SET tasks = $2, revision = revision + 1, updated_at = NOW() WHERE id = $1
+	`, projectID, tasksJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT ` + projectColumns + `
+		FROM projects p
+		JOIN project_members m ON m.project_id = p.id AND m.user_id = $2
+		WHERE p.id = $1
+	`
+	p, err := scanProject(tx.QueryRowContext(ctx, query, projectID, actorID))
+	if err != nil {
+		return nil, err
+	}
+	if err := saveRevision(ctx, tx, p, actorID); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// unassignUser takes userID off every task it is assigned to, in projectID only unless it is
+// nil, saving each project it changes as a new revision by actorID. actorID must be a member
+// of those projects; projects they are not a member of are left alone.
+func unassignUser(ctx context.Context, tx *sql.Tx, projectID *uuid.UUID, userID, actorID uuid.UUID) error {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT p.id, p.tasks FROM projects p
+		WHERE ($1::uuid IS NULL OR p.id = $1)
+		  AND EXISTS (SELECT 1 FROM project_members m WHERE m.project_id = p.id AND m.user_id = $3)
+		  AND EXISTS (
+		      SELECT 1 FROM jsonb_array_elements(p.tasks) AS t(task)
+		      WHERE t.task->'assigneeIds' ? $2
+		  )
+		FOR UPDATE
+	`, projectID, userID.String(), actorID)
+	if err != nil {
+		return err
+	}
+	unassigned := make(map[uuid.UUID][]project.Task)
+	for rows.Next() {
+		var id uuid.UUID
+		var tasksJSON []byte
+		if err := rows.Scan(&id, &tasksJSON); err != nil {
+			rows.Close()
+			return err
+		}
+		var tasks []project.Task
+		if err := json.Unmarshal(tasksJSON, &tasks); err != nil {
+			rows.Close()
+			return err
+		}
+		for i := range tasks {
+			tasks[i].KeepAssignees(func(id string) bool { return id != userID.String() })
+		}
+		unassigned[id] = tasks
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for id, tasks := range unassigned {
+		if _, err := saveTasks(ctx, tx, id, tasks, actorID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ProjectRepository) SpreadLongRanks(ctx context.Context) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
 	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, tasks FROM projects
+		WHERE EXISTS (
+		    SELECT 1 FROM jsonb_array_elements(tasks) AS t(task)
+		    WHERE length(t.task->>'rank') > $1
+		)
+		FOR UPDATE
+	`, project.MaxRankLength)
+	if err != nil {
+		return 0, err
+	}
+	spread := make(map[uuid.UUID][]project.Task)
+	for rows.Next() {
+		var id uuid.UUID
+		var tasksJSON []byte
+		if err := rows.Scan(&id, &tasksJSON); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		var tasks []project.Task
+		if err := json.Unmarshal(tasksJSON, &tasks); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		project.SortByRank(tasks)
+		project.SpreadRanks(tasks)
+		spread[id] = tasks
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for id, tasks := range spread {
+		tasksJSON, err := json.Marshal(tasks)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE projects SET tasks = $2 WHERE id = $1`, id, tasksJSON); err != nil {
+			return 0, err
+		}
+	}
+	return len(spread), tx.Commit()
+}
+
+// saveProjectLabels replaces the project's labels with p.LabelIDs
+func saveProjectLabels(ctx context.Context, tx *sql.Tx, p *project.Project) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM project_labels WHERE project_id = $1`, p.ID); err != nil {
+		return err
+	}
+	if len(p.LabelIDs) == 0 {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO project_labels (project_id, label_id)
+		SELECT $1, id FROM labels WHERE id = ANY($2::uuid[])
+		ON CONFLICT DO NOTHING
+	`, p.ID, p.LabelIDs)
 	return err
 }
 
 func (r *ProjectRepository) Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
-	query := `DELETE FROM projects WHERE id = $1 AND user_id = $2`
-	result, err := r.db.ExecContext(ctx, query, id, userID)
+	query := `
+		DELETE FROM projects
+		WHERE id = $1 AND EXISTS (
+			SELECT 1 FROM project_members
+			WHERE project_id = $1 AND user_id = $2 AND role = $3
+		)
+	`
+	result, err := r.db.ExecContext(ctx, query, id, userID, project.RoleOwner)
 	if err != nil {
 		return err
 	}
@@ -92,84 +367,378 @@ func (r *ProjectRepository) Delete(ctx context.Context, id uuid.UUID, userID uui
 	return nil
 }
 
-func (r *ProjectRepository) FindByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*project.Project, error) {
-	var p project.Project
-	var tasksJSON, documentsJSON []byte
+// successors lists, for every project, the member other than $1 who takes over from them:
+// an owner if there is one, else the longest-standing editor, else viewer
+const successors = `
+	SELECT DISTINCT ON (project_id) project_id, user_id
+	FROM project_members
+	WHERE user_id <> $1
+	ORDER BY project_id, CASE role WHEN 'owner' THEN 0 WHEN 'editor' THEN 1 ELSE 2 END, created_at
+`
+
+// removeUserFromProjects is part of purging an account. Projects only the user is a member of
+// are deleted. Where the user was the last owner their successor becomes one, projects the user
+// created are credited to the successor, and projects in the user's personal workspace move to
+// the successor's, which is created if need be, since personal workspaces go with their owner.
+// The user is taken off the tasks of the projects that stay.
+func removeUserFromProjects(ctx context.Context, tx *sql.Tx, userID uuid.UUID) error {
+	// While the user is still a member, so the revisions can be credited to them
+	if err := unassignUser(ctx, tx, nil, userID, userID); err != nil {
+		return err
+	}
+
+	statements := []string{
+		`DELETE FROM projects p
+		 WHERE (p.user_id = $1 OR EXISTS (
+		         SELECT 1 FROM project_members m WHERE m.project_id = p.id AND m.user_id = $1))
+		   AND NOT EXISTS (SELECT 1 FROM project_members m WHERE m.project_id = p.id AND m.user_id <> $1)`,
+		`UPDATE project_members m SET role = 'owner'
+		 FROM (` + successors + `) s
+		 WHERE m.project_id = s.project_id AND m.user_id = s.user_id
+		   AND s.project_id IN (SELECT project_id FROM project_members WHERE user_id = $1 AND role = 'owner')`,
+		`UPDATE projects p SET user_id = s.user_id
+		 FROM (` + successors + `) s
+		 WHERE p.id = s.project_id AND p.user_id = $1`,
+		`INSERT INTO workspaces (name, personal_owner_id, settings, created_at, updated_at)
+		 SELECT DISTINCT ON (p.user_id) 'Personal', p.user_id, w.settings, NOW(), NOW()
+		 FROM projects p
+		 JOIN workspaces w ON w.id = p.workspace_id AND w.personal_owner_id = $1
+		 ON CONFLICT (personal_owner_id) DO NOTHING`,
+		`INSERT INTO workspace_members (workspace_id, user_id, role, created_at)
+		 SELECT w.id, w.personal_owner_id, 'owner', NOW()
+		 FROM workspaces w
+		 WHERE w.personal_owner_id IN (
+		     SELECT p.user_id FROM projects p
+		     JOIN workspaces uw ON uw.id = p.workspace_id AND uw.personal_owner_id = $1)
+		 ON CONFLICT DO NOTHING`,
+		`UPDATE projects p SET workspace_id = w.id
+		 FROM workspaces uw, workspaces w
+		 WHERE p.workspace_id = uw.id AND uw.personal_owner_id = $1 AND w.personal_owner_id = p.user_id`,
+		`DELETE FROM project_members WHERE user_id = $1`,
+	}
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ProjectRepository) CountByStatusForUser(ctx context.Context, userID uuid.UUID) (map[project.ProjectStatus]int, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT status, COUNT(*) FROM projects WHERE user_id = $1 GROUP BY status`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[project.ProjectStatus]int)
+	for rows.Next() {
+		var status project.ProjectStatus
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, err
+		}
+		counts[status] = count
+	}
+	return counts, rows.Err()
+}
 
+func (r *ProjectRepository) FindByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*project.Project, error) {
 	query := `
-		SELECT id, user_id, name, description, status, progress,
-		       start_date, end_date, tasks, documents, created_at, updated_at
-		FROM projects
-		WHERE id = $1 AND user_id = $2
+		SELECT ` + projectColumns + `
+		FROM projects p
+		JOIN project_members m ON m.project_id = p.id AND m.user_id = $2
+		WHERE p.id = $1
 	`
 
-	err := r.db.QueryRowContext(ctx, query, id, userID).Scan(
-		&p.ID, &p.UserID, &p.Name, &p.Description, &p.Status, &p.Progress,
-		&p.StartDate, &p.EndDate, &tasksJSON, &documentsJSON,
-		&p.CreatedAt, &p.UpdatedAt,
-	)
-
+	p, err := scanProject(r.db.QueryRowContext(ctx, query, id, userID))
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, err
 	}
+	return p, nil
+}
 
-	if err := json.Unmarshal(tasksJSON, &p.Tasks); err != nil {
+func (r *ProjectRepository) FindAllByUserID(ctx context.Context, userID uuid.UUID) ([]project.Project, error) {
+	query := `
+		SELECT ` + projectColumns + `
+		FROM projects p
+		JOIN project_members m ON m.project_id = p.id AND m.user_id = $1
+		ORDER BY p.created_at DESC
+	`
+
+	return r.queryProjects(ctx, query, userID)
+}
+
+func (r *ProjectRepository) FindAllOwnedBy(ctx context.Context, userID uuid.UUID) ([]project.Project, error) {
+	query := `
+		SELECT ` + projectColumns + `
+		FROM projects p
+		JOIN project_members m ON m.project_id = p.id AND m.user_id = $1
+		WHERE m.role = $2
+		ORDER BY p.created_at DESC
+	`
+	return r.queryProjects(ctx, query, userID, project.RoleOwner)
+}
+
+func (r *ProjectRepository) FindAllInWorkspace(ctx context.Context, workspaceID uuid.UUID, userID uuid.UUID) ([]project.Project, error) {
+	query := `
+		SELECT ` + projectColumns + `
+		FROM projects p
+		JOIN project_members m ON m.project_id = p.id AND m.user_id = $2
+		WHERE p.workspace_id = $1
+		   -- Projects shared from workspaces the user is not part of show up in their personal workspace
+		   OR (
+		       EXISTS (SELECT 1 FROM workspaces pw WHERE pw.id = $1 AND pw.personal_owner_id = $2)
+		       AND NOT EXISTS (
+		           SELECT 1 FROM workspace_members wm
+		           WHERE wm.workspace_id = p.workspace_id AND wm.user_id = $2
+		       )
+		   )
+		ORDER BY p.created_at DESC
+	`
+	return r.queryProjects(ctx, query, workspaceID, userID)
+}
+
+func (r *ProjectRepository) queryProjects(ctx context.Context, query string, args ...any) ([]project.Project, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	if err := json.Unmarshal(documentsJSON, &p.Documents); err != nil {
+	var projects []project.Project
+	for rows.Next() {
+		p, err := scanProject(rows)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, *p)
+	}
+
+	if err = rows.Err(); err != nil {
 		return nil, err
 	}
 
-	return &p, nil
+	return projects, nil
 }
 
-func (r *ProjectRepository) FindAllByUserID(ctx context.Context, userID uuid.UUID) ([]project.Project, error) {
+func (r *ProjectRepository) FindTasksAssignedTo(ctx context.Context, userID uuid.UUID, f project.TaskFilter) ([]project.AssignedTask, error) {
+	// Assignee IDs are stored as JSON strings, so the ID is passed as text as well
+	args := []any{userID, userID.String()}
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	where := []string{"t.task->'assigneeIds' ? $2"}
+	if len(f.Statuses) > 0 {
+		statuses := make([]string, len(f.Statuses))
+		for i, s := range f.Statuses {
+			statuses[i] = string(s)
+		}
+		where = append(where, "t.task->>'status' = ANY("+arg(statuses)+")")
+	}
+	if f.DueBefore != nil {
+		where = append(where, "(t.task->>'dueDate')::timestamptz < "+arg(*f.DueBefore))
+	}
+	if f.DueAfter != nil {
+		where = append(where, "(t.task->>'dueDate')::timestamptz >= "+arg(*f.DueAfter))
+	}
+	if len(f.LabelIDs) > 0 {
+		where = append(where, "t.task->'labelIds' ?& "+arg(f.LabelIDs))
+	}
+
+	query := `
+		SELECT p.id, p.name, t.task
+		FROM projects p
+		JOIN project_members m ON m.project_id = p.id AND m.user_id = $1
+		CROSS JOIN LATERAL jsonb_array_elements(p.tasks) AS t(task)
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY (t.task->>'dueDate')::timestamptz NULLS LAST, p.name
+	`
+	return r.queryTasks(ctx, query, args...)
+}
+
+func (r *ProjectRepository) FindOpenTasksDueBetween(ctx context.Context, from, to time.Time) ([]project.AssignedTask, error) {
 	query := `
-		SELECT id, user_id, name, description, status, progress,
-		       start_date, end_date, tasks, documents, created_at, updated_at
-		FROM projects
-		WHERE user_id = $1
-		ORDER BY created_at DESC
+		SELECT p.id, p.name, t.task
+		FROM projects p
+		CROSS JOIN LATERAL jsonb_array_elements(p.tasks) AS t(task)
+		WHERE t.task->>'dueDate' IS NOT NULL
+		  AND (t.task->>'dueDate')::timestamptz >= $1
+		  AND (t.task->>'dueDate')::timestamptz < $2
+		  AND jsonb_array_length(COALESCE(t.task->'assigneeIds', '[]')) > 0
+		  AND NOT EXISTS (
+		      SELECT 1 FROM jsonb_array_elements(p.workflow->'statuses') AS s(status)
+		      WHERE s.status->>'key' = t.task->>'status' AND s.status->>'category' = $3
+		  )
+		ORDER BY (t.task->>'dueDate')::timestamptz
 	`
+	return r.queryTasks(ctx, query, from, to, project.CategoryDone)
+}
 
-	rows, err := r.db.QueryContext(ctx, query, userID)
+// queryTasks reads rows of project ID, project name and task JSON
+func (r *ProjectRepository) queryTasks(ctx context.Context, query string, args ...any) ([]project.AssignedTask, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var projects []project.Project
+	var tasks []project.AssignedTask
 	for rows.Next() {
-		var p project.Project
-		var tasksJSON, documentsJSON []byte
-
-		err := rows.Scan(
-			&p.ID, &p.UserID, &p.Name, &p.Description, &p.Status, &p.Progress,
-			&p.StartDate, &p.EndDate, &tasksJSON, &documentsJSON,
-			&p.CreatedAt, &p.UpdatedAt,
-		)
-		if err != nil {
+		var at project.AssignedTask
+		var taskJSON []byte
+		if err := rows.Scan(&at.ProjectID, &at.ProjectName, &taskJSON); err != nil {
 			return nil, err
 		}
-
-		if err := json.Unmarshal(tasksJSON, &p.Tasks); err != nil {
+		if err := json.Unmarshal(taskJSON, &at.Task); err != nil {
 			return nil, err
 		}
+		tasks = append(tasks, at)
+	}
+	return tasks, rows.Err()
+}
+
+func (r *ProjectRepository) ListMembers(ctx context.Context, projectID uuid.UUID) ([]project.Member, error) {
+	query := `
+		SELECT m.project_id, m.user_id, u.email, u.display_name, m.role, m.created_at
+		FROM project_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.project_id = $1
+		ORDER BY m.created_at
+	`
+	rows, err := r.db.QueryContext(ctx, query, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-		if err := json.Unmarshal(documentsJSON, &p.Documents); err != nil {
+	var members []project.Member
+	for rows.Next() {
+		var m project.Member
+		if err := rows.Scan(&m.ProjectID, &m.UserID, &m.Email, &m.DisplayName, &m.Role, &m.CreatedAt); err != nil {
 			return nil, err
 		}
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
 
-		projects = append(projects, p)
+func (r *ProjectRepository) FindMember(ctx context.Context, projectID, userID uuid.UUID) (*project.Member, error) {
+	var m project.Member
+	query := `
+		SELECT m.project_id, m.user_id, u.email, u.display_name, m.role, m.created_at
+		FROM project_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.project_id = $1 AND m.user_id = $2
+	`
+	err := r.db.QueryRowContext(ctx, query, projectID, userID).Scan(
+		&m.ProjectID, &m.UserID, &m.Email, &m.DisplayName, &m.Role, &m.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
 	}
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
 
-	if err = rows.Err(); err != nil {
+func (r *ProjectRepository) AddMember(ctx context.Context, m *project.Member) error {
+	query := `
+		INSERT INTO project_members (project_id, user_id, role, created_at)
+		VALUES ($1, $2, $3, NOW())
+		RETURNING created_at
+	`
+	return r.db.QueryRowContext(ctx, query, m.ProjectID, m.UserID, m.Role).Scan(&m.CreatedAt)
+}
+
+func (r *ProjectRepository) UpdateMemberRole(ctx context.Context, projectID, userID uuid.UUID, role project.MemberRole) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE project_members SET role = $3 WHERE project_id = $1 AND user_id = $2`,
+		projectID, userID, role,
+	)
+	if err != nil {
+		return err
+	}
+	return expectOneRow(result, errors.New("member not found"))
+}
+
+func (r *ProjectRepository) RemoveMember(ctx context.Context, projectID, userID, actorID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// While the member is still there, since they may be the one leaving
+	if err := unassignUser(ctx, tx, &projectID, userID, actorID); err != nil {
+		return err
+	}
+	result, err := tx.ExecContext(ctx,
+		`DELETE FROM project_members WHERE project_id = $1 AND user_id = $2`,
+		projectID, userID,
+	)
+	if err != nil {
+		return err
+	}
+	if err := expectOneRow(result, errors.New("member not found")); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func scanProject(row rowScanner) (*project.Project, error) {
+	var p project.Project
+	var tasksJSON, documentsJSON, workflowJSON, progressJSON, labelsJSON []byte
+
+	err := row.Scan(
+		&p.ID, &p.WorkspaceID, &p.UserID, &p.Name, &p.Description, &p.Status, &p.Progress,
+		&p.StartDate, &p.EndDate, &tasksJSON, &documentsJSON, &workflowJSON, &progressJSON,
+		&p.Revision, &p.CreatedAt, &p.UpdatedAt, &labelsJSON,
+		&p.Role,
+	)
+	if err != nil {
 		return nil, err
 	}
 
-	return projects, nil
+	if err := json.Unmarshal(tasksJSON, &p.Tasks); err != nil {
+		return nil, err
+	}
+	project.SortByRank(p.Tasks)
+
+	if err := json.Unmarshal(documentsJSON, &p.Documents); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(labelsJSON, &p.LabelIDs); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(workflowJSON, &p.Workflow); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(progressJSON, &p.ProgressSettings); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// expectOneRow returns notFound when the statement did not touch any row
+func expectOneRow(result sql.Result, notFound error) error {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return notFound
+	}
+	return nil
 }
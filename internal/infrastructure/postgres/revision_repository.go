@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+)
+
+// Revisions are written by ProjectRepository.Create and Update in the same transaction as the
+// project, so every saved state has one
+
+func saveRevision(ctx context.Context, tx *sql.Tx, p *project.Project, authorID uuid.UUID) error {
+	snapshotJSON, err := json.Marshal(p.Snapshot())
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO project_revisions (project_id, revision, author_id, snapshot, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, p.ID, p.Revision, authorID, snapshotJSON, p.UpdatedAt)
+	return err
+}
+
+func (r *ProjectRepository) ListRevisions(ctx context.Context, projectID uuid.UUID, offset, limit int) ([]project.Revision, int, error) {
+	var total int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM project_revisions WHERE project_id = $1`, projectID).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT r.project_id, r.revision, r.author_id, COALESCE(NULLIF(u.display_name, ''), u.email, ''), r.created_at
+		FROM project_revisions r
+		LEFT JOIN users u ON u.id = r.author_id
+		WHERE r.project_id = $1
+		ORDER BY r.revision DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.QueryContext(ctx, query, projectID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var revisions []project.Revision
+	for rows.Next() {
+		var rev project.Revision
+		if err := rows.Scan(&rev.ProjectID, &rev.Number, &rev.AuthorID, &rev.AuthorName, &rev.CreatedAt); err != nil {
+			return nil, 0, err
+		}
+		revisions = append(revisions, rev)
+	}
+	return revisions, total, rows.Err()
+}
+
+func (r *ProjectRepository) FindRevision(ctx context.Context, projectID uuid.UUID, number int) (*project.Revision, error) {
+	var rev project.Revision
+	var snapshotJSON []byte
+	query := `
+		SELECT r.project_id, r.revision, r.author_id, COALESCE(NULLIF(u.display_name, ''), u.email, ''), r.snapshot, r.created_at
+		FROM project_revisions r
+		LEFT JOIN users u ON u.id = r.author_id
+		WHERE r.project_id = $1 AND r.revision = $2
+	`
+	err := r.db.QueryRowContext(ctx, query, projectID, number).Scan(
+		&rev.ProjectID, &rev.Number, &rev.AuthorID, &rev.AuthorName, &snapshotJSON, &rev.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, project.ErrRevisionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(snapshotJSON, &rev.Snapshot); err != nil {
+		return nil, err
+	}
+	return &rev, nil
+}
+
+func (r *ProjectRepository) CompactRevisions(ctx context.Context, keepAll, keepHourly time.Duration) (int, error) {
+	// Old revisions are grouped by the hour or day they were made in and only the last of each
+	// group survives. The latest revision of a project is always last in its group.
+	query := `
+		DELETE FROM project_revisions r
+		USING (
+			SELECT project_id, revision,
+			       ROW_NUMBER() OVER (
+			           PARTITION BY project_id,
+			                        date_trunc(CASE WHEN created_at >= $2 THEN 'hour' ELSE 'day' END, created_at)
+			           ORDER BY revision DESC
+			       ) AS rank
+			FROM project_revisions
+			WHERE created_at < $1
+		) old
+		WHERE r.project_id = old.project_id AND r.revision = old.revision AND old.rank > 1
+	`
+	now := time.Now()
+	result, err := r.db.ExecContext(ctx, query, now.Add(-keepAll), now.Add(-keepHourly))
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	return int(n), err
+}
@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+)
+
+type StreamTicketRepository struct {
+	db *sql.DB
+}
+
+func NewStreamTicketRepository(db *sql.DB) *StreamTicketRepository {
+	return &StreamTicketRepository{db}
+}
+
+func (r *StreamTicketRepository) CreateStreamTicket(ctx context.Context, t *project.StreamTicket) error {
+	// Tickets that were never used are cleared out on the way
+	query := `
+		WITH pruned AS (
+			DELETE FROM stream_tickets WHERE expires_at < NOW()
+		)
+		INSERT INTO stream_tickets (token_hash, user_id, project_id, expires_at, access_expires_at, token_issued_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		t.TokenHash, t.UserID, t.ProjectID, t.ExpiresAt, t.AccessExpiresAt, t.TokenIssuedAt)
+	return err
+}
+
+func (r *StreamTicketRepository) RedeemStreamTicket(ctx context.Context, tokenHash string) (*project.StreamTicket, error) {
+	// Deleted as it is read, so a ticket works once even with concurrent requests
+	query := `
+		DELETE FROM stream_tickets
+		WHERE token_hash = $1 AND expires_at > NOW()
+		RETURNING token_hash, user_id, project_id, expires_at, access_expires_at, token_issued_at
+	`
+	var t project.StreamTicket
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(
+		&t.TokenHash, &t.UserID, &t.ProjectID, &t.ExpiresAt, &t.AccessExpiresAt, &t.TokenIssuedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
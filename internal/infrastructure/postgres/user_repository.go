@@ -4,10 +4,25 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
 )
 
+const userColumns = `
+	id, email, password, is_active, role, activation_token,
+	reset_token, reset_token_expires,
+	display_name, avatar_url, timezone, locale, week_start,
+	pending_email, email_change_token, email_change_token_expires,
+	previous_email, email_revert_token, email_revert_token_expires,
+	banned_at, ban_reason, password_reset_required, tokens_valid_after,
+	deletion_scheduled_at, created_at, updated_at
+`
+
 type UserRepository struct {
 	db *sql.DB
 }
@@ -18,109 +33,256 @@ func NewUserRepository(db *sql.DB) *UserRepository {
 
 func (r *UserRepository) Create(ctx context.Context, u *user.User) error {
 	query := `
-		INSERT INTO users (email, password, is_active, activation_token, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, NOW(), NOW())
+		INSERT INTO users (email, password, is_active, role, activation_token,
+		                   display_name, timezone, locale, week_start, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW())
 		RETURNING id, created_at, updated_at
 	`
-	return r.db.QueryRowContext(ctx, query,
-		u.Email, u.Password, u.IsActive, u.ActivationToken,
+	if u.Role == "" {
+		u.Role = user.RoleUser
+	}
+	prefs := u.Preferences()
+	err := r.db.QueryRowContext(ctx, query,
+		u.Email, u.Password, u.IsActive, u.Role, u.ActivationToken,
+		u.DisplayName, prefs.Timezone, prefs.Locale, int(prefs.WeekStart),
 	).Scan(&u.ID, &u.CreatedAt, &u.UpdatedAt)
+	return mapUserWriteError(err)
 }
 
 func (r *UserRepository) Update(ctx context.Context, u *user.User) error {
 	query := `
 		UPDATE users
 		SET email = $1, password = $2, is_active = $3, activation_token = $4,
-		    reset_token = $5, reset_token_expires = $6, updated_at = NOW()
-		WHERE id = $7
+		    reset_token = $5, reset_token_expires = $6,
+		    display_name = $7, avatar_url = $8, timezone = $9, locale = $10, week_start = $11,
+		    pending_email = $12, email_change_token = $13, email_change_token_expires = $14,
+		    previous_email = $15, email_revert_token = $16, email_revert_token_expires = $17,
+		    deletion_scheduled_at = $18, role = $19,
+		    banned_at = $20, ban_reason = $21, password_reset_required = $22,
+		    tokens_valid_after = $23, updated_at = NOW()
+		WHERE id = $24
 		RETURNING updated_at
 	`
-	return r.db.QueryRowContext(ctx, query,
+	prefs := u.Preferences()
+	err := r.db.QueryRowContext(ctx, query,
 		u.Email, u.Password, u.IsActive, u.ActivationToken,
-		u.ResetToken, u.ResetTokenExpires, u.ID,
+		u.ResetToken, u.ResetTokenExpires,
+		u.DisplayName, u.AvatarURL, prefs.Timezone, prefs.Locale, int(prefs.WeekStart),
+		u.PendingEmail, u.EmailChangeToken, u.EmailChangeTokenExpires,
+		u.PreviousEmail, u.EmailRevertToken, u.EmailRevertTokenExpires,
+		u.DeletionScheduledAt, u.Role,
+		u.BannedAt, u.BanReason, u.PasswordResetRequired, u.TokensValidAfter,
+		u.ID,
 	).Scan(&u.UpdatedAt)
+	return mapUserWriteError(err)
 }
 
 func (r *UserRepository) FindByID(ctx context.Context, id string) (*user.User, error) {
-	var u user.User
-	query := `
-		SELECT id, email, password, is_active, activation_token,
-		       reset_token, reset_token_expires, created_at, updated_at
-		FROM users WHERE id = $1
-	`
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&u.ID, &u.Email, &u.Password, &u.IsActive, &u.ActivationToken,
-		&u.ResetToken, &u.ResetTokenExpires, &u.CreatedAt, &u.UpdatedAt,
-	)
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $1`
+	u, err := scanUser(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return u, err
+}
 
+func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*user.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE LOWER(email) = LOWER($1)`
+	u, err := scanUser(r.db.QueryRowContext(ctx, query, email))
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
-	if err != nil {
-		return nil, err
+	return u, err
+}
+
+func (r *UserRepository) FindByActivationToken(ctx context.Context, token string) (*user.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE activation_token = $1`
+	u, err := scanUser(r.db.QueryRowContext(ctx, query, token))
+	if err == sql.ErrNoRows {
+		return nil, errors.New("invalid activation token")
 	}
-	return &u, nil
+	return u, err
 }
 
-func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*user.User, error) {
-	var u user.User
-	query := `
-		SELECT id, email, password, is_active, activation_token,
-		       reset_token, reset_token_expires, created_at, updated_at
-		FROM users WHERE email = $1
-	`
-	err := r.db.QueryRowContext(ctx, query, email).Scan(
-		&u.ID, &u.Email, &u.Password, &u.IsActive, &u.ActivationToken,
-		&u.ResetToken, &u.ResetTokenExpires, &u.CreatedAt, &u.UpdatedAt,
-	)
+func (r *UserRepository) FindByResetToken(ctx context.Context, token string) (*user.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE reset_token = $1`
+	u, err := scanUser(r.db.QueryRowContext(ctx, query, token))
+	if err == sql.ErrNoRows {
+		return nil, errors.New("invalid reset token")
+	}
+	return u, err
+}
 
+func (r *UserRepository) FindByEmailChangeToken(ctx context.Context, token string) (*user.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE email_change_token = $1`
+	u, err := scanUser(r.db.QueryRowContext(ctx, query, token))
 	if err == sql.ErrNoRows {
-		return nil, nil
+		return nil, errors.New("invalid email change token")
 	}
-	if err != nil {
-		return nil, err
+	return u, err
+}
+
+func (r *UserRepository) FindByEmailRevertToken(ctx context.Context, token string) (*user.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE email_revert_token = $1`
+	u, err := scanUser(r.db.QueryRowContext(ctx, query, token))
+	if err == sql.ErrNoRows {
+		return nil, errors.New("invalid email revert token")
 	}
-	return &u, nil
+	return u, err
 }
 
-func (r *UserRepository) FindByActivationToken(ctx context.Context, token string) (*user.User, error) {
-	var u user.User
+func (r *UserRepository) FindDueForDeletion(ctx context.Context, before time.Time) ([]user.User, error) {
 	query := `
-		SELECT id, email, password, is_active, activation_token,
-		       reset_token, reset_token_expires, created_at, updated_at
-		FROM users WHERE activation_token = $1
+		SELECT ` + userColumns + ` FROM users
+		WHERE deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= $1
+		ORDER BY deletion_scheduled_at
 	`
-	err := r.db.QueryRowContext(ctx, query, token).Scan(
-		&u.ID, &u.Email, &u.Password, &u.IsActive, &u.ActivationToken,
-		&u.ResetToken, &u.ResetTokenExpires, &u.CreatedAt, &u.UpdatedAt,
-	)
+	rows, err := r.db.QueryContext(ctx, query, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-	if err == sql.ErrNoRows {
-		return nil, errors.New("invalid activation token")
+	var users []user.User
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, *u)
 	}
+	return users, rows.Err()
+}
+
+func (r *UserRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, id)
+	return err
+}
+
+func (r *UserRepository) Purge(ctx context.Context, id string) error {
+	userID, err := uuid.Parse(id)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return &u, nil
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := removeUserFromProjects(ctx, tx, userID); err != nil {
+		return err
+	}
+	if err := removeUserFromWorkspaces(ctx, tx, userID); err != nil {
+		return err
+	}
+
+	// Deleting the user cascades to their notifications and preferences, webhooks, security
+	// events, memberships, magic links and stream tickets. Comments and revisions they wrote
+	// stay with the projects, no longer linked to them.
+	statements := []string{
+		`DELETE FROM project_activity WHERE actor_id = $1`,
+		`DELETE FROM magic_link_attempts WHERE LOWER(email) = (SELECT LOWER(email) FROM users WHERE id = $1)`,
+		`DELETE FROM users WHERE id = $1`,
+	}
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement, userID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
 }
 
-func (r *UserRepository) FindByResetToken(ctx context.Context, token string) (*user.User, error) {
-	var u user.User
-	query := `
-		SELECT id, email, password, is_active, activation_token,
-		       reset_token, reset_token_expires, created_at, updated_at
-		FROM users WHERE reset_token = $1
-	`
-	err := r.db.QueryRowContext(ctx, query, token).Scan(
-		&u.ID, &u.Email, &u.Password, &u.IsActive, &u.ActivationToken,
-		&u.ResetToken, &u.ResetTokenExpires, &u.CreatedAt, &u.UpdatedAt,
-	)
+func (r *UserRepository) Search(ctx context.Context, f user.SearchFilter) ([]user.User, int, error) {
+	where := []string{"TRUE"}
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
 
-	if err == sql.ErrNoRows {
-		return nil, errors.New("invalid reset token")
+	if q := strings.TrimSpace(f.Query); q != "" {
+		p := arg("%" + q + "%")
+		where = append(where, fmt.Sprintf("(email ILIKE %s OR display_name ILIKE %s)", p, p))
+	}
+	if f.Role != "" {
+		where = append(where, "role = "+arg(f.Role))
+	}
+	if f.Active != nil {
+		where = append(where, "is_active = "+arg(*f.Active))
 	}
+	if f.Banned != nil {
+		if *f.Banned {
+			where = append(where, "banned_at IS NOT NULL")
+		} else {
+			where = append(where, "banned_at IS NULL")
+		}
+	}
+	cond := strings.Join(where, " AND ")
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users WHERE `+cond, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT ` + userColumns + ` FROM users WHERE ` + cond +
+		` ORDER BY created_at DESC LIMIT ` + arg(f.Limit) + ` OFFSET ` + arg(f.Offset)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var users []user.User
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		users = append(users, *u)
+	}
+	return users, total, rows.Err()
+}
+
+// PreferencesFor implements user.PreferencesReader.
+func (r *UserRepository) PreferencesFor(ctx context.Context, userID string) (user.Preferences, error) {
+	u, err := r.FindByID(ctx, userID)
+	if err != nil {
+		return user.Preferences{}, err
+	}
+	if u == nil {
+		return user.DefaultPreferences(), nil
+	}
+	return u.Preferences(), nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanUser(row rowScanner) (*user.User, error) {
+	var u user.User
+	err := row.Scan(
+		&u.ID, &u.Email, &u.Password, &u.IsActive, &u.Role, &u.ActivationToken,
+		&u.ResetToken, &u.ResetTokenExpires,
+		&u.DisplayName, &u.AvatarURL, &u.Timezone, &u.Locale, &u.WeekStart,
+		&u.PendingEmail, &u.EmailChangeToken, &u.EmailChangeTokenExpires,
+		&u.PreviousEmail, &u.EmailRevertToken, &u.EmailRevertTokenExpires,
+		&u.BannedAt, &u.BanReason, &u.PasswordResetRequired, &u.TokensValidAfter,
+		&u.DeletionScheduledAt, &u.CreatedAt, &u.UpdatedAt,
+	)
 	if err != nil {
 		return nil, err
 	}
 	return &u, nil
-}
\ No newline at end of file
+}
+
+// mapUserWriteError turns a violation of the case-insensitive email index into user.ErrEmailTaken.
+func mapUserWriteError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return user.ErrEmailTaken
+	}
+	return err
+}
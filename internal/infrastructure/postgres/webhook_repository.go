@@ -0,0 +1,275 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/webhook"
+)
+
+const webhookColumns = `
+	id, user_id, project_id, url, secret, events, description, active,
+	consecutive_failures, disabled_at, created_at, updated_at
+`
+
+const deliveryColumns = `
+	id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+	response_status, response_body, last_error, duration_ms, created_at, delivered_at
+`
+
+type WebhookRepository struct {
+	db *sql.DB
+}
+
+func NewWebhookRepository(db *sql.DB) *WebhookRepository {
+	return &WebhookRepository{db}
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, w *webhook.Webhook) error {
+	eventsJSON, err := json.Marshal(w.Events)
+	if err != nil {
+		return err
+	}
+	query := `
+		INSERT INTO webhooks (user_id, project_id, url, secret, events, description, active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
+		RETURNING id, created_at, updated_at
+	`
+	return r.db.QueryRowContext(ctx, query,
+		w.UserID, w.ProjectID, w.URL, w.Secret, eventsJSON, w.Description, w.Active,
+	).Scan(&w.ID, &w.CreatedAt, &w.UpdatedAt)
+}
+
+func (r *WebhookRepository) Update(ctx context.Context, w *webhook.Webhook) error {
+	eventsJSON, err := json.Marshal(w.Events)
+	if err != nil {
+		return err
+	}
+	query := `
+		UPDATE webhooks
+		SET url = $2, secret = $3, events = $4, description = $5, active = $6,
+			consecutive_failures = $7, disabled_at = $8, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at
+	`
+	err = r.db.QueryRowContext(ctx, query,
+		w.ID, w.URL, w.Secret, eventsJSON, w.Description, w.Active, w.ConsecutiveFailures, w.DisabledAt,
+	).Scan(&w.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return webhook.ErrNotFound
+	}
+	return err
+}
+
+func (r *WebhookRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return expectOneRow(result, webhook.ErrNotFound)
+}
+
+func (r *WebhookRepository) FindByID(ctx context.Context, id, userID uuid.UUID) (*webhook.Webhook, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE id = $1 AND user_id = $2`
+	w, err := scanWebhook(r.db.QueryRowContext(ctx, query, id, userID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return w, err
+}
+
+func (r *WebhookRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]webhook.Webhook, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE user_id = $1 ORDER BY created_at`
+	return r.queryWebhooks(ctx, query, userID)
+}
+
+func (r *WebhookRepository) FindSubscribed(ctx context.Context, projectID uuid.UUID, actorID string) ([]webhook.Webhook, error) {
+	query := `
+		SELECT ` + webhookColumns + `
+		FROM webhooks w
+		WHERE w.active
+			AND (w.project_id IS NULL OR w.project_id = $1)
+			AND (
+				w.user_id::text = $2
+				OR EXISTS (SELECT 1 FROM project_members m WHERE m.project_id = $1 AND m.user_id = w.user_id)
+			)
+	`
+	return r.queryWebhooks(ctx, query, projectID, actorID)
+}
+
+func (r *WebhookRepository) queryWebhooks(ctx context.Context, query string, args ...any) ([]webhook.Webhook, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []webhook.Webhook
+	for rows.Next() {
+		w, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, *w)
+	}
+	return webhooks, rows.Err()
+}
+
+func (r *WebhookRepository) Enqueue(ctx context.Context, deliveries []webhook.Delivery) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for i := range deliveries {
+		d := &deliveries[i]
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload, status, next_attempt_at, created_at)
+			VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+			RETURNING id, next_attempt_at, created_at
+		`, d.WebhookID, d.EventID, d.EventType, d.Payload, webhook.DeliveryPending,
+		).Scan(&d.ID, &d.NextAttemptAt, &d.CreatedAt)
+		if err != nil {
+			return err
+		}
+		d.Status = webhook.DeliveryPending
+	}
+	return tx.Commit()
+}
+
+func (r *WebhookRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]webhook.Delivery, error) {
+	query := `
+		UPDATE webhook_deliveries
+		SET next_attempt_at = NOW() + $2::int * INTERVAL '1 second'
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = $3 AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + deliveryColumns
+	return r.queryDeliveries(ctx, query, limit, int(lease.Seconds()), webhook.DeliveryPending)
+}
+
+func (r *WebhookRepository) FindForDelivery(ctx context.Context, d *webhook.Delivery) (*webhook.Webhook, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE id = $1`
+	w, err := scanWebhook(r.db.QueryRowContext(ctx, query, d.WebhookID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return w, err
+}
+
+func (r *WebhookRepository) RecordAttempt(ctx context.Context, d *webhook.Delivery, failed bool, disableAfter int) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, next_attempt_at = $4, response_status = $5, response_body = $6,
+			last_error = $7, duration_ms = $8, delivered_at = $9
+		WHERE id = $1
+	`, d.ID, d.Status, d.Attempts, d.NextAttemptAt, d.ResponseStatus, d.ResponseBody,
+		d.LastError, d.DurationMs, d.DeliveredAt)
+	if err != nil {
+		return false, err
+	}
+
+	// Counting in SQL keeps concurrent workers from losing each other's failures
+	var disabled bool
+	err = tx.QueryRowContext(ctx, `
+		UPDATE webhooks
+		SET consecutive_failures = CASE WHEN $2 THEN consecutive_failures + 1 WHEN $4 THEN 0 ELSE consecutive_failures END,
+			active = active AND NOT ($2 AND consecutive_failures + 1 >= $3),
+			disabled_at = CASE WHEN active AND $2 AND consecutive_failures + 1 >= $3 THEN NOW() ELSE disabled_at END
+		WHERE id = $1
+		RETURNING NOT active AND COALESCE(disabled_at = NOW(), FALSE)
+	`, d.WebhookID, failed, disableAfter, d.Status == webhook.DeliverySucceeded).Scan(&disabled)
+	if err != nil && err != sql.ErrNoRows {
+		return false, err
+	}
+	return disabled, tx.Commit()
+}
+
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, webhookID uuid.UUID, offset, limit int) ([]webhook.Delivery, int, error) {
+	var total int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM webhook_deliveries WHERE webhook_id = $1
+	`, webhookID).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT ` + deliveryColumns + `
+		FROM webhook_deliveries
+		WHERE webhook_id = $1
+		ORDER BY created_at DESC, id
+		LIMIT $2 OFFSET $3
+	`
+	deliveries, err := r.queryDeliveries(ctx, query, webhookID, limit, offset)
+	return deliveries, total, err
+}
+
+func (r *WebhookRepository) FindDelivery(ctx context.Context, webhookID, id uuid.UUID) (*webhook.Delivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries WHERE id = $1 AND webhook_id = $2`
+	d, err := scanDelivery(r.db.QueryRowContext(ctx, query, id, webhookID))
+	if err == sql.ErrNoRows {
+		return nil, webhook.ErrDeliveryNotFound
+	}
+	return d, err
+}
+
+func (r *WebhookRepository) queryDeliveries(ctx context.Context, query string, args ...any) ([]webhook.Delivery, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []webhook.Delivery
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, *d)
+	}
+	return deliveries, rows.Err()
+}
+
+func scanWebhook(row rowScanner) (*webhook.Webhook, error) {
+	var w webhook.Webhook
+	var eventsJSON []byte
+	err := row.Scan(
+		&w.ID, &w.UserID, &w.ProjectID, &w.URL, &w.Secret, &eventsJSON, &w.Description, &w.Active,
+		&w.ConsecutiveFailures, &w.DisabledAt, &w.CreatedAt, &w.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(eventsJSON, &w.Events); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+func scanDelivery(row rowScanner) (*webhook.Delivery, error) {
+	var d webhook.Delivery
+	err := row.Scan(
+		&d.ID, &d.WebhookID, &d.EventID, &d.EventType, &d.Payload, &d.Status, &d.Attempts, &d.NextAttemptAt,
+		&d.ResponseStatus, &d.ResponseBody, &d.LastError, &d.DurationMs, &d.CreatedAt, &d.DeliveredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
@@ -0,0 +1,293 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/workspace"
+)
+
+// workspaceColumns are selected from "workspaces w" joined with the caller's membership "m"
+const workspaceColumns = `
+	w.id, w.name, w.personal_owner_id IS NOT NULL, w.settings, w.created_at, w.updated_at, m.role
+`
+
+type WorkspaceRepository struct {
+	db *sql.DB
+}
+
+func NewWorkspaceRepository(db *sql.DB) *WorkspaceRepository {
+	return &WorkspaceRepository{db}
+}
+
+func (r *WorkspaceRepository) Create(ctx context.Context, w *workspace.Workspace, ownerID uuid.UUID) error {
+	settingsJSON, err := json.Marshal(w.Settings)
+	if err != nil {
+		return err
+	}
+
+	var personalOwner *uuid.UUID
+	if w.Personal {
+		personalOwner = &ownerID
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO workspaces (name, personal_owner_id, settings, created_at, updated_at)
+		VALUES ($1, $2, $3, NOW(), NOW())
+		RETURNING id, created_at, updated_at
+	`
+	err = tx.QueryRowContext(ctx, query, w.Name, personalOwner, settingsJSON).
+		Scan(&w.ID, &w.CreatedAt, &w.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO workspace_members (workspace_id, user_id, role, created_at) VALUES ($1, $2, $3, NOW())`,
+		w.ID, ownerID, workspace.RoleOwner,
+	)
+	if err != nil {
+		return err
+	}
+	w.Role = workspace.RoleOwner
+
+	return tx.Commit()
+}
+
+func (r *WorkspaceRepository) Update(ctx context.Context, w *workspace.Workspace) error {
+	settingsJSON, err := json.Marshal(w.Settings)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE workspaces SET name = $1, settings = $2, updated_at = NOW()
+		WHERE id = $3
+		RETURNING updated_at
+	`
+	err = r.db.QueryRowContext(ctx, query, w.Name, settingsJSON, w.ID).Scan(&w.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return errors.New("workspace not found")
+	}
+	return err
+}
+
+func (r *WorkspaceRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM workspaces WHERE id = $1`, id)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+		return errors.New("move or delete the workspace's projects first")
+	}
+	if err != nil {
+		return err
+	}
+	return expectOneRow(result, errors.New("workspace not found"))
+}
+
+func (r *WorkspaceRepository) FindByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*workspace.Workspace, error) {
+	query := `
+		SELECT ` + workspaceColumns + `
+		FROM workspaces w
+		JOIN workspace_members m ON m.workspace_id = w.id AND m.user_id = $2
+		WHERE w.id = $1
+	`
+	w, err := scanWorkspace(r.db.QueryRowContext(ctx, query, id, userID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return w, err
+}
+
+func (r *WorkspaceRepository) FindPersonal(ctx context.Context, userID uuid.UUID) (*workspace.Workspace, error) {
+	query := `
+		SELECT ` + workspaceColumns + `
+		FROM workspaces w
+		JOIN workspace_members m ON m.workspace_id = w.id AND m.user_id = $1
+		WHERE w.personal_owner_id = $1
+	`
+	w, err := scanWorkspace(r.db.QueryRowContext(ctx, query, userID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return w, err
+}
+
+func (r *WorkspaceRepository) FindAllByUserID(ctx context.Context, userID uuid.UUID) ([]workspace.Workspace, error) {
+	query := `
+		SELECT ` + workspaceColumns + `
+		FROM workspaces w
+		JOIN workspace_members m ON m.workspace_id = w.id AND m.user_id = $1
+		ORDER BY w.personal_owner_id IS NULL, w.name
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var workspaces []workspace.Workspace
+	for rows.Next() {
+		w, err := scanWorkspace(rows)
+		if err != nil {
+			return nil, err
+		}
+		workspaces = append(workspaces, *w)
+	}
+	return workspaces, rows.Err()
+}
+
+func (r *WorkspaceRepository) SettingsFor(ctx context.Context, id uuid.UUID) (workspace.Settings, error) {
+	var settingsJSON []byte
+	err := r.db.QueryRowContext(ctx, `SELECT settings FROM workspaces WHERE id = $1`, id).Scan(&settingsJSON)
+	if err == sql.ErrNoRows {
+		return workspace.Settings{}, errors.New("workspace not found")
+	}
+	if err != nil {
+		return workspace.Settings{}, err
+	}
+	return decodeWorkspaceSettings(settingsJSON)
+}
+
+func (r *WorkspaceRepository) ListMembers(ctx context.Context, workspaceID uuid.UUID) ([]workspace.Member, error) {
+	query := `
+		SELECT m.workspace_id, m.user_id, u.email, u.display_name, m.role, m.created_at
+		FROM workspace_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.workspace_id = $1
+		ORDER BY m.created_at
+	`
+	rows, err := r.db.QueryContext(ctx, query, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []workspace.Member
+	for rows.Next() {
+		var m workspace.Member
+		if err := rows.Scan(&m.WorkspaceID, &m.UserID, &m.Email, &m.DisplayName, &m.Role, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
+
+func (r *WorkspaceRepository) FindMember(ctx context.Context, workspaceID, userID uuid.UUID) (*workspace.Member, error) {
+	var m workspace.Member
+	query := `
+		SELECT m.workspace_id, m.user_id, u.email, u.display_name, m.role, m.created_at
+		FROM workspace_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.workspace_id = $1 AND m.user_id = $2
+	`
+	err := r.db.QueryRowContext(ctx, query, workspaceID, userID).Scan(
+		&m.WorkspaceID, &m.UserID, &m.Email, &m.DisplayName, &m.Role, &m.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (r *WorkspaceRepository) AddMember(ctx context.Context, m *workspace.Member) error {
+	query := `
+		INSERT INTO workspace_members (workspace_id, user_id, role, created_at)
+		VALUES ($1, $2, $3, NOW())
+		RETURNING created_at
+	`
+	return r.db.QueryRowContext(ctx, query, m.WorkspaceID, m.UserID, m.Role).Scan(&m.CreatedAt)
+}
+
+func (r *WorkspaceRepository) UpdateMemberRole(ctx context.Context, workspaceID, userID uuid.UUID, role workspace.Role) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE workspace_members SET role = $3 WHERE workspace_id = $1 AND user_id = $2`,
+		workspaceID, userID, role,
+	)
+	if err != nil {
+		return err
+	}
+	return expectOneRow(result, errors.New("member not found"))
+}
+
+func (r *WorkspaceRepository) RemoveMember(ctx context.Context, workspaceID, userID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM workspace_members WHERE workspace_id = $1 AND user_id = $2`,
+		workspaceID, userID,
+	)
+	if err != nil {
+		return err
+	}
+	return expectOneRow(result, errors.New("member not found"))
+}
+
+// removeUserFromWorkspaces is part of purging an account, after removeUserFromProjects has
+// moved the projects out of the user's personal workspace. Where the user was the last owner of
+// a shared workspace the longest-standing admin, else member, becomes one; shared workspaces no
+// one else is a member of are deleted once they hold no projects. The personal workspace and the
+// memberships go with the user.
+func removeUserFromWorkspaces(ctx context.Context, tx *sql.Tx, userID uuid.UUID) error {
+	statements := []string{
+		`UPDATE workspace_members m SET role = 'owner'
+		 FROM (
+		     SELECT DISTINCT ON (workspace_id) workspace_id, user_id
+		     FROM workspace_members
+		     WHERE user_id <> $1
+		     ORDER BY workspace_id, CASE role WHEN 'owner' THEN 0 WHEN 'admin' THEN 1 ELSE 2 END, created_at
+		 ) s
+		 WHERE m.workspace_id = s.workspace_id AND m.user_id = s.user_id
+		   AND s.workspace_id IN (SELECT workspace_id FROM workspace_members WHERE user_id = $1 AND role = 'owner')`,
+		`DELETE FROM workspaces w
+		 WHERE w.personal_owner_id IS NULL
+		   AND EXISTS (SELECT 1 FROM workspace_members m WHERE m.workspace_id = w.id AND m.user_id = $1)
+		   AND NOT EXISTS (SELECT 1 FROM workspace_members m WHERE m.workspace_id = w.id AND m.user_id <> $1)
+		   AND NOT EXISTS (SELECT 1 FROM projects p WHERE p.workspace_id = w.id)`,
+	}
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scanWorkspace(row rowScanner) (*workspace.Workspace, error) {
+	var w workspace.Workspace
+	var settingsJSON []byte
+
+	err := row.Scan(&w.ID, &w.Name, &w.Personal, &settingsJSON, &w.CreatedAt, &w.UpdatedAt, &w.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	w.Settings, err = decodeWorkspaceSettings(settingsJSON)
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// decodeWorkspaceSettings fills in defaults for settings that were never saved
+func decodeWorkspaceSettings(data []byte) (workspace.Settings, error) {
+	settings := workspace.DefaultSettings()
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return workspace.Settings{}, err
+	}
+	if len(settings.DefaultTaskStatuses) == 0 {
+		settings.DefaultTaskStatuses = workspace.DefaultSettings().DefaultTaskStatuses
+	}
+	return settings, nil
+}
@@ -0,0 +1,75 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	"github.com/tomtom2k/kairo-anchor-server/pkg/pubsub"
+)
+
+// ProjectEvents carries project events over a pubsub broker, one topic per project
+type ProjectEvents struct {
+	broker pubsub.Broker
+}
+
+func NewProjectEvents(broker pubsub.Broker) *ProjectEvents {
+	return &ProjectEvents{broker: broker}
+}
+
+func (p *ProjectEvents) Publish(ctx context.Context, event project.Event) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Failed to encode %s event for project %s: %v", event.Type, event.ProjectID, err)
+		return
+	}
+
+	err = p.broker.Publish(ctx, topic(event.ProjectID), payload)
+	if errors.Is(err, pubsub.ErrPayloadTooLarge) {
+		// Subscribers still learn what changed and can refetch it
+		event.Data = nil
+		payload, _ = json.Marshal(event)
+		err = p.broker.Publish(ctx, topic(event.ProjectID), payload)
+	}
+	if err != nil {
+		log.Printf("Failed to publish %s event for project %s: %v", event.Type, event.ProjectID, err)
+	}
+}
+
+func (p *ProjectEvents) Subscribe(ctx context.Context, projectID uuid.UUID) <-chan project.Event {
+	sub := p.broker.Subscribe(topic(projectID))
+	events := make(chan project.Event)
+
+	go func() {
+		defer close(events)
+		defer sub.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case payload, ok := <-sub.C:
+				if !ok {
+					return
+				}
+				var event project.Event
+				if err := json.Unmarshal(payload, &event); err != nil {
+					log.Printf("Dropped malformed event for project %s: %v", projectID, err)
+					continue
+				}
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return events
+}
+
+func topic(projectID uuid.UUID) string {
+	return "project:" + projectID.String()
+}
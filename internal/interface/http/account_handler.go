@@ -0,0 +1,115 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tomtom2k/kairo-anchor-server/internal/usecase/account"
+)
+
+type AccountHandler struct {
+	exportData       *account.ExportDataUseCase
+	scheduleDeletion *account.ScheduleDeletionUseCase
+	cancelDeletion   *account.CancelDeletionUseCase
+}
+
+func NewAccountHandler(
+	export *account.ExportDataUseCase,
+	schedule *account.ScheduleDeletionUseCase,
+	cancel *account.CancelDeletionUseCase,
+) *AccountHandler {
+	return &AccountHandler{
+		exportData:       export,
+		scheduleDeletion: schedule,
+		cancelDeletion:   cancel,
+	}
+}
+
+// ExportData godoc
+// @Summary Export personal data
+// @Description Download a zip archive with the user's profile, the projects they own or are a member of with their
+// @Description tasks and document metadata, the tasks assigned to them, the comments they wrote, the workspaces
+// @Description they belong to, their notifications and notification preferences, their webhooks with their
+// @Description deliveries, their project activity and the security log of their account
+// @Tags account
+// @Produce application/zip
+// @Security BearerAuth
+// @Success 200 {file} file
+// @Failure 401 {object} APIErrorResponse
+// @Router /auth/me/export [get]
+func (h *AccountHandler) ExportData(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	result, err := h.exportData.Execute(c.Request.Context(), userID)
+	if err != nil {
+		SendInternalError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="`+result.FileName+`"`)
+	c.Data(http.StatusOK, "application/zip", result.Data)
+}
+
+// DeleteAccount godoc
+// @Summary Delete account
+// @Description Schedule the account and all of its data for deletion after a grace period (requires password)
+// @Tags account
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body DeleteAccountRequest true "Delete Account Request"
+// @Success 202 {object} APIResponse{data=AccountDeletionResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Router /auth/me [delete]
+func (h *AccountHandler) DeleteAccount(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req DeleteAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	deleteAt, err := h.scheduleDeletion.Execute(c.Request.Context(), userID, req.Password)
+	if err != nil {
+		SendError(c, http.StatusBadRequest, "ACCOUNT_DELETION_FAILED", err.Error())
+		return
+	}
+
+	SendSuccess(c, http.StatusAccepted, AccountDeletionResponse{DeletionScheduledAt: *deleteAt},
+		"Account scheduled for deletion, you can cancel before the deletion date")
+}
+
+// CancelDeletion godoc
+// @Summary Cancel account deletion
+// @Description Cancel a pending account deletion during the grace period
+// @Tags account
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} APIResponse
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Router /auth/me/cancel-deletion [post]
+func (h *AccountHandler) CancelDeletion(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := h.cancelDeletion.Execute(c.Request.Context(), userID); err != nil {
+		SendError(c, http.StatusBadRequest, "CANCEL_DELETION_FAILED", err.Error())
+		return
+	}
+
+	SendSuccess(c, http.StatusOK, nil, "Account deletion cancelled")
+}
@@ -0,0 +1,43 @@
+package http
+
+import "time"
+
+// Admin DTOs
+
+type BanUserRequest struct {
+	Reason string `json:"reason" binding:"max=500" example:"Spam"`
+}
+
+type SetUserRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=user admin" example:"admin"`
+}
+
+type AdminUserResponse struct {
+	ProfileResponse
+	BannedAt              *time.Time `json:"banned_at,omitempty" example:"2024-01-01T00:00:00Z"`
+	BanReason             string     `json:"ban_reason,omitempty" example:"Spam"`
+	PasswordResetRequired bool       `json:"password_reset_required" example:"false"`
+}
+
+type AdminUserDetailResponse struct {
+	AdminUserResponse
+	ProjectCounts map[string]int `json:"project_counts"`
+	TotalProjects int            `json:"total_projects" example:"3"`
+}
+
+type CreateInviteCodeRequest struct {
+	Email     *string    `json:"email,omitempty" binding:"omitempty,email" example:"new.hire@example.com"`
+	MaxUses   int        `json:"max_uses" binding:"omitempty,min=1,max=10000" example:"1"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" example:"2024-02-01T00:00:00Z"`
+}
+
+type InviteCodeResponse struct {
+	ID        string     `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Code      string     `json:"code" example:"3F9A1C2B7D4E8A60"`
+	Email     *string    `json:"email,omitempty" example:"new.hire@example.com"`
+	MaxUses   int        `json:"max_uses" example:"1"`
+	Uses      int        `json:"uses" example:"0"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" example:"2024-02-01T00:00:00Z"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at" example:"2024-01-01T00:00:00Z"`
+}
@@ -0,0 +1,411 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+	"github.com/tomtom2k/kairo-anchor-server/internal/usecase/admin"
+)
+
+type AdminHandler struct {
+	listUsers  *admin.ListUsersUseCase
+	getUser    *admin.GetUserUseCase
+	setActive  *admin.SetUserActiveUseCase
+	banUser    *admin.BanUserUseCase
+	forceReset *admin.ForcePasswordResetUseCase
+	setRole    *admin.SetUserRoleUseCase
+	createCode *admin.CreateInviteCodeUseCase
+	listCodes  *admin.ListInviteCodesUseCase
+	revokeCode *admin.RevokeInviteCodeUseCase
+}
+
+func NewAdminHandler(
+	list *admin.ListUsersUseCase,
+	get *admin.GetUserUseCase,
+	setActive *admin.SetUserActiveUseCase,
+	ban *admin.BanUserUseCase,
+	forceReset *admin.ForcePasswordResetUseCase,
+	setRole *admin.SetUserRoleUseCase,
+	createCode *admin.CreateInviteCodeUseCase,
+	listCodes *admin.ListInviteCodesUseCase,
+	revokeCode *admin.RevokeInviteCodeUseCase,
+) *AdminHandler {
+	return &AdminHandler{
+		listUsers:  list,
+		getUser:    get,
+		setActive:  setActive,
+		banUser:    ban,
+		forceReset: forceReset,
+		setRole:    setRole,
+		createCode: createCode,
+		listCodes:  listCodes,
+		revokeCode: revokeCode,
+	}
+}
+
+// ListUsers godoc
+// @Summary List users
+// @Description List and search users (admin only)
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param q query string false "Search by email or display name"
+// @Param role query string false "Filter by role" Enums(user, admin)
+// @Param active query bool false "Filter by activation state"
+// @Param banned query bool false "Filter by ban state"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} APIListResponse{data=ListData{items=[]AdminUserResponse}}
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /admin/users [get]
+func (h *AdminHandler) ListUsers(c *gin.Context) {
+	input := admin.ListUsersInput{
+		Query:    c.Query("q"),
+		Role:     user.Role(c.Query("role")),
+		Active:   queryBool(c, "active"),
+		Banned:   queryBool(c, "banned"),
+		Page:     queryInt(c, "page", 1),
+		PageSize: queryInt(c, "page_size", 20),
+	}
+
+	result, err := h.listUsers.Execute(c.Request.Context(), input)
+	if err != nil {
+		SendInternalError(c, err)
+		return
+	}
+
+	items := make([]AdminUserResponse, len(result.Users))
+	for i := range result.Users {
+		items[i] = toAdminUserResponse(&result.Users[i])
+	}
+
+	SendSuccessList(c, items, newPaginationMeta(result.Total, result.Page, result.PageSize))
+}
+
+// GetUser godoc
+// @Summary Get a user
+// @Description Get a user with their project counts (admin only)
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID (UUID)"
+// @Success 200 {object} APIResponse{data=AdminUserDetailResponse}
+// @Failure 403 {object} APIErrorResponse
+// @Failure 404 {object} APIErrorResponse
+// @Router /admin/users/{id} [get]
+func (h *AdminHandler) GetUser(c *gin.Context) {
+	details, err := h.getUser.Execute(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		SendError(c, http.StatusNotFound, ErrCodeNotFound, err.Error())
+		return
+	}
+
+	counts := make(map[string]int, len(details.ProjectCounts))
+	for status, n := range details.ProjectCounts {
+		counts[string(status)] = n
+	}
+
+	SendSuccess(c, http.StatusOK, AdminUserDetailResponse{
+		AdminUserResponse: toAdminUserResponse(details.User),
+		ProjectCounts:     counts,
+		TotalProjects:     details.TotalProjects,
+	}, "")
+}
+
+// ActivateUser godoc
+// @Summary Activate a user
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID (UUID)"
+// @Success 200 {object} APIResponse{data=AdminUserResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /admin/users/{id}/activate [post]
+func (h *AdminHandler) ActivateUser(c *gin.Context) {
+	h.setUserActive(c, true)
+}
+
+// DeactivateUser godoc
+// @Summary Deactivate a user
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID (UUID)"
+// @Success 200 {object} APIResponse{data=AdminUserResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /admin/users/{id}/deactivate [post]
+func (h *AdminHandler) DeactivateUser(c *gin.Context) {
+	h.setUserActive(c, false)
+}
+
+func (h *AdminHandler) setUserActive(c *gin.Context, active bool) {
+	u, err := h.setActive.Execute(c.Request.Context(), c.Param("id"), active)
+	if err != nil {
+		SendError(c, http.StatusBadRequest, "UPDATE_USER_FAILED", err.Error())
+		return
+	}
+
+	SendSuccess(c, http.StatusOK, toAdminUserResponse(u), "User updated")
+}
+
+// BanUser godoc
+// @Summary Ban a user
+// @Description Suspend an account; the user is signed out everywhere immediately (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID (UUID)"
+// @Param request body BanUserRequest false "Ban User Request"
+// @Success 200 {object} APIResponse{data=AdminUserResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /admin/users/{id}/ban [post]
+func (h *AdminHandler) BanUser(c *gin.Context) {
+	adminID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req BanUserRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+			return
+		}
+	}
+
+	u, err := h.banUser.Ban(c.Request.Context(), adminID, c.Param("id"), req.Reason)
+	if err != nil {
+		SendError(c, http.StatusBadRequest, "BAN_USER_FAILED", err.Error())
+		return
+	}
+
+	SendSuccess(c, http.StatusOK, toAdminUserResponse(u), "User banned")
+}
+
+// UnbanUser godoc
+// @Summary Unban a user
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID (UUID)"
+// @Success 200 {object} APIResponse{data=AdminUserResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /admin/users/{id}/unban [post]
+func (h *AdminHandler) UnbanUser(c *gin.Context) {
+	u, err := h.banUser.Unban(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		SendError(c, http.StatusBadRequest, "UNBAN_USER_FAILED", err.Error())
+		return
+	}
+
+	SendSuccess(c, http.StatusOK, toAdminUserResponse(u), "User unbanned")
+}
+
+// ForcePasswordReset godoc
+// @Summary Force a password reset
+// @Description Block password login, revoke the user's access tokens and email them a reset link (admin only)
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID (UUID)"
+// @Success 200 {object} APIResponse
+// @Failure 400 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /admin/users/{id}/force-password-reset [post]
+func (h *AdminHandler) ForcePasswordReset(c *gin.Context) {
+	if err := h.forceReset.Execute(c.Request.Context(), c.Param("id")); err != nil {
+		SendError(c, http.StatusBadRequest, "FORCE_PASSWORD_RESET_FAILED", err.Error())
+		return
+	}
+
+	SendSuccess(c, http.StatusOK, nil, "Password reset email sent to the user")
+}
+
+// SetUserRole godoc
+// @Summary Change a user's role
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID (UUID)"
+// @Param request body SetUserRoleRequest true "Set User Role Request"
+// @Success 200 {object} APIResponse{data=AdminUserResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /admin/users/{id}/role [put]
+func (h *AdminHandler) SetUserRole(c *gin.Context) {
+	adminID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req SetUserRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	u, err := h.setRole.Execute(c.Request.Context(), adminID, c.Param("id"), user.Role(req.Role))
+	if err != nil {
+		SendError(c, http.StatusBadRequest, "SET_ROLE_FAILED", err.Error())
+		return
+	}
+
+	SendSuccess(c, http.StatusOK, toAdminUserResponse(u), "Role updated")
+}
+
+// CreateInviteCode godoc
+// @Summary Create an invite code
+// @Description Create a registration invite code, optionally tied to one email address (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateInviteCodeRequest true "Create Invite Code Request"
+// @Success 201 {object} APIResponse{data=InviteCodeResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /admin/invite-codes [post]
+func (h *AdminHandler) CreateInviteCode(c *gin.Context) {
+	adminID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req CreateInviteCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	code, err := h.createCode.Execute(c.Request.Context(), admin.CreateInviteCodeInput{
+		CreatedBy: adminID,
+		Email:     req.Email,
+		MaxUses:   req.MaxUses,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		SendError(c, http.StatusBadRequest, "CREATE_INVITE_CODE_FAILED", err.Error())
+		return
+	}
+
+	SendSuccess(c, http.StatusCreated, toInviteCodeResponse(code), "Invite code created")
+}
+
+// ListInviteCodes godoc
+// @Summary List invite codes
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} APIResponse{data=[]InviteCodeResponse}
+// @Failure 403 {object} APIErrorResponse
+// @Router /admin/invite-codes [get]
+func (h *AdminHandler) ListInviteCodes(c *gin.Context) {
+	codes, err := h.listCodes.Execute(c.Request.Context())
+	if err != nil {
+		SendInternalError(c, err)
+		return
+	}
+
+	response := make([]InviteCodeResponse, len(codes))
+	for i := range codes {
+		response[i] = toInviteCodeResponse(&codes[i])
+	}
+
+	SendSuccess(c, http.StatusOK, response, "")
+}
+
+// RevokeInviteCode godoc
+// @Summary Revoke an invite code
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Invite code ID (UUID)"
+// @Success 200 {object} APIResponse
+// @Failure 400 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /admin/invite-codes/{id} [delete]
+func (h *AdminHandler) RevokeInviteCode(c *gin.Context) {
+	if err := h.revokeCode.Execute(c.Request.Context(), c.Param("id")); err != nil {
+		SendError(c, http.StatusBadRequest, "REVOKE_INVITE_CODE_FAILED", err.Error())
+		return
+	}
+
+	SendSuccess(c, http.StatusOK, nil, "Invite code revoked")
+}
+
+func toInviteCodeResponse(code *user.InviteCode) InviteCodeResponse {
+	return InviteCodeResponse{
+		ID:        code.ID,
+		Code:      code.Code,
+		Email:     code.Email,
+		MaxUses:   code.MaxUses,
+		Uses:      code.Uses,
+		ExpiresAt: code.ExpiresAt,
+		RevokedAt: code.RevokedAt,
+		CreatedAt: code.CreatedAt,
+	}
+}
+
+func toAdminUserResponse(u *user.User) AdminUserResponse {
+	return AdminUserResponse{
+		ProfileResponse:       toProfileResponse(u),
+		BannedAt:              u.BannedAt,
+		BanReason:             u.BanReason,
+		PasswordResetRequired: u.PasswordResetRequired,
+	}
+}
+
+func queryInt(c *gin.Context, key string, def int) int {
+	if v, err := strconv.Atoi(c.Query(key)); err == nil {
+		return v
+	}
+	return def
+}
+
+func queryBool(c *gin.Context, key string) *bool {
+	v, err := strconv.ParseBool(c.Query(key))
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+// queryTime accepts RFC3339 timestamps or plain dates, which are read as midnight UTC
+func queryTime(c *gin.Context, key string) (*time.Time, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("%s must be an RFC3339 time or a YYYY-MM-DD date", key)
+}
+
+// queryList splits a comma-separated parameter, skipping empty entries
+func queryList(c *gin.Context, key string) []string {
+	var values []string
+	for _, v := range strings.Split(c.Query(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
@@ -0,0 +1,35 @@
+package http
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Audit DTOs
+
+type ActivityChangeDTO struct {
+	Before json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	After  json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+}
+
+type ActivityResponse struct {
+	ID         string                       `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ActorID    *string                      `json:"actorId,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ActorName  string                       `json:"actorName,omitempty" example:"Jane Doe"`
+	Action     string                       `json:"action" example:"task.updated"`
+	EntityType string                       `json:"entityType" example:"task"`
+	EntityID   string                       `json:"entityId" example:"task-1"`
+	Changes    map[string]ActivityChangeDTO `json:"changes"`
+	RequestID  string                       `json:"requestId,omitempty" example:"4b2f0c1e-8d7a-4a53-9c1b-2f8e6d3a5b70"`
+	CreatedAt  time.Time                    `json:"createdAt" example:"2024-01-01T00:00:00Z"`
+}
+
+type SecurityEventResponse struct {
+	ID        string            `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Event     string            `json:"event" example:"user.logged_in"`
+	Details   map[string]string `json:"details"`
+	IP        string            `json:"ip,omitempty" example:"203.0.113.7"`
+	UserAgent string            `json:"user_agent,omitempty" example:"Mozilla/5.0"`
+	RequestID string            `json:"request_id,omitempty" example:"4b2f0c1e-8d7a-4a53-9c1b-2f8e6d3a5b70"`
+	CreatedAt time.Time         `json:"created_at" example:"2024-01-01T00:00:00Z"`
+}
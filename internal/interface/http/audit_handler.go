@@ -0,0 +1,152 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/audit"
+	auditUC "github.com/tomtom2k/kairo-anchor-server/internal/usecase/audit"
+)
+
+type AuditHandler struct {
+	listActivity       *auditUC.ListActivityUseCase
+	listSecurityEvents *auditUC.ListSecurityEventsUseCase
+}
+
+func NewAuditHandler(
+	listActivity *auditUC.ListActivityUseCase,
+	listSecurityEvents *auditUC.ListSecurityEventsUseCase,
+) *AuditHandler {
+	return &AuditHandler{listActivity: listActivity, listSecurityEvents: listSecurityEvents}
+}
+
+// ListActivity godoc
+// @Summary List project activity
+// @Description Who changed what in a project, its tasks and documents, newest first. Each entry has the fields
+// @Description that changed with their values before and after. Entries are kept after the project is deleted.
+// @Tags projects
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID (UUID)"
+// @Param actor query string false "Only changes made by this user (UUID)"
+// @Param entity_type query string false "Only changes to this kind of entity" Enums(project, task, document)
+// @Param entity_id query string false "Only changes to this entity, e.g. a task ID"
+// @Param action query string false "Only this action, e.g. task.deleted"
+// @Param from query string false "Only changes at or after this time (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Only changes before this time (RFC3339 or YYYY-MM-DD)"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} APIListResponse{data=ListData{items=[]ActivityResponse}}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Router /projects/{id}/activity [get]
+func (h *AuditHandler) ListActivity(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	filter := audit.ActivityFilter{
+		EntityType: audit.EntityType(c.Query("entity_type")),
+		EntityID:   c.Query("entity_id"),
+		Action:     c.Query("action"),
+	}
+	if raw := c.Query("actor"); raw != "" {
+		actorID, err := uuid.Parse(raw)
+		if err != nil {
+			SendError(c, http.StatusBadRequest, ErrCodeValidation, "actor must be a user ID")
+			return
+		}
+		filter.ActorID = &actorID
+	}
+	if filter.From, err = queryTime(c, "from"); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+	if filter.To, err = queryTime(c, "to"); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	result, err := h.listActivity.Execute(c.Request.Context(), auditUC.ListActivityInput{
+		ProjectID: c.Param("id"),
+		UserID:    userID,
+		Filter:    filter,
+		Page:      queryInt(c, "page", 1),
+		PageSize:  queryInt(c, "page_size", 20),
+	})
+	if err != nil {
+		SendError(c, http.StatusBadRequest, "LIST_ACTIVITY_FAILED", err.Error())
+		return
+	}
+
+	items := make([]ActivityResponse, len(result.Activity))
+	for i := range result.Activity {
+		items[i] = toActivityResponse(&result.Activity[i])
+	}
+	SendSuccessList(c, items, newPaginationMeta(result.Total, result.Page, result.PageSize))
+}
+
+// ListSecurityEvents godoc
+// @Summary List account security events
+// @Description Sign-ins, failed sign-in attempts, password and email changes and the links sent to the account,
+// @Description newest first, with the IP address and client they came from
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} APIListResponse{data=ListData{items=[]SecurityEventResponse}}
+// @Failure 401 {object} APIErrorResponse
+// @Router /auth/me/security-log [get]
+func (h *AuditHandler) ListSecurityEvents(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	result, err := h.listSecurityEvents.Execute(c.Request.Context(), auditUC.ListSecurityEventsInput{
+		UserID:   userID,
+		Page:     queryInt(c, "page", 1),
+		PageSize: queryInt(c, "page_size", 20),
+	})
+	if err != nil {
+		SendInternalError(c, err)
+		return
+	}
+
+	items := make([]SecurityEventResponse, len(result.Events))
+	for i, e := range result.Events {
+		items[i] = SecurityEventResponse{
+			ID:        e.ID.String(),
+			Event:     e.Event,
+			Details:   e.Details,
+			IP:        e.IP,
+			UserAgent: e.UserAgent,
+			RequestID: e.RequestID,
+			CreatedAt: e.CreatedAt,
+		}
+	}
+	SendSuccessList(c, items, newPaginationMeta(result.Total, result.Page, result.PageSize))
+}
+
+func toActivityResponse(a *audit.Activity) ActivityResponse {
+	changes := make(map[string]ActivityChangeDTO, len(a.Changes))
+	for field, change := range a.Changes {
+		changes[field] = ActivityChangeDTO{Before: change.Before, After: change.After}
+	}
+	return ActivityResponse{
+		ID:         a.ID.String(),
+		ActorID:    uuidString(a.ActorID),
+		ActorName:  a.ActorName,
+		Action:     a.Action,
+		EntityType: string(a.EntityType),
+		EntityID:   a.EntityID,
+		Changes:    changes,
+		RequestID:  a.RequestID,
+		CreatedAt:  a.CreatedAt,
+	}
+}
@@ -0,0 +1,106 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	projectUC "github.com/tomtom2k/kairo-anchor-server/internal/usecase/project"
+)
+
+type BoardHandler struct {
+	getProject *projectUC.GetProjectUseCase
+	moveTask   *projectUC.MoveTaskUseCase
+}
+
+func NewBoardHandler(getProject *projectUC.GetProjectUseCase, moveTask *projectUC.MoveTaskUseCase) *BoardHandler {
+	return &BoardHandler{getProject: getProject, moveTask: moveTask}
+}
+
+// GetBoard godoc
+// @Summary Get a project's board
+// @Description The project's tasks grouped by status, one column per workflow status in workflow order, with each
+// @Description task's position in its column
+// @Tags projects
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Success 200 {object} APIResponse{data=BoardResponse}
+// @Failure 401 {object} APIErrorResponse
+// @Failure 404 {object} APIErrorResponse
+// @Router /projects/{id}/board [get]
+func (h *BoardHandler) GetBoard(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+	p, err := h.getProject.Execute(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		SendError(c, http.StatusNotFound, ErrCodeNotFound, err.Error())
+		return
+	}
+	SendSuccess(c, http.StatusOK, toBoardResponse(p), "")
+}
+
+// MoveTask godoc
+// @Summary Move a task on the board
+// @Description Change a task's status and its position within the status's column in one step. Status changes
+// @Description follow the workflow's transitions and WIP limits; tasks in other columns keep their order.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param taskId path string true "Task ID"
+// @Param request body MoveTaskRequest true "Move Task Request"
+// @Success 200 {object} APIResponse{data=BoardResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Failure 409 {object} APIErrorResponse
+// @Router /projects/{id}/tasks/{taskId}/move [post]
+func (h *BoardHandler) MoveTask(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+	var req MoveTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	p, err := h.moveTask.Execute(c.Request.Context(), projectUC.MoveTaskInput{
+		ProjectID: c.Param("id"),
+		UserID:    userID,
+		TaskID:    c.Param("taskId"),
+		Status:    project.TaskStatus(req.Status),
+		Position:  req.Position,
+		Force:     req.Force,
+	})
+	if err != nil {
+		sendProjectError(c, "MOVE_TASK_FAILED", err)
+		return
+	}
+	SendSuccess(c, http.StatusOK, toBoardResponse(p), "Task moved")
+}
+
+func toBoardResponse(p *project.Project) BoardResponse {
+	board := p.Board()
+	columns := make([]BoardColumnDTO, len(board))
+	for i, column := range board {
+		s := column.Status
+		columns[i] = BoardColumnDTO{
+			WorkflowStatusDTO: WorkflowStatusDTO{Key: string(s.Key), Name: s.Name, Category: string(s.Category), WIPLimit: s.WIPLimit},
+			Count:             len(column.Tasks),
+			OverLimit:         s.WIPLimit > 0 && len(column.Tasks) > s.WIPLimit,
+			Tasks:             make([]BoardTaskDTO, len(column.Tasks)),
+		}
+		for n := range column.Tasks {
+			columns[i].Tasks[n] = BoardTaskDTO{TaskDTO: toTaskDTO(&column.Tasks[n]), Position: n}
+		}
+	}
+	return BoardResponse{ProjectID: p.ID.String(), Columns: columns}
+}
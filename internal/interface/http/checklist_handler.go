@@ -0,0 +1,165 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	projectUC "github.com/tomtom2k/kairo-anchor-server/internal/usecase/project"
+)
+
+type ChecklistHandler struct {
+	addItem    *projectUC.AddChecklistItemUseCase
+	updateItem *projectUC.UpdateChecklistItemUseCase
+	deleteItem *projectUC.DeleteChecklistItemUseCase
+	reorder    *projectUC.ReorderChecklistUseCase
+}
+
+func NewChecklistHandler(
+	addItem *projectUC.AddChecklistItemUseCase,
+	updateItem *projectUC.UpdateChecklistItemUseCase,
+	deleteItem *projectUC.DeleteChecklistItemUseCase,
+	reorder *projectUC.ReorderChecklistUseCase,
+) *ChecklistHandler {
+	return &ChecklistHandler{addItem: addItem, updateItem: updateItem, deleteItem: deleteItem, reorder: reorder}
+}
+
+// AddChecklistItem godoc
+// @Summary Add a checklist item to a task
+// @Description Checklist items are lightweight steps within a task. Unfinished tasks count towards project
+// @Description progress as far as their checklist is done.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param taskId path string true "Task ID"
+// @Param request body CreateChecklistItemRequest true "Create Checklist Item Request"
+// @Success 201 {object} APIResponse{data=ProjectResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /projects/{id}/tasks/{taskId}/checklist [post]
+func (h *ChecklistHandler) AddChecklistItem(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+	var req CreateChecklistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	p, err := h.addItem.Execute(c.Request.Context(), c.Param("id"), userID, c.Param("taskId"), req.Text)
+	if err != nil {
+		sendProjectError(c, "ADD_CHECKLIST_ITEM_FAILED", err)
+		return
+	}
+	SendSuccess(c, http.StatusCreated, toProjectResponse(p), "Checklist item added")
+}
+
+// UpdateChecklistItem godoc
+// @Summary Update a checklist item
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param taskId path string true "Task ID"
+// @Param itemId path string true "Checklist item ID"
+// @Param request body UpdateChecklistItemRequest true "Update Checklist Item Request"
+// @Success 200 {object} APIResponse{data=ProjectResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /projects/{id}/tasks/{taskId}/checklist/{itemId} [put]
+func (h *ChecklistHandler) UpdateChecklistItem(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+	var req UpdateChecklistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	p, err := h.updateItem.Execute(c.Request.Context(), projectUC.UpdateChecklistItemInput{
+		ProjectID: c.Param("id"),
+		UserID:    userID,
+		TaskID:    c.Param("taskId"),
+		ItemID:    c.Param("itemId"),
+		Text:      req.Text,
+		Done:      req.Done,
+	})
+	if err != nil {
+		sendProjectError(c, "UPDATE_CHECKLIST_ITEM_FAILED", err)
+		return
+	}
+	SendSuccess(c, http.StatusOK, toProjectResponse(p), "Checklist item updated")
+}
+
+// DeleteChecklistItem godoc
+// @Summary Delete a checklist item
+// @Tags projects
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param taskId path string true "Task ID"
+// @Param itemId path string true "Checklist item ID"
+// @Success 200 {object} APIResponse{data=ProjectResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /projects/{id}/tasks/{taskId}/checklist/{itemId} [delete]
+func (h *ChecklistHandler) DeleteChecklistItem(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	p, err := h.deleteItem.Execute(c.Request.Context(), c.Param("id"), userID, c.Param("taskId"), c.Param("itemId"))
+	if err != nil {
+		sendProjectError(c, "DELETE_CHECKLIST_ITEM_FAILED", err)
+		return
+	}
+	SendSuccess(c, http.StatusOK, toProjectResponse(p), "Checklist item deleted")
+}
+
+// ReorderChecklist godoc
+// @Summary Reorder a task's checklist
+// @Description Items left out keep their order after the listed ones
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param taskId path string true "Task ID"
+// @Param request body ReorderChecklistRequest true "Ordered list of checklist item IDs"
+// @Success 200 {object} APIResponse{data=ProjectResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /projects/{id}/tasks/{taskId}/checklist/order [put]
+func (h *ChecklistHandler) ReorderChecklist(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+	var req ReorderChecklistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	p, err := h.reorder.Execute(c.Request.Context(), c.Param("id"), userID, c.Param("taskId"), req.ItemIDs)
+	if err != nil {
+		sendProjectError(c, "REORDER_CHECKLIST_FAILED", err)
+		return
+	}
+	SendSuccess(c, http.StatusOK, toProjectResponse(p), "Checklist reordered")
+}
@@ -0,0 +1,271 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	projectUC "github.com/tomtom2k/kairo-anchor-server/internal/usecase/project"
+)
+
+type CommentHandler struct {
+	listComments  *projectUC.ListCommentsUseCase
+	addComment    *projectUC.AddCommentUseCase
+	editComment   *projectUC.EditCommentUseCase
+	deleteComment *projectUC.DeleteCommentUseCase
+	history       *projectUC.ListCommentHistoryUseCase
+}
+
+func NewCommentHandler(
+	list *projectUC.ListCommentsUseCase,
+	add *projectUC.AddCommentUseCase,
+	edit *projectUC.EditCommentUseCase,
+	remove *projectUC.DeleteCommentUseCase,
+	history *projectUC.ListCommentHistoryUseCase,
+) *CommentHandler {
+	return &CommentHandler{
+		listComments:  list,
+		addComment:    add,
+		editComment:   edit,
+		deleteComment: remove,
+		history:       history,
+	}
+}
+
+// ListComments godoc
+// @Summary List task comments
+// @Description Threads of comments on a task, oldest first. Pagination counts threads; replies are nested in their thread.
+// @Tags projects
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID (UUID)"
+// @Param taskId path string true "Task ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} APIListResponse{data=ListData{items=[]CommentResponse}}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Router /projects/{id}/tasks/{taskId}/comments [get]
+func (h *CommentHandler) ListComments(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	result, err := h.listComments.Execute(c.Request.Context(), projectUC.ListCommentsInput{
+		ProjectID: c.Param("id"),
+		UserID:    userID,
+		TaskID:    c.Param("taskId"),
+		Page:      queryInt(c, "page", 1),
+		PageSize:  queryInt(c, "page_size", 20),
+	})
+	if err != nil {
+		sendCommentError(c, "LIST_COMMENTS_FAILED", err)
+		return
+	}
+
+	items := make([]CommentResponse, len(result.Threads))
+	for i := range result.Threads {
+		items[i] = toCommentResponse(&result.Threads[i])
+	}
+	SendSuccessList(c, items, newPaginationMeta(result.Total, result.Page, result.PageSize))
+}
+
+// AddComment godoc
+// @Summary Comment on a task
+// @Description Any project member may comment. Set parentId to reply in a thread. Members mentioned as @name or @email are notified.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID (UUID)"
+// @Param taskId path string true "Task ID"
+// @Param request body CreateCommentRequest true "Create Comment Request"
+// @Success 201 {object} APIResponse{data=CommentResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 404 {object} APIErrorResponse
+// @Router /projects/{id}/tasks/{taskId}/comments [post]
+func (h *CommentHandler) AddComment(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req CreateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	comment, err := h.addComment.Execute(c.Request.Context(), projectUC.AddCommentInput{
+		ProjectID: c.Param("id"),
+		UserID:    userID,
+		TaskID:    c.Param("taskId"),
+		ParentID:  req.ParentID,
+		Body:      req.Body,
+	})
+	if err != nil {
+		sendCommentError(c, "ADD_COMMENT_FAILED", err)
+		return
+	}
+
+	SendSuccess(c, http.StatusCreated, toCommentResponse(comment), "Comment added successfully")
+}
+
+// UpdateComment godoc
+// @Summary Edit a comment
+// @Description Only the author may edit a comment; the previous text is kept in its history
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID (UUID)"
+// @Param taskId path string true "Task ID"
+// @Param commentId path string true "Comment ID (UUID)"
+// @Param request body UpdateCommentRequest true "Update Comment Request"
+// @Success 200 {object} APIResponse{data=CommentResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Failure 404 {object} APIErrorResponse
+// @Router /projects/{id}/tasks/{taskId}/comments/{commentId} [put]
+func (h *CommentHandler) UpdateComment(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req UpdateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	comment, err := h.editComment.Execute(c.Request.Context(), projectUC.EditCommentInput{
+		ProjectID: c.Param("id"),
+		UserID:    userID,
+		TaskID:    c.Param("taskId"),
+		CommentID: c.Param("commentId"),
+		Body:      req.Body,
+	})
+	if err != nil {
+		sendCommentError(c, "UPDATE_COMMENT_FAILED", err)
+		return
+	}
+
+	SendSuccess(c, http.StatusOK, toCommentResponse(comment), "Comment updated successfully")
+}
+
+// DeleteComment godoc
+// @Summary Delete a comment
+// @Description Authors may delete their own comments and project owners may delete any. Replies are kept.
+// @Tags projects
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID (UUID)"
+// @Param taskId path string true "Task ID"
+// @Param commentId path string true "Comment ID (UUID)"
+// @Success 200 {object} APIResponse
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Failure 404 {object} APIErrorResponse
+// @Router /projects/{id}/tasks/{taskId}/comments/{commentId} [delete]
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	err = h.deleteComment.Execute(c.Request.Context(), c.Param("id"), userID, c.Param("taskId"), c.Param("commentId"))
+	if err != nil {
+		sendCommentError(c, "DELETE_COMMENT_FAILED", err)
+		return
+	}
+
+	SendSuccess(c, http.StatusOK, nil, "Comment deleted successfully")
+}
+
+// CommentHistory godoc
+// @Summary List a comment's edit history
+// @Description Earlier versions of a comment, newest first
+// @Tags projects
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID (UUID)"
+// @Param taskId path string true "Task ID"
+// @Param commentId path string true "Comment ID (UUID)"
+// @Success 200 {object} APIResponse{data=[]CommentEditResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 404 {object} APIErrorResponse
+// @Router /projects/{id}/tasks/{taskId}/comments/{commentId}/history [get]
+func (h *CommentHandler) CommentHistory(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	edits, err := h.history.Execute(c.Request.Context(), c.Param("id"), userID, c.Param("taskId"), c.Param("commentId"))
+	if err != nil {
+		sendCommentError(c, "COMMENT_HISTORY_FAILED", err)
+		return
+	}
+
+	response := make([]CommentEditResponse, len(edits))
+	for i, e := range edits {
+		response[i] = CommentEditResponse{
+			Body:     e.Body,
+			EditedBy: uuidString(e.EditedBy),
+			EditedAt: e.EditedAt,
+		}
+	}
+	SendSuccess(c, http.StatusOK, response, "")
+}
+
+func sendCommentError(c *gin.Context, code string, err error) {
+	if errors.Is(err, project.ErrCommentNotFound) {
+		SendError(c, http.StatusNotFound, ErrCodeNotFound, err.Error())
+		return
+	}
+	sendProjectError(c, code, err)
+}
+
+func toCommentResponse(comment *project.Comment) CommentResponse {
+	r := CommentResponse{
+		ID:         comment.ID.String(),
+		TaskID:     comment.TaskID,
+		ParentID:   uuidString(comment.ParentID),
+		AuthorID:   uuidString(comment.AuthorID),
+		AuthorName: comment.AuthorName,
+		Body:       comment.Body,
+		MentionIDs: comment.MentionIDs,
+		Edited:     comment.EditedAt != nil,
+		Deleted:    comment.DeletedAt != nil,
+		EditedAt:   comment.EditedAt,
+		CreatedAt:  comment.CreatedAt,
+	}
+	if r.MentionIDs == nil {
+		r.MentionIDs = []string{}
+	}
+	for i := range comment.Replies {
+		r.Replies = append(r.Replies, toCommentResponse(&comment.Replies[i]))
+	}
+	return r
+}
+
+func uuidString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
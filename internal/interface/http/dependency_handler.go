@@ -0,0 +1,155 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	projectUC "github.com/tomtom2k/kairo-anchor-server/internal/usecase/project"
+)
+
+type DependencyHandler struct {
+	addDependency    *projectUC.AddDependencyUseCase
+	removeDependency *projectUC.RemoveDependencyUseCase
+	criticalPath     *projectUC.GetCriticalPathUseCase
+}
+
+func NewDependencyHandler(
+	addDependency *projectUC.AddDependencyUseCase,
+	removeDependency *projectUC.RemoveDependencyUseCase,
+	criticalPath *projectUC.GetCriticalPathUseCase,
+) *DependencyHandler {
+	return &DependencyHandler{addDependency: addDependency, removeDependency: removeDependency, criticalPath: criticalPath}
+}
+
+// AddDependency godoc
+// @Summary Make a task wait for another task
+// @Description With finish_to_start (the default) the task cannot start until dependsOn is completed; with
+// @Description start_to_start it cannot start until dependsOn has started. Dependencies that would form a cycle,
+// @Description or link a task to its own parents or subtasks, are rejected.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param taskId path string true "ID of the task that waits"
+// @Param request body CreateDependencyRequest true "Create Dependency Request"
+// @Success 201 {object} APIResponse{data=ProjectResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /projects/{id}/tasks/{taskId}/dependencies [post]
+func (h *DependencyHandler) AddDependency(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+	var req CreateDependencyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	p, err := h.addDependency.Execute(c.Request.Context(), projectUC.AddDependencyInput{
+		ProjectID: c.Param("id"),
+		UserID:    userID,
+		TaskID:    c.Param("taskId"),
+		DependsOn: req.DependsOn,
+		Type:      project.DependencyType(req.Type),
+	})
+	if err != nil {
+		sendProjectError(c, "ADD_DEPENDENCY_FAILED", err)
+		return
+	}
+	SendSuccess(c, http.StatusCreated, toProjectResponse(p), "Dependency added")
+}
+
+// RemoveDependency godoc
+// @Summary Stop a task waiting for another task
+// @Tags projects
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param taskId path string true "ID of the task that waits"
+// @Param dependsOn path string true "ID of the task it waits for"
+// @Success 200 {object} APIResponse{data=ProjectResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /projects/{id}/tasks/{taskId}/dependencies/{dependsOn} [delete]
+func (h *DependencyHandler) RemoveDependency(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	p, err := h.removeDependency.Execute(c.Request.Context(), c.Param("id"), userID, c.Param("taskId"), c.Param("dependsOn"))
+	if err != nil {
+		sendProjectError(c, "REMOVE_DEPENDENCY_FAILED", err)
+		return
+	}
+	SendSuccess(c, http.StatusOK, toProjectResponse(p), "Dependency removed")
+}
+
+// CriticalPath godoc
+// @Summary Get a project's critical path
+// @Description Schedules the unfinished tasks from now (or the project's start date, if later) using their
+// @Description estimates and dependencies. Slack is how long a task can slip before it misses its due date or
+// @Description the project's end date; chain lists the tasks with the least slack in the order they are worked on.
+// @Description Without an end date the deadline is the projected finish. Negative slack means the plan is late.
+// @Tags projects
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Success 200 {object} APIResponse{data=CriticalPathResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Router /projects/{id}/critical-path [get]
+func (h *DependencyHandler) CriticalPath(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	path, err := h.criticalPath.Execute(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		sendProjectError(c, "CRITICAL_PATH_FAILED", err)
+		return
+	}
+	SendSuccess(c, http.StatusOK, toCriticalPathResponse(path), "")
+}
+
+func toCriticalPathResponse(path *project.CriticalPath) CriticalPathResponse {
+	tasks := make([]ScheduledTaskDTO, len(path.Tasks))
+	for i, t := range path.Tasks {
+		tasks[i] = ScheduledTaskDTO{
+			TaskID:         t.TaskID,
+			Title:          t.Title,
+			EstimateHours:  t.Duration.Hours(),
+			EarliestStart:  t.EarliestStart,
+			EarliestFinish: t.EarliestFinish,
+			LatestStart:    t.LatestStart,
+			LatestFinish:   t.LatestFinish,
+			SlackHours:     hours(t.Slack),
+			Critical:       t.Critical,
+		}
+	}
+	return CriticalPathResponse{
+		Start:      path.Start,
+		Finish:     path.Finish,
+		Deadline:   path.Deadline,
+		SlackHours: hours(path.Slack),
+		Late:       path.Slack < 0,
+		Chain:      path.Chain,
+		Tasks:      tasks,
+	}
+}
+
+// hours rounds a duration to hundredths of an hour
+func hours(d time.Duration) float64 {
+	return float64(d.Round(36*time.Second)) / float64(time.Hour)
+}
@@ -4,8 +4,15 @@ import "time"
 
 // Request DTOs
 type RegisterRequest struct {
-	Email    string `json:"email" binding:"required,email" example:"user@example.com"`
-	Password string `json:"password" binding:"required,min=6" example:"password123"`
+	Email             string `json:"email" binding:"required,email" example:"user@example.com"`
+	Password          string `json:"password" binding:"required" example:"Password123"`
+	InviteCode        string `json:"invite_code,omitempty" example:"3F9A1C2B7D4E8A60"`
+	ProjectInvitation string `json:"project_invitation,omitempty" example:"a1b2c3d4e5f6..."`
+}
+
+type RegisterResponse struct {
+	Activated bool   `json:"activated" example:"true"`
+	ProjectID string `json:"project_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
 }
 
 type LoginRequest struct {
@@ -23,20 +30,68 @@ type ForgotPasswordRequest struct {
 
 type ChangePasswordRequest struct {
 	Token       string `json:"token" binding:"required" example:"reset-token-here"`
-	NewPassword string `json:"new_password" binding:"required,min=6" example:"newpassword123"`
+	NewPassword string `json:"new_password" binding:"required" example:"NewPassword123"`
 }
 
 type ResetPasswordRequest struct {
 	OldPassword string `json:"old_password" binding:"required" example:"oldpassword123"`
-	NewPassword string `json:"new_password" binding:"required,min=6" example:"newpassword123"`
+	NewPassword string `json:"new_password" binding:"required" example:"NewPassword123"`
+}
+
+type RequestEmailChangeRequest struct {
+	NewEmail string `json:"new_email" binding:"required,email" example:"new@example.com"`
+	Password string `json:"password" binding:"required" example:"password123"`
+}
+
+type EmailTokenRequest struct {
+	Token string `json:"token" binding:"required" example:"email-token-here"`
+}
+
+type UpdateProfileRequest struct {
+	DisplayName *string `json:"display_name,omitempty" binding:"omitempty,max=100" example:"Nguyen Van A"`
+	AvatarURL   *string `json:"avatar_url,omitempty" binding:"omitempty,max=2048" example:"https://example.com/avatar.png"`
+	Timezone    *string `json:"timezone,omitempty" binding:"omitempty,max=64" example:"Asia/Ho_Chi_Minh"`
+	Locale      *string `json:"locale,omitempty" binding:"omitempty,max=35" example:"vi-VN"`
+	WeekStart   *string `json:"week_start,omitempty" binding:"omitempty,oneof=sunday monday tuesday wednesday thursday friday saturday" example:"monday"`
+}
+
+type MagicLinkRequest struct {
+	Email string `json:"email" binding:"required,email" example:"user@example.com"`
+	// BindDevice makes the link work only in the browser that requested it
+	BindDevice bool `json:"bind_device" example:"false"`
+}
+
+type VerifyMagicLinkRequest struct {
+	Token string `json:"token" binding:"required" example:"magic-link-token-here"`
+}
+
+type DeleteAccountRequest struct {
+	Password string `json:"password" binding:"required" example:"password123"`
 }
 
 
 // Response DTOs
 type ProfileResponse struct {
-	ID        string    `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
-	Email     string    `json:"email" example:"user@example.com"`
-	IsActive  bool      `json:"is_active" example:"true"`
-	CreatedAt time.Time `json:"created_at" example:"2024-01-01T00:00:00Z"`
-	UpdatedAt time.Time `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+	ID                  string     `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Email               string     `json:"email" example:"user@example.com"`
+	IsActive            bool       `json:"is_active" example:"true"`
+	Role                string     `json:"role" example:"user"`
+	DisplayName         string     `json:"display_name" example:"Nguyen Van A"`
+	AvatarURL           *string    `json:"avatar_url,omitempty" example:"https://example.com/avatar.png"`
+	Timezone            string     `json:"timezone" example:"Asia/Ho_Chi_Minh"`
+	Locale              string     `json:"locale" example:"vi-VN"`
+	WeekStart           string     `json:"week_start" example:"monday"`
+	PendingEmail        *string    `json:"pending_email,omitempty" example:"new@example.com"`
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty" example:"2024-01-15T00:00:00Z"`
+	CreatedAt           time.Time  `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt           time.Time  `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+}
+
+type LoginResponse struct {
+	Token string          `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	User  ProfileResponse `json:"user"`
+}
+
+type AccountDeletionResponse struct {
+	DeletionScheduledAt time.Time `json:"deletion_scheduled_at" example:"2024-01-15T00:00:00Z"`
 }
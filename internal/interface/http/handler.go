@@ -1,10 +1,15 @@
 package http
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
 	"github.com/tomtom2k/kairo-anchor-server/internal/usecase/auth"
+	"github.com/tomtom2k/kairo-anchor-server/pkg/password"
 )
 
 type Handler struct {
@@ -15,8 +20,17 @@ type Handler struct {
 	forgotPassword *auth.ForgotPasswordUseCase
 	changePassword *auth.ChangePasswordUseCase
 	resetPassword  *auth.ResetPasswordUseCase
+	requestEmail   *auth.RequestEmailChangeUseCase
+	confirmEmail   *auth.ConfirmEmailChangeUseCase
+	revertEmail    *auth.RevertEmailChangeUseCase
+	updateProfile  *auth.UpdateProfileUseCase
+	requestMagic   *auth.RequestMagicLinkUseCase
+	verifyMagic    *auth.VerifyMagicLinkUseCase
 }
 
+// magicLinkDeviceCookie holds the device secret of a device-bound magic link
+const magicLinkDeviceCookie = "kairo_magic_link_device"
+
 
 func NewHandler(
 	r *auth.RegisterUseCase,
@@ -26,6 +40,12 @@ func NewHandler(
 	fp *auth.ForgotPasswordUseCase,
 	cp *auth.ChangePasswordUseCase,
 	rp *auth.ResetPasswordUseCase,
+	re *auth.RequestEmailChangeUseCase,
+	ce *auth.ConfirmEmailChangeUseCase,
+	ve *auth.RevertEmailChangeUseCase,
+	up *auth.UpdateProfileUseCase,
+	rm *auth.RequestMagicLinkUseCase,
+	vm *auth.VerifyMagicLinkUseCase,
 ) *Handler {
 	return &Handler{
 		register:       r,
@@ -35,18 +55,24 @@ func NewHandler(
 		forgotPassword: fp,
 		changePassword: cp,
 		resetPassword:  rp,
+		requestEmail:   re,
+		confirmEmail:   ce,
+		revertEmail:    ve,
+		updateProfile:  up,
+		requestMagic:   rm,
+		verifyMagic:    vm,
 	}
 }
 
 
 // Register godoc
 // @Summary Register a new user
-// @Description Create a new user account and send activation email
+// @Description Create a new user account and send activation email. Signing up with a project invitation activates the account and joins the project, and works even when registration is invite-only or closed.
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param request body RegisterRequest true "Register Request"
-// @Success 201 {object} APIResponse
+// @Success 201 {object} APIResponse{data=RegisterResponse}
 // @Failure 400 {object} APIErrorResponse
 // @Router /auth/register [post]
 func (h *Handler) Register(c *gin.Context) {
@@ -57,13 +83,36 @@ func (h *Handler) Register(c *gin.Context) {
 		return
 	}
 
-	err := h.register.Execute(c.Request.Context(), req.Email, req.Password)
-	if err != nil {
+	result, err := h.register.Execute(c.Request.Context(), auth.RegisterInput{
+		Email:             req.Email,
+		Password:          req.Password,
+		InviteCode:        req.InviteCode,
+		ProjectInvitation: req.ProjectInvitation,
+	})
+	if sendPasswordPolicyError(c, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, user.ErrRegistrationClosed),
+		errors.Is(err, user.ErrEmailDomainNotAllowed):
+		SendError(c, http.StatusForbidden, ErrCodeRegistrationDenied, err.Error())
+		return
+	case errors.Is(err, user.ErrInviteCodeRequired),
+		errors.Is(err, user.ErrInvalidInviteCode),
+		errors.Is(err, project.ErrInvalidInvitation):
+		SendError(c, http.StatusForbidden, ErrCodeInvalidInvite, err.Error())
+		return
+	case err != nil:
 		SendError(c, http.StatusBadRequest, "REGISTRATION_FAILED", err.Error())
 		return
 	}
 
-	SendSuccess(c, http.StatusCreated, nil, "Registration successful, please check your email to activate your account")
+	response := RegisterResponse{Activated: result.Activated, ProjectID: result.ProjectID}
+	if result.Activated {
+		SendSuccess(c, http.StatusCreated, response, "Registration successful, you can now log in")
+		return
+	}
+	SendSuccess(c, http.StatusCreated, response, "Registration successful, please check your email to activate your account")
 }
 
 // Login godoc
@@ -91,15 +140,9 @@ func (h *Handler) Login(c *gin.Context) {
 		return
 	}
 
-	SendSuccess(c, http.StatusOK, gin.H{
-		"token": result.Token,
-		"user": ProfileResponse{
-			ID:        result.User.ID,
-			Email:     result.User.Email,
-			IsActive:  result.User.IsActive,
-			CreatedAt: result.User.CreatedAt,
-			UpdatedAt: result.User.UpdatedAt,
-		},
+	SendSuccess(c, http.StatusOK, LoginResponse{
+		Token: result.Token,
+		User:  toProfileResponse(result.User),
 	}, "Login successful")
 }
 
@@ -121,24 +164,18 @@ func (h *Handler) GetProfile(c *gin.Context) {
 		return
 	}
 
-	user, err := h.getProfile.Execute(c.Request.Context(), userID)
+	u, err := h.getProfile.Execute(c.Request.Context(), userID)
 	if err != nil {
 		SendInternalError(c, err)
 		return
 	}
 
-	if user == nil {
+	if u == nil {
 		SendError(c, http.StatusNotFound, ErrCodeNotFound, "User not found")
 		return
 	}
 
-	SendSuccess(c, http.StatusOK, ProfileResponse{
-		ID:        user.ID,
-		Email:     user.Email,
-		IsActive:  user.IsActive,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
-	}, "")
+	SendSuccess(c, http.StatusOK, toProfileResponse(u), "")
 }
 
 // ActivateAccount godoc
@@ -214,6 +251,9 @@ func (h *Handler) ChangePassword(c *gin.Context) {
 	}
 
 	err := h.changePassword.Execute(c.Request.Context(), req.Token, req.NewPassword)
+	if sendPasswordPolicyError(c, err) {
+		return
+	}
 	if err != nil {
 		SendError(c, http.StatusBadRequest, "PASSWORD_CHANGE_FAILED", err.Error())
 		return
@@ -248,10 +288,255 @@ func (h *Handler) ResetPassword(c *gin.Context) {
 	}
 
 	err = h.resetPassword.Execute(c.Request.Context(), userID, req.OldPassword, req.NewPassword)
+	if sendPasswordPolicyError(c, err) {
+		return
+	}
 	if err != nil {
 		SendError(c, http.StatusBadRequest, "PASSWORD_RESET_FAILED", err.Error())
 		return
 	}
 
 	SendSuccess(c, http.StatusOK, nil, "Password reset successfully")
-}
\ No newline at end of file
+}
+
+// RequestEmailChange godoc
+// @Summary Request an email change
+// @Description Send a confirmation link to the new address; the email is only changed once it is confirmed
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body RequestEmailChangeRequest true "Request Email Change Request"
+// @Success 200 {object} APIResponse
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 409 {object} APIErrorResponse
+// @Router /auth/email/change [post]
+func (h *Handler) RequestEmailChange(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req RequestEmailChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	err = h.requestEmail.Execute(c.Request.Context(), userID, req.NewEmail, req.Password)
+	if errors.Is(err, user.ErrEmailTaken) {
+		SendError(c, http.StatusConflict, ErrCodeEmailTaken, err.Error())
+		return
+	}
+	if err != nil {
+		SendError(c, http.StatusBadRequest, "EMAIL_CHANGE_FAILED", err.Error())
+		return
+	}
+
+	SendSuccess(c, http.StatusOK, nil, "Confirmation email sent to the new address")
+}
+
+// ConfirmEmailChange godoc
+// @Summary Confirm an email change
+// @Description Confirm the new address using the token sent to it; the old address is notified with an undo link
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body EmailTokenRequest true "Email Token Request"
+// @Success 200 {object} APIResponse
+// @Failure 400 {object} APIErrorResponse
+// @Failure 409 {object} APIErrorResponse
+// @Router /auth/email/confirm [post]
+func (h *Handler) ConfirmEmailChange(c *gin.Context) {
+	var req EmailTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	err := h.confirmEmail.Execute(c.Request.Context(), req.Token)
+	if errors.Is(err, user.ErrEmailTaken) {
+		SendError(c, http.StatusConflict, ErrCodeEmailTaken, err.Error())
+		return
+	}
+	if err != nil {
+		SendError(c, http.StatusBadRequest, "EMAIL_CHANGE_FAILED", err.Error())
+		return
+	}
+
+	SendSuccess(c, http.StatusOK, nil, "Email address changed successfully")
+}
+
+// RevertEmailChange godoc
+// @Summary Undo an email change
+// @Description Restore the previous email address using the undo token sent to it
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body EmailTokenRequest true "Email Token Request"
+// @Success 200 {object} APIResponse
+// @Failure 400 {object} APIErrorResponse
+// @Failure 409 {object} APIErrorResponse
+// @Router /auth/email/revert [post]
+func (h *Handler) RevertEmailChange(c *gin.Context) {
+	var req EmailTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	err := h.revertEmail.Execute(c.Request.Context(), req.Token)
+	if errors.Is(err, user.ErrEmailTaken) {
+		SendError(c, http.StatusConflict, ErrCodeEmailTaken, err.Error())
+		return
+	}
+	if err != nil {
+		SendError(c, http.StatusBadRequest, "EMAIL_REVERT_FAILED", err.Error())
+		return
+	}
+
+	SendSuccess(c, http.StatusOK, nil, "Email address restored, please reset your password if you did not request the change")
+}
+
+// UpdateProfile godoc
+// @Summary Update user profile
+// @Description Update display name, avatar, time zone, locale and week start day; only provided fields change
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body UpdateProfileRequest true "Update Profile Request"
+// @Success 200 {object} APIResponse{data=ProfileResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Router /auth/profile [patch]
+func (h *Handler) UpdateProfile(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	u, err := h.updateProfile.Execute(c.Request.Context(), auth.UpdateProfileInput{
+		UserID:      userID,
+		DisplayName: req.DisplayName,
+		AvatarURL:   req.AvatarURL,
+		Timezone:    req.Timezone,
+		Locale:      req.Locale,
+		WeekStart:   req.WeekStart,
+	})
+	if err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	SendSuccess(c, http.StatusOK, toProfileResponse(u), "Profile updated")
+}
+
+// RequestMagicLink godoc
+// @Summary Request a magic sign-in link
+// @Description Email a short-lived, single-use sign-in link. With bind_device the link only works in this browser.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body MagicLinkRequest true "Magic Link Request"
+// @Success 200 {object} APIResponse
+// @Failure 400 {object} APIErrorResponse
+// @Failure 429 {object} APIErrorResponse
+// @Router /auth/magic-link [post]
+func (h *Handler) RequestMagicLink(c *gin.Context) {
+	var req MagicLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	result, err := h.requestMagic.Execute(c.Request.Context(), req.Email, c.ClientIP(), req.BindDevice)
+	if errors.Is(err, user.ErrTooManyRequests) {
+		SendError(c, http.StatusTooManyRequests, ErrCodeTooManyRequests, err.Error())
+		return
+	}
+	if err != nil {
+		SendInternalError(c, err)
+		return
+	}
+
+	if result.DeviceSecret != "" {
+		c.SetSameSite(http.SameSiteLaxMode)
+		c.SetCookie(magicLinkDeviceCookie, result.DeviceSecret, int(result.ExpiresIn.Seconds()),
+			"/api/auth/magic-link", "", c.Request.TLS != nil, true)
+	}
+
+	SendSuccess(c, http.StatusOK, nil, "If an account exists for this email, a sign-in link has been sent")
+}
+
+// VerifyMagicLink godoc
+// @Summary Sign in with a magic link
+// @Description Exchange a magic link token for a JWT
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body VerifyMagicLinkRequest true "Verify Magic Link Request"
+// @Success 200 {object} APIResponse{data=LoginResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Router /auth/magic-link/verify [post]
+func (h *Handler) VerifyMagicLink(c *gin.Context) {
+	var req VerifyMagicLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	deviceSecret, _ := c.Cookie(magicLinkDeviceCookie)
+	result, err := h.verifyMagic.Execute(c.Request.Context(), req.Token, deviceSecret)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, err.Error())
+		return
+	}
+
+	// The device secret is single use as well
+	c.SetCookie(magicLinkDeviceCookie, "", -1, "/api/auth/magic-link", "", c.Request.TLS != nil, true)
+
+	SendSuccess(c, http.StatusOK, LoginResponse{
+		Token: result.Token,
+		User:  toProfileResponse(result.User),
+	}, "Login successful")
+}
+
+// sendPasswordPolicyError responds with every failed password rule, if err is a policy violation
+func sendPasswordPolicyError(c *gin.Context, err error) bool {
+	var pwErr *password.ValidationError
+	if !errors.As(err, &pwErr) {
+		return false
+	}
+	SendValidationError(c, ErrCodeWeakPassword, pwErr.Error(), pwErr.Violations)
+	return true
+}
+
+func toProfileResponse(u *user.User) ProfileResponse {
+	prefs := u.Preferences()
+	return ProfileResponse{
+		ID:                  u.ID,
+		Email:               u.Email,
+		IsActive:            u.IsActive,
+		Role:                string(u.Role),
+		DisplayName:         u.DisplayName,
+		AvatarURL:           u.AvatarURL,
+		Timezone:            prefs.Timezone,
+		Locale:              prefs.Locale,
+		WeekStart:           strings.ToLower(prefs.WeekStart.String()),
+		PendingEmail:        u.PendingEmail,
+		DeletionScheduledAt: u.DeletionScheduledAt,
+		CreatedAt:           u.CreatedAt,
+		UpdatedAt:           u.UpdatedAt,
+	}
+}
@@ -0,0 +1,183 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	projectUC "github.com/tomtom2k/kairo-anchor-server/internal/usecase/project"
+)
+
+type InvitationHandler struct {
+	invite  *projectUC.InviteMemberUseCase
+	list    *projectUC.ListInvitationsUseCase
+	accept  *projectUC.AcceptInvitationUseCase
+	decline *projectUC.DeclineInvitationUseCase
+}
+
+func NewInvitationHandler(
+	invite *projectUC.InviteMemberUseCase,
+	list *projectUC.ListInvitationsUseCase,
+	accept *projectUC.AcceptInvitationUseCase,
+	decline *projectUC.DeclineInvitationUseCase,
+) *InvitationHandler {
+	return &InvitationHandler{
+		invite:  invite,
+		list:    list,
+		accept:  accept,
+		decline: decline,
+	}
+}
+
+// InviteMember godoc
+// @Summary Invite someone to a project
+// @Description Email an invitation to join the project (owners only). The address does not need an account yet.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID (UUID)"
+// @Param request body InviteMemberRequest true "Invite Member Request"
+// @Success 201 {object} APIResponse{data=InvitationResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /projects/{id}/invitations [post]
+func (h *InvitationHandler) InviteMember(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req InviteMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	invitation, err := h.invite.Execute(c.Request.Context(), projectUC.InviteMemberInput{
+		ProjectID: c.Param("id"),
+		UserID:    userID,
+		Email:     req.Email,
+		Role:      project.MemberRole(req.Role),
+	})
+	if err != nil {
+		sendProjectError(c, "INVITE_MEMBER_FAILED", err)
+		return
+	}
+
+	SendSuccess(c, http.StatusCreated, toInvitationResponse(invitation), "Invitation sent successfully")
+}
+
+// ListInvitations godoc
+// @Summary List project invitations
+// @Tags projects
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID (UUID)"
+// @Success 200 {object} APIResponse{data=[]InvitationResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /projects/{id}/invitations [get]
+func (h *InvitationHandler) ListInvitations(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	invitations, err := h.list.Execute(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		sendProjectError(c, "LIST_INVITATIONS_FAILED", err)
+		return
+	}
+
+	response := make([]InvitationResponse, len(invitations))
+	for i := range invitations {
+		response[i] = toInvitationResponse(&invitations[i])
+	}
+	SendSuccess(c, http.StatusOK, response, "")
+}
+
+// AcceptInvitation godoc
+// @Summary Accept a project invitation
+// @Description Join the project as the signed-in user. The invitation must have been sent to the user's email.
+// @Tags invitations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body InvitationTokenRequest true "Invitation Token"
+// @Success 200 {object} APIResponse{data=InvitationResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Router /invitations/accept [post]
+func (h *InvitationHandler) AcceptInvitation(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req InvitationTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	invitation, err := h.accept.Execute(c.Request.Context(), req.Token, userID)
+	if err != nil {
+		sendInvitationError(c, "ACCEPT_INVITATION_FAILED", err)
+		return
+	}
+
+	SendSuccess(c, http.StatusOK, toInvitationResponse(invitation), "Invitation accepted")
+}
+
+// DeclineInvitation godoc
+// @Summary Decline a project invitation
+// @Tags invitations
+// @Accept json
+// @Produce json
+// @Param request body InvitationTokenRequest true "Invitation Token"
+// @Success 200 {object} APIResponse
+// @Failure 400 {object} APIErrorResponse
+// @Router /invitations/decline [post]
+func (h *InvitationHandler) DeclineInvitation(c *gin.Context) {
+	var req InvitationTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	if err := h.decline.Execute(c.Request.Context(), req.Token); err != nil {
+		sendInvitationError(c, "DECLINE_INVITATION_FAILED", err)
+		return
+	}
+
+	SendSuccess(c, http.StatusOK, nil, "Invitation declined")
+}
+
+func sendInvitationError(c *gin.Context, code string, err error) {
+	if errors.Is(err, project.ErrInvalidInvitation) {
+		SendError(c, http.StatusBadRequest, ErrCodeInvalidInvite, err.Error())
+		return
+	}
+	SendError(c, http.StatusBadRequest, code, err.Error())
+}
+
+func toInvitationResponse(i *project.Invitation) InvitationResponse {
+	return InvitationResponse{
+		ID:          i.ID.String(),
+		ProjectID:   i.ProjectID.String(),
+		ProjectName: i.ProjectName,
+		Email:       i.Email,
+		Role:        string(i.Role),
+		ExpiresAt:   i.ExpiresAt,
+		AcceptedAt:  i.AcceptedAt,
+		DeclinedAt:  i.DeclinedAt,
+		CreatedAt:   i.CreatedAt,
+	}
+}
@@ -0,0 +1,270 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/label"
+	projectUC "github.com/tomtom2k/kairo-anchor-server/internal/usecase/project"
+	workspaceUC "github.com/tomtom2k/kairo-anchor-server/internal/usecase/workspace"
+)
+
+type LabelHandler struct {
+	list          *workspaceUC.ListLabelsUseCase
+	create        *workspaceUC.CreateLabelUseCase
+	update        *workspaceUC.UpdateLabelUseCase
+	delete        *workspaceUC.DeleteLabelUseCase
+	projectLabels *projectUC.ListProjectLabelsUseCase
+	search        *projectUC.SearchUseCase
+}
+
+func NewLabelHandler(
+	list *workspaceUC.ListLabelsUseCase,
+	create *workspaceUC.CreateLabelUseCase,
+	update *workspaceUC.UpdateLabelUseCase,
+	delete *workspaceUC.DeleteLabelUseCase,
+	projectLabels *projectUC.ListProjectLabelsUseCase,
+	search *projectUC.SearchUseCase,
+) *LabelHandler {
+	return &LabelHandler{
+		list:          list,
+		create:        create,
+		update:        update,
+		delete:        delete,
+		projectLabels: projectLabels,
+		search:        search,
+	}
+}
+
+// ListLabels godoc
+// @Summary List workspace labels
+// @Tags workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Workspace ID (UUID)"
+// @Success 200 {object} APIResponse{data=[]LabelResponse}
+// @Failure 401 {object} APIErrorResponse
+// @Failure 404 {object} APIErrorResponse
+// @Router /workspaces/{id}/labels [get]
+func (h *LabelHandler) ListLabels(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	labels, err := h.list.Execute(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		sendWorkspaceError(c, "LIST_LABELS_FAILED", err)
+		return
+	}
+	SendSuccess(c, http.StatusOK, toLabelResponses(labels), "")
+}
+
+// CreateLabel godoc
+// @Summary Create a label
+// @Description Add a label that tasks and projects of the workspace can carry (any member)
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Workspace ID (UUID)"
+// @Param request body CreateLabelRequest true "Create Label Request"
+// @Success 201 {object} APIResponse{data=LabelResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 409 {object} APIErrorResponse
+// @Router /workspaces/{id}/labels [post]
+func (h *LabelHandler) CreateLabel(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+	var req CreateLabelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	l, err := h.create.Execute(c.Request.Context(), workspaceUC.CreateLabelInput{
+		WorkspaceID: c.Param("id"),
+		UserID:      userID,
+		Name:        req.Name,
+		Color:       req.Color,
+	})
+	if err != nil {
+		sendWorkspaceError(c, "CREATE_LABEL_FAILED", err)
+		return
+	}
+	SendSuccess(c, http.StatusCreated, toLabelResponse(l), "Label created successfully")
+}
+
+// UpdateLabel godoc
+// @Summary Update a label
+// @Description Rename or recolor a label (owners and admins); every task and project carrying it follows
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Workspace ID (UUID)"
+// @Param labelId path string true "Label ID (UUID)"
+// @Param request body UpdateLabelRequest true "Update Label Request"
+// @Success 200 {object} APIResponse{data=LabelResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Failure 404 {object} APIErrorResponse
+// @Failure 409 {object} APIErrorResponse
+// @Router /workspaces/{id}/labels/{labelId} [put]
+func (h *LabelHandler) UpdateLabel(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+	var req UpdateLabelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	l, err := h.update.Execute(c.Request.Context(), workspaceUC.UpdateLabelInput{
+		WorkspaceID: c.Param("id"),
+		UserID:      userID,
+		LabelID:     c.Param("labelId"),
+		Name:        req.Name,
+		Color:       req.Color,
+	})
+	if err != nil {
+		sendWorkspaceError(c, "UPDATE_LABEL_FAILED", err)
+		return
+	}
+	SendSuccess(c, http.StatusOK, toLabelResponse(l), "Label updated successfully")
+}
+
+// DeleteLabel godoc
+// @Summary Delete a label
+// @Description Delete a label and take it off every task and project (owners and admins)
+// @Tags workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Workspace ID (UUID)"
+// @Param labelId path string true "Label ID (UUID)"
+// @Success 200 {object} APIResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Failure 404 {object} APIErrorResponse
+// @Router /workspaces/{id}/labels/{labelId} [delete]
+func (h *LabelHandler) DeleteLabel(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := h.delete.Execute(c.Request.Context(), c.Param("id"), userID, c.Param("labelId")); err != nil {
+		sendWorkspaceError(c, "DELETE_LABEL_FAILED", err)
+		return
+	}
+	SendSuccess(c, http.StatusOK, nil, "Label deleted successfully")
+}
+
+// ListProjectLabels godoc
+// @Summary List the labels a project can use
+// @Description The labels of the project's workspace, for members who may not belong to the workspace
+// @Tags projects
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Success 200 {object} APIResponse{data=[]LabelResponse}
+// @Failure 401 {object} APIErrorResponse
+// @Failure 404 {object} APIErrorResponse
+// @Router /projects/{id}/labels [get]
+func (h *LabelHandler) ListProjectLabels(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	labels, err := h.projectLabels.Execute(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		SendError(c, http.StatusNotFound, ErrCodeNotFound, err.Error())
+		return
+	}
+	SendSuccess(c, http.StatusOK, toLabelResponses(labels), "")
+}
+
+// Search godoc
+// @Summary Search projects and tasks
+// @Description Find projects by name or description and tasks by title in the current workspace, ignoring case.
+// @Description With labels, only results carrying all of them are returned. At most 100 of each are returned.
+// @Tags projects
+// @Produce json
+// @Security BearerAuth
+// @Param X-Workspace-ID header string false "Workspace ID, defaults to the personal workspace"
+// @Param q query string false "Text to look for"
+// @Param labels query string false "Comma-separated label IDs"
+// @Success 200 {object} APIResponse{data=SearchResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Router /search [get]
+func (h *LabelHandler) Search(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+	workspaceID, err := GetWorkspaceID(c)
+	if err != nil {
+		SendInternalError(c, err)
+		return
+	}
+
+	result, err := h.search.Execute(c.Request.Context(), projectUC.SearchInput{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		Query:       c.Query("q"),
+		LabelIDs:    queryList(c, "labels"),
+	})
+	if err != nil {
+		SendError(c, http.StatusBadRequest, "SEARCH_FAILED", err.Error())
+		return
+	}
+
+	response := SearchResponse{
+		Projects: make([]ProjectResponse, len(result.Projects)),
+		Tasks:    make([]AssignedTaskDTO, len(result.Tasks)),
+	}
+	for i := range result.Projects {
+		response.Projects[i] = *toProjectResponse(&result.Projects[i])
+	}
+	for i, m := range result.Tasks {
+		response.Tasks[i] = AssignedTaskDTO{
+			TaskDTO:     toTaskDTO(&m.Task),
+			ProjectID:   m.ProjectID.String(),
+			ProjectName: m.ProjectName,
+		}
+	}
+	SendSuccess(c, http.StatusOK, response, "")
+}
+
+func toLabelResponse(l *label.Label) LabelResponse {
+	return LabelResponse{
+		ID:          l.ID.String(),
+		WorkspaceID: l.WorkspaceID.String(),
+		Name:        l.Name,
+		Color:       l.Color,
+		CreatedAt:   l.CreatedAt,
+		UpdatedAt:   l.UpdatedAt,
+	}
+}
+
+func toLabelResponses(labels []label.Label) []LabelResponse {
+	response := make([]LabelResponse, len(labels))
+	for i := range labels {
+		response[i] = toLabelResponse(&labels[i])
+	}
+	return response
+}
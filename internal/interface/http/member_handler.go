@@ -0,0 +1,180 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	projectUC "github.com/tomtom2k/kairo-anchor-server/internal/usecase/project"
+)
+
+type MemberHandler struct {
+	listMembers  *projectUC.ListMembersUseCase
+	addMember    *projectUC.AddMemberUseCase
+	updateRole   *projectUC.UpdateMemberRoleUseCase
+	removeMember *projectUC.RemoveMemberUseCase
+}
+
+func NewMemberHandler(
+	list *projectUC.ListMembersUseCase,
+	add *projectUC.AddMemberUseCase,
+	updateRole *projectUC.UpdateMemberRoleUseCase,
+	remove *projectUC.RemoveMemberUseCase,
+) *MemberHandler {
+	return &MemberHandler{
+		listMembers:  list,
+		addMember:    add,
+		updateRole:   updateRole,
+		removeMember: remove,
+	}
+}
+
+// ListMembers godoc
+// @Summary List project members
+// @Tags projects
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID (UUID)"
+// @Success 200 {object} APIResponse{data=[]MemberResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Router /projects/{id}/members [get]
+func (h *MemberHandler) ListMembers(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	members, err := h.listMembers.Execute(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		sendProjectError(c, "LIST_MEMBERS_FAILED", err)
+		return
+	}
+
+	response := make([]MemberResponse, len(members))
+	for i := range members {
+		response[i] = toMemberResponse(&members[i])
+	}
+	SendSuccess(c, http.StatusOK, response, "")
+}
+
+// AddMember godoc
+// @Summary Add a project member
+// @Description Share the project with an existing user (owners only)
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID (UUID)"
+// @Param request body AddMemberRequest true "Add Member Request"
+// @Success 201 {object} APIResponse{data=MemberResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /projects/{id}/members [post]
+func (h *MemberHandler) AddMember(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req AddMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	m, err := h.addMember.Execute(c.Request.Context(), projectUC.AddMemberInput{
+		ProjectID: c.Param("id"),
+		UserID:    userID,
+		Email:     req.Email,
+		Role:      project.MemberRole(req.Role),
+	})
+	if err != nil {
+		sendProjectError(c, "ADD_MEMBER_FAILED", err)
+		return
+	}
+
+	SendSuccess(c, http.StatusCreated, toMemberResponse(m), "Member added successfully")
+}
+
+// UpdateMemberRole godoc
+// @Summary Change a member's role
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID (UUID)"
+// @Param userId path string true "Member user ID (UUID)"
+// @Param request body UpdateMemberRoleRequest true "Update Member Role Request"
+// @Success 200 {object} APIResponse{data=MemberResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /projects/{id}/members/{userId} [put]
+func (h *MemberHandler) UpdateMemberRole(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req UpdateMemberRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	m, err := h.updateRole.Execute(c.Request.Context(), projectUC.UpdateMemberRoleInput{
+		ProjectID:    c.Param("id"),
+		UserID:       userID,
+		MemberUserID: c.Param("userId"),
+		Role:         project.MemberRole(req.Role),
+	})
+	if err != nil {
+		sendProjectError(c, "UPDATE_MEMBER_FAILED", err)
+		return
+	}
+
+	SendSuccess(c, http.StatusOK, toMemberResponse(m), "Member role updated successfully")
+}
+
+// RemoveMember godoc
+// @Summary Remove a project member
+// @Description Owners may remove any member; other members may remove themselves to leave the project
+// @Tags projects
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID (UUID)"
+// @Param userId path string true "Member user ID (UUID)"
+// @Success 200 {object} APIResponse
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /projects/{id}/members/{userId} [delete]
+func (h *MemberHandler) RemoveMember(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := h.removeMember.Execute(c.Request.Context(), c.Param("id"), userID, c.Param("userId")); err != nil {
+		sendProjectError(c, "REMOVE_MEMBER_FAILED", err)
+		return
+	}
+
+	SendSuccess(c, http.StatusOK, nil, "Member removed successfully")
+}
+
+func toMemberResponse(m *project.Member) MemberResponse {
+	return MemberResponse{
+		UserID:      m.UserID.String(),
+		Email:       m.Email,
+		DisplayName: m.DisplayName,
+		Role:        string(m.Role),
+		CreatedAt:   m.CreatedAt,
+	}
+}
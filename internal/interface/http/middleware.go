@@ -1,27 +1,58 @@
 package http
 
 import (
+	"context"
 	"errors"
 	"log"
 	"net/http"
 	"runtime/debug"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/workspace"
+	projectUC "github.com/tomtom2k/kairo-anchor-server/internal/usecase/project"
+	workspaceUC "github.com/tomtom2k/kairo-anchor-server/internal/usecase/workspace"
+	"github.com/tomtom2k/kairo-anchor-server/pkg/requestinfo"
 )
 
-const UserIDKey = "userID"
+const (
+	UserIDKey        = "userID"
+	UserRoleKey      = "userRole"
+	TokenIssuedKey   = "tokenIssuedAt"
+	TokenExpiresKey  = "tokenExpiresAt"
+	WorkspaceIDKey   = "workspaceID"
+	WorkspaceRoleKey = "workspaceRole"
+)
+
+// WorkspaceHeader selects the workspace a request acts in, the personal workspace when absent
+const WorkspaceHeader = "X-Workspace-ID"
+
+// RequestIDHeader carries the request ID; a well-formed ID from the client or a proxy is kept
+const RequestIDHeader = "X-Request-ID"
+
+const maxRequestIDLength = 64
 
 type AuthMiddleware struct {
 	tokenService TokenService
+	users        UserFinder
 }
 
 type TokenService interface {
-	Validate(token string) (string, error)
+	// ValidateWithTimes returns the user ID and when the token was issued and expires
+	ValidateWithTimes(token string) (string, time.Time, time.Time, error)
+}
+
+// UserFinder loads the account behind a token so bans and roles take effect immediately
+type UserFinder interface {
+	FindByID(ctx context.Context, id string) (*user.User, error)
 }
 
-func NewAuthMiddleware(ts TokenService) *AuthMiddleware {
-	return &AuthMiddleware{tokenService: ts}
+func NewAuthMiddleware(ts TokenService, users UserFinder) *AuthMiddleware {
+	return &AuthMiddleware{tokenService: ts, users: users}
 }
 
 // Recovery returns a middleware that recovers from panics and logs the error
@@ -30,7 +61,7 @@ func Recovery() gin.HandlerFunc {
 		defer func() {
 			if err := recover(); err != nil {
 				// Log the panic with stack trace
-				log.Printf("[PANIC RECOVERED] %v\n%s", err, debug.Stack())
+				log.Printf("[PANIC RECOVERED] request %s: %v\n%s", requestinfo.FromContext(c.Request.Context()).ID, err, debug.Stack())
 
 				// Send generic error response to client
 				SendError(c, http.StatusInternalServerError, ErrCodeInternal, "Something went wrong")
@@ -41,6 +72,36 @@ func Recovery() gin.HandlerFunc {
 	}
 }
 
+// RequestID tags every request with an ID, echoed in the response and kept in the request
+// context with the client's IP and user agent
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+		c.Header(RequestIDHeader, id)
+		c.Request = c.Request.WithContext(requestinfo.NewContext(c.Request.Context(), requestinfo.Info{
+			ID:        id,
+			IP:        c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+		}))
+		c.Next()
+	}
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+			return false
+		}
+	}
+	return true
+}
+
 func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get token from Authorization header
@@ -50,28 +111,110 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 			c.Abort()
 			return
 		}
+		m.authenticate(c, authHeader)
+	}
+}
 
-		// Check Bearer prefix
-		parts := strings.SplitN(authHeader, " ", 2)
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Invalid authorization header format")
-			c.Abort()
+// RequireStreamAuth works like RequireAuth but also accepts a stream ticket for the project in
+// the ticket query parameter, since browsers cannot set headers on EventSource connections.
+// Tickets are short-lived and single-use, so one showing up in an access log is harmless.
+func (m *AuthMiddleware) RequireStreamAuth(tickets *projectUC.RedeemStreamTicketUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if authHeader := c.GetHeader("Authorization"); authHeader != "" {
+			m.authenticate(c, authHeader)
 			return
 		}
 
-		token := parts[1]
-
-		// Validate token
-		userID, err := m.tokenService.Validate(token)
+		token := c.Query("ticket")
+		if token == "" {
+			SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Authorization header or stream ticket required")
+			c.Abort()
+			return
+		}
+		ticket, err := tickets.Execute(c.Request.Context(), token, c.Param("id"))
+		if errors.Is(err, project.ErrInvalidStreamTicket) {
+			SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, err.Error())
+			c.Abort()
+			return
+		}
 		if err != nil {
-			SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Invalid or expired token")
+			SendInternalError(c, err)
 			c.Abort()
 			return
 		}
+		m.admit(c, ticket.UserID.String(), ticket.TokenIssuedAt, ticket.AccessExpiresAt)
+	}
+}
 
-		// Store user ID in context
-		c.Set(UserIDKey, userID)
-		c.Next()
+func (m *AuthMiddleware) authenticate(c *gin.Context, authHeader string) {
+	// Check Bearer prefix
+	parts := strings.SplitN(authHeader, " ", 2)
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Invalid authorization header format")
+		c.Abort()
+		return
+	}
+
+	token := parts[1]
+
+	// Validate token
+	userID, issuedAt, expiresAt, err := m.tokenService.ValidateWithTimes(token)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Invalid or expired token")
+		c.Abort()
+		return
+	}
+	m.admit(c, userID, issuedAt, expiresAt)
+}
+
+// admit lets the request through as userID, whose credentials were issued at issuedAt and
+// expire at expiresAt
+func (m *AuthMiddleware) admit(c *gin.Context, userID string, issuedAt, expiresAt time.Time) {
+	// Make sure the account still exists and is allowed in
+	u, err := m.users.FindByID(c.Request.Context(), userID)
+	if err != nil {
+		SendInternalError(c, err)
+		c.Abort()
+		return
+	}
+	if u == nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Account no longer exists")
+		c.Abort()
+		return
+	}
+	if u.IsBanned() {
+		SendError(c, http.StatusForbidden, ErrCodeAccountBanned, "Account has been suspended")
+		c.Abort()
+		return
+	}
+	if !u.AcceptsTokenIssuedAt(issuedAt) {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Session has been revoked, please sign in again")
+		c.Abort()
+		return
+	}
+
+	// Store user ID and role in context
+	c.Set(UserIDKey, userID)
+	c.Set(UserRoleKey, u.Role)
+	c.Set(TokenIssuedKey, issuedAt)
+	c.Set(TokenExpiresKey, expiresAt)
+	c.Next()
+}
+
+// RequireRole only lets through users with one of the given roles.
+// It must be used after RequireAuth.
+func (m *AuthMiddleware) RequireRole(roles ...user.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, _ := c.Get(UserRoleKey)
+		for _, r := range roles {
+			if role == r {
+				c.Next()
+				return
+			}
+		}
+
+		SendError(c, http.StatusForbidden, ErrCodeForbidden, "You do not have permission to access this resource")
+		c.Abort()
 	}
 }
 
@@ -83,3 +226,63 @@ func GetUserID(c *gin.Context) (string, error) {
 	}
 	return userID.(string), nil
 }
+
+// GetTokenIssuedAt returns when the credentials of the request were issued, the zero time if unknown
+func GetTokenIssuedAt(c *gin.Context) time.Time {
+	issuedAt, _ := c.Get(TokenIssuedKey)
+	t, _ := issuedAt.(time.Time)
+	return t
+}
+
+// GetTokenExpiry returns when the credentials of the request expire, the zero time if unknown
+func GetTokenExpiry(c *gin.Context) time.Time {
+	expiresAt, _ := c.Get(TokenExpiresKey)
+	t, _ := expiresAt.(time.Time)
+	return t
+}
+
+type WorkspaceMiddleware struct {
+	resolve *workspaceUC.ResolveWorkspaceUseCase
+}
+
+func NewWorkspaceMiddleware(resolve *workspaceUC.ResolveWorkspaceUseCase) *WorkspaceMiddleware {
+	return &WorkspaceMiddleware{resolve: resolve}
+}
+
+// RequireWorkspace resolves the workspace from the X-Workspace-ID header and checks membership.
+// It must be used after RequireAuth.
+func (m *WorkspaceMiddleware) RequireWorkspace() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := GetUserID(c)
+		if err != nil {
+			SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+			c.Abort()
+			return
+		}
+
+		w, err := m.resolve.Execute(c.Request.Context(), userID, c.GetHeader(WorkspaceHeader))
+		if errors.Is(err, workspace.ErrNotMember) {
+			SendError(c, http.StatusForbidden, ErrCodeForbidden, err.Error())
+			c.Abort()
+			return
+		}
+		if err != nil {
+			SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+			c.Abort()
+			return
+		}
+
+		c.Set(WorkspaceIDKey, w.ID.String())
+		c.Set(WorkspaceRoleKey, w.Role)
+		c.Next()
+	}
+}
+
+// GetWorkspaceID extracts the workspace ID set by RequireWorkspace
+func GetWorkspaceID(c *gin.Context) (string, error) {
+	workspaceID, exists := c.Get(WorkspaceIDKey)
+	if !exists {
+		return "", errors.New("workspace ID not found in context")
+	}
+	return workspaceID.(string), nil
+}
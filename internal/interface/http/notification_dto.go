@@ -0,0 +1,50 @@
+package http
+
+import "time"
+
+// Notification DTOs
+
+type NotificationDataDTO struct {
+	ActorName   string `json:"actorName,omitempty" example:"Jane Doe"`
+	ProjectName string `json:"projectName,omitempty" example:"Warehouse system"`
+	TaskTitle   string `json:"taskTitle,omitempty" example:"Design database schema"`
+	Excerpt     string `json:"excerpt,omitempty" example:"@john can you review this?"`
+}
+
+type NotificationResponse struct {
+	ID        string              `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Type      string              `json:"type" example:"task_assigned"`
+	Title     string              `json:"title" example:"Jane Doe assigned you to \"Design database schema\""`
+	Body      string              `json:"body" example:"You were assigned to \"Design database schema\" in Warehouse system."`
+	ProjectID *string             `json:"projectId,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	TaskID    string              `json:"taskId,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Data      NotificationDataDTO `json:"data"`
+	Read      bool                `json:"read" example:"false"`
+	ReadAt    *time.Time          `json:"readAt,omitempty"`
+	CreatedAt time.Time           `json:"createdAt" example:"2024-01-01T00:00:00Z"`
+}
+
+// NotificationListResponse is a page of notifications plus the unread count for badges
+type NotificationListResponse struct {
+	Items       []NotificationResponse `json:"items"`
+	UnreadCount int                    `json:"unreadCount" example:"3"`
+	Meta        PaginationMeta         `json:"meta"`
+}
+
+type UnreadCountResponse struct {
+	UnreadCount int `json:"unreadCount" example:"3"`
+}
+
+type MarkAllReadResponse struct {
+	Marked int `json:"marked" example:"3"`
+}
+
+type NotificationPreferenceDTO struct {
+	Type  string `json:"type" binding:"required,oneof=task_assigned mention task_due_soon project_membership" example:"mention"`
+	InApp bool   `json:"inApp" example:"true"`
+	Email bool   `json:"email" example:"false"`
+}
+
+type UpdateNotificationPreferencesRequest struct {
+	Preferences []NotificationPreferenceDTO `json:"preferences" binding:"required,dive"`
+}
@@ -0,0 +1,243 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/notification"
+	notificationUC "github.com/tomtom2k/kairo-anchor-server/internal/usecase/notification"
+)
+
+type NotificationHandler struct {
+	list              *notificationUC.ListNotificationsUseCase
+	countUnread       *notificationUC.CountUnreadUseCase
+	markRead          *notificationUC.MarkReadUseCase
+	markAllRead       *notificationUC.MarkAllReadUseCase
+	getPreferences    *notificationUC.GetPreferencesUseCase
+	updatePreferences *notificationUC.UpdatePreferencesUseCase
+}
+
+func NewNotificationHandler(
+	list *notificationUC.ListNotificationsUseCase,
+	countUnread *notificationUC.CountUnreadUseCase,
+	markRead *notificationUC.MarkReadUseCase,
+	markAllRead *notificationUC.MarkAllReadUseCase,
+	getPreferences *notificationUC.GetPreferencesUseCase,
+	updatePreferences *notificationUC.UpdatePreferencesUseCase,
+) *NotificationHandler {
+	return &NotificationHandler{
+		list:              list,
+		countUnread:       countUnread,
+		markRead:          markRead,
+		markAllRead:       markAllRead,
+		getPreferences:    getPreferences,
+		updatePreferences: updatePreferences,
+	}
+}
+
+// ListNotifications godoc
+// @Summary List notifications
+// @Description The user's notifications, newest first, with the number still unread
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Param unread query bool false "Only unread notifications"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} APIResponse{data=NotificationListResponse}
+// @Failure 401 {object} APIErrorResponse
+// @Router /notifications [get]
+func (h *NotificationHandler) ListNotifications(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	unreadOnly := queryBool(c, "unread")
+	result, err := h.list.Execute(c.Request.Context(), notificationUC.ListNotificationsInput{
+		UserID:     userID,
+		UnreadOnly: unreadOnly != nil && *unreadOnly,
+		Page:       queryInt(c, "page", 1),
+		PageSize:   queryInt(c, "page_size", 20),
+	})
+	if err != nil {
+		SendInternalError(c, err)
+		return
+	}
+
+	items := make([]NotificationResponse, len(result.Notifications))
+	for i := range result.Notifications {
+		items[i] = toNotificationResponse(&result.Notifications[i])
+	}
+	SendSuccess(c, http.StatusOK, NotificationListResponse{
+		Items:       items,
+		UnreadCount: result.Unread,
+		Meta:        newPaginationMeta(result.Total, result.Page, result.PageSize),
+	}, "")
+}
+
+// UnreadCount godoc
+// @Summary Count unread notifications
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} APIResponse{data=UnreadCountResponse}
+// @Failure 401 {object} APIErrorResponse
+// @Router /notifications/unread-count [get]
+func (h *NotificationHandler) UnreadCount(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	count, err := h.countUnread.Execute(c.Request.Context(), userID)
+	if err != nil {
+		SendInternalError(c, err)
+		return
+	}
+	SendSuccess(c, http.StatusOK, UnreadCountResponse{UnreadCount: count}, "")
+}
+
+// MarkRead godoc
+// @Summary Mark a notification as read
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Notification ID (UUID)"
+// @Success 200 {object} APIResponse
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 404 {object} APIErrorResponse
+// @Router /notifications/{id}/read [post]
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := h.markRead.Execute(c.Request.Context(), userID, c.Param("id")); err != nil {
+		if errors.Is(err, notification.ErrNotFound) {
+			SendError(c, http.StatusNotFound, ErrCodeNotFound, err.Error())
+			return
+		}
+		SendError(c, http.StatusBadRequest, "MARK_READ_FAILED", err.Error())
+		return
+	}
+	SendSuccess(c, http.StatusOK, nil, "Notification marked as read")
+}
+
+// MarkAllRead godoc
+// @Summary Mark all notifications as read
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} APIResponse{data=MarkAllReadResponse}
+// @Failure 401 {object} APIErrorResponse
+// @Router /notifications/read-all [post]
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	marked, err := h.markAllRead.Execute(c.Request.Context(), userID)
+	if err != nil {
+		SendInternalError(c, err)
+		return
+	}
+	SendSuccess(c, http.StatusOK, MarkAllReadResponse{Marked: marked}, "All notifications marked as read")
+}
+
+// GetPreferences godoc
+// @Summary Get notification preferences
+// @Description How the user wants to hear about each type of notification, in the app and by email
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} APIResponse{data=[]NotificationPreferenceDTO}
+// @Failure 401 {object} APIErrorResponse
+// @Router /notifications/preferences [get]
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	prefs, err := h.getPreferences.Execute(c.Request.Context(), userID)
+	if err != nil {
+		SendInternalError(c, err)
+		return
+	}
+	SendSuccess(c, http.StatusOK, toPreferenceDTOs(prefs), "")
+}
+
+// UpdatePreferences godoc
+// @Summary Update notification preferences
+// @Description Types left out of the request keep their current setting
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body UpdateNotificationPreferencesRequest true "Update Notification Preferences Request"
+// @Success 200 {object} APIResponse{data=[]NotificationPreferenceDTO}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Router /notifications/preferences [put]
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req UpdateNotificationPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	input := make([]notification.Preference, len(req.Preferences))
+	for i, p := range req.Preferences {
+		input[i] = notification.Preference{Type: notification.Type(p.Type), InApp: p.InApp, Email: p.Email}
+	}
+	prefs, err := h.updatePreferences.Execute(c.Request.Context(), userID, input)
+	if err != nil {
+		SendError(c, http.StatusBadRequest, "UPDATE_PREFERENCES_FAILED", err.Error())
+		return
+	}
+	SendSuccess(c, http.StatusOK, toPreferenceDTOs(prefs), "Notification preferences updated successfully")
+}
+
+func toNotificationResponse(n *notification.Notification) NotificationResponse {
+	return NotificationResponse{
+		ID:        n.ID.String(),
+		Type:      string(n.Type),
+		Title:     n.Title,
+		Body:      n.Body,
+		ProjectID: uuidString(n.ProjectID),
+		TaskID:    n.TaskID,
+		Data: NotificationDataDTO{
+			ActorName:   n.Data.ActorName,
+			ProjectName: n.Data.ProjectName,
+			TaskTitle:   n.Data.TaskTitle,
+			Excerpt:     n.Data.Excerpt,
+		},
+		Read:      n.ReadAt != nil,
+		ReadAt:    n.ReadAt,
+		CreatedAt: n.CreatedAt,
+	}
+}
+
+func toPreferenceDTOs(prefs []notification.Preference) []NotificationPreferenceDTO {
+	dtos := make([]NotificationPreferenceDTO, len(prefs))
+	for i, p := range prefs {
+		dtos[i] = NotificationPreferenceDTO{Type: string(p.Type), InApp: p.InApp, Email: p.Email}
+	}
+	return dtos
+}
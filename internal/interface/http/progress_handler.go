@@ -0,0 +1,67 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	projectUC "github.com/tomtom2k/kairo-anchor-server/internal/usecase/project"
+)
+
+type ProgressHandler struct {
+	updateSettings *projectUC.UpdateProgressSettingsUseCase
+}
+
+func NewProgressHandler(updateSettings *projectUC.UpdateProgressSettingsUseCase) *ProgressHandler {
+	return &ProgressHandler{updateSettings: updateSettings}
+}
+
+// UpdateProgressSettings godoc
+// @Summary Change how a project's progress is calculated
+// @Description Tasks count the same (count), by their estimated hours (estimate), by their story points (points) or by
+// @Description priority, with high counting three times and medium twice as much as low (priority). Tasks without an
+// @Description estimate or points count as much as the average of their siblings. In manual mode progress is what
+// @Description "manual" says. inProgressCredit is the percentage a task in a doing status counts as done.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param request body ProgressSettingsDTO true "Progress Settings"
+// @Success 200 {object} APIResponse{data=ProjectResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /projects/{id}/progress [put]
+func (h *ProgressHandler) UpdateProgressSettings(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+	var req ProgressSettingsDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	settings := project.ProgressSettings{
+		Mode:             project.ProgressMode(req.Mode),
+		InProgressCredit: req.InProgressCredit,
+		Manual:           req.Manual,
+	}
+	p, err := h.updateSettings.Execute(c.Request.Context(), c.Param("id"), userID, settings)
+	if err != nil {
+		sendProjectError(c, "UPDATE_PROGRESS_SETTINGS_FAILED", err)
+		return
+	}
+	SendSuccess(c, http.StatusOK, toProjectResponse(p), "Progress settings updated")
+}
+
+func toProgressSettingsDTO(s project.ProgressSettings) ProgressSettingsDTO {
+	mode := s.Mode
+	if mode == "" {
+		mode = project.ProgressByCount
+	}
+	return ProgressSettingsDTO{Mode: string(mode), InProgressCredit: s.InProgressCredit, Manual: s.Manual}
+}
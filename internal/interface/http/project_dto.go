@@ -1,15 +1,79 @@
 package http
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // Project DTOs
 
 type TaskDTO struct {
-	ID       string    `json:"id" example:"t1"`
-	Title    string    `json:"title" binding:"required" example:"Thiết kế Database"`
-	Status   string    `json:"status" binding:"required,oneof=todo in-progress completed" example:"completed"`
-	Priority string    `json:"priority" binding:"required,oneof=low medium high" example:"high"`
-	DueDate  *time.Time `json:"dueDate,omitempty" example:"2024-02-01T00:00:00Z"`
+	ID            string             `json:"id" example:"t1"`
+	ParentID      string             `json:"parentId,omitempty" example:"t0"`
+	Title         string             `json:"title" binding:"required" example:"Thiết kế Database"`
+	Status        string             `json:"status" example:"completed"`
+	Priority      string             `json:"priority" binding:"required,oneof=low medium high" example:"high"`
+	DueDate       *time.Time         `json:"dueDate,omitempty" example:"2024-02-01T00:00:00Z"`
+	EstimateHours float64            `json:"estimateHours" example:"8"`
+	StoryPoints   float64            `json:"storyPoints" example:"5"`
+	AssigneeIDs   []string           `json:"assigneeIds" example:"123e4567-e89b-12d3-a456-426614174000"`
+	LabelIDs      []string           `json:"labelIds" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Checklist     []ChecklistItemDTO `json:"checklist"`
+	Dependencies  []DependencyDTO    `json:"dependencies"`
+	Recurrence    *RecurrenceDTO     `json:"recurrence,omitempty"`
+	Rank          string             `json:"rank,omitempty" example:"i"`
+}
+
+type ChecklistItemDTO struct {
+	ID   string `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Text string `json:"text" example:"Review the migration"`
+	Done bool   `json:"done" example:"false"`
+}
+
+type DependencyDTO struct {
+	TaskID string `json:"taskId" example:"t0"`
+	Type   string `json:"type" example:"finish_to_start"`
+}
+
+type RecurrenceDTO struct {
+	Rule       string          `json:"rule" example:"FREQ=WEEKLY;BYDAY=MO"`
+	Timezone   string          `json:"timezone" example:"Asia/Ho_Chi_Minh"`
+	SeriesID   string          `json:"seriesId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Start      time.Time       `json:"start" example:"2024-01-01T02:00:00Z"`
+	Occurrence time.Time       `json:"occurrence" example:"2024-01-08T02:00:00Z"`
+	Template   TaskTemplateDTO `json:"template"`
+}
+
+type TaskTemplateDTO struct {
+	Title         string   `json:"title" example:"Weekly report"`
+	Priority      string   `json:"priority" example:"medium"`
+	EstimateHours float64  `json:"estimateHours" example:"2"`
+	StoryPoints   float64  `json:"storyPoints" example:"1"`
+	AssigneeIDs   []string `json:"assigneeIds" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Checklist     []string `json:"checklist" example:"Collect numbers"`
+}
+
+type WorkflowDTO struct {
+	Statuses    []WorkflowStatusDTO `json:"statuses" binding:"required,min=1,max=20,dive"`
+	Transitions []TransitionDTO     `json:"transitions" binding:"max=400,dive"`
+}
+
+type WorkflowStatusDTO struct {
+	Key      string `json:"key" binding:"required,max=30" example:"review"`
+	Name     string `json:"name" binding:"max=50" example:"In review"`
+	Category string `json:"category" binding:"required,oneof=todo doing done" example:"doing"`
+	WIPLimit int    `json:"wipLimit" binding:"min=0,max=1000" example:"3"`
+}
+
+type TransitionDTO struct {
+	From string `json:"from" binding:"required" example:"in-progress"`
+	To   string `json:"to" binding:"required" example:"review"`
+}
+
+type AssignedTaskDTO struct {
+	TaskDTO
+	ProjectID   string `json:"projectId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ProjectName string `json:"projectName" example:"Hệ thống quản lý kho"`
 }
 
 type DocumentDTO struct {
@@ -27,6 +91,7 @@ type CreateProjectRequest struct {
 	Progress    int       `json:"progress" binding:"min=0,max=100" example:"65"`
 	StartDate   time.Time `json:"startDate" binding:"required" example:"2024-01-15T00:00:00Z"`
 	EndDate     *time.Time `json:"endDate,omitempty" example:"2024-06-30T00:00:00Z"`
+	LabelIDs    []string  `json:"labelIds,omitempty" binding:"omitempty,max=20,dive,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
 }
 
 type UpdateProjectRequest struct {
@@ -35,26 +100,121 @@ type UpdateProjectRequest struct {
 	Status      string     `json:"status,omitempty" binding:"omitempty,oneof=active pending completed" example:"active"`
 	StartDate   *time.Time `json:"startDate,omitempty" example:"2024-01-15T00:00:00Z"`
 	EndDate     *time.Time `json:"endDate,omitempty" example:"2024-06-30T00:00:00Z"`
+	LabelIDs    *[]string  `json:"labelIds,omitempty" binding:"omitempty,max=20,dive,uuid"`
 }
 
 type CreateTaskRequest struct {
-	Title    string     `json:"title" binding:"required" example:"Thiết kế Database"`
-	Status   string     `json:"status" binding:"required,oneof=todo in-progress completed" example:"todo"`
-	Priority string     `json:"priority" binding:"required,oneof=low medium high" example:"medium"`
-	DueDate  *time.Time `json:"dueDate,omitempty"`
+	ParentID      string             `json:"parentId,omitempty" example:"t0"`
+	Title         string             `json:"title" binding:"required" example:"Thiết kế Database"`
+	Status        string             `json:"status,omitempty" binding:"omitempty,max=30" example:"todo"`
+	Priority      string             `json:"priority" binding:"required,oneof=low medium high" example:"medium"`
+	DueDate       *time.Time         `json:"dueDate,omitempty"`
+	EstimateHours float64            `json:"estimateHours,omitempty" binding:"min=0,max=10000" example:"8"`
+	StoryPoints   float64            `json:"storyPoints,omitempty" binding:"min=0,max=1000" example:"5"`
+	AssigneeIDs   []string           `json:"assigneeIds,omitempty" binding:"omitempty,dive,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	LabelIDs      []string           `json:"labelIds,omitempty" binding:"omitempty,max=20,dive,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Recurrence    *RecurrenceRequest `json:"recurrence,omitempty"`
+}
+
+type RecurrenceRequest struct {
+	Rule     string `json:"rule" binding:"required,max=500" example:"FREQ=WEEKLY;BYDAY=MO"`
+	Timezone string `json:"timezone,omitempty" binding:"omitempty,max=64" example:"Asia/Ho_Chi_Minh"`
 }
 
 type UpdateTaskRequest struct {
-	Title    *string    `json:"title,omitempty"`
-	Status   *string    `json:"status,omitempty" binding:"omitempty,oneof=todo in-progress completed"`
-	Priority *string    `json:"priority,omitempty" binding:"omitempty,oneof=low medium high"`
-	DueDate  *time.Time `json:"dueDate,omitempty"`
+	ParentID      *string    `json:"parentId,omitempty" example:"t0"`
+	Title         *string    `json:"title,omitempty"`
+	Status        *string    `json:"status,omitempty" binding:"omitempty,max=30" example:"review"`
+	Priority      *string    `json:"priority,omitempty" binding:"omitempty,oneof=low medium high"`
+	DueDate       *time.Time `json:"dueDate,omitempty"`
+	EstimateHours *float64   `json:"estimateHours,omitempty" binding:"omitempty,min=0,max=10000" example:"8"`
+	StoryPoints   *float64   `json:"storyPoints,omitempty" binding:"omitempty,min=0,max=1000" example:"5"`
+	AssigneeIDs   *[]string  `json:"assigneeIds,omitempty" binding:"omitempty,dive,uuid"`
+	LabelIDs      *[]string  `json:"labelIds,omitempty" binding:"omitempty,max=20,dive,uuid"`
+	Force         bool       `json:"force,omitempty" example:"false"`
 }
 
 type ReorderTasksRequest struct {
 	TaskIDs []string `json:"taskIds" binding:"required" example:"id1,id2,id3"`
 }
 
+type RankTaskRequest struct {
+	After  string `json:"after" example:"t1"`
+	Before string `json:"before" example:"t2"`
+}
+
+type ReorderSubtasksRequest struct {
+	SubtaskIDs []string `json:"subtaskIds" binding:"required" example:"id1,id2,id3"`
+}
+
+type UpdateOccurrenceRequest struct {
+	Title         *string    `json:"title,omitempty" binding:"omitempty,min=1"`
+	Priority      *string    `json:"priority,omitempty" binding:"omitempty,oneof=low medium high"`
+	DueDate       *time.Time `json:"dueDate,omitempty"`
+	EstimateHours *float64   `json:"estimateHours,omitempty" binding:"omitempty,min=0,max=10000" example:"2"`
+	StoryPoints   *float64   `json:"storyPoints,omitempty" binding:"omitempty,min=0,max=1000" example:"1"`
+	AssigneeIDs   *[]string  `json:"assigneeIds,omitempty" binding:"omitempty,dive,uuid"`
+}
+
+type UpdateSeriesRequest struct {
+	UpdateOccurrenceRequest
+	Rule     *string `json:"rule,omitempty" binding:"omitempty,max=500" example:"FREQ=MONTHLY;BYMONTHDAY=-1"`
+	Timezone *string `json:"timezone,omitempty" binding:"omitempty,max=64" example:"Asia/Ho_Chi_Minh"`
+}
+
+type UpdateWorkflowRequest struct {
+	WorkflowDTO
+	// StatusMap moves the tasks on statuses that are removed, e.g. {"qa": "review"}
+	StatusMap map[string]string `json:"statusMap,omitempty"`
+}
+
+type ProgressSettingsDTO struct {
+	Mode             string `json:"mode" binding:"required,oneof=count estimate points priority manual" example:"estimate"`
+	InProgressCredit int    `json:"inProgressCredit" binding:"min=0,max=100" example:"50"`
+	Manual           int    `json:"manual" binding:"min=0,max=100" example:"0"`
+}
+
+type MoveTaskRequest struct {
+	Status   string `json:"status" binding:"required,max=30" example:"in-progress"`
+	Position int    `json:"position" binding:"min=0" example:"0"`
+	Force    bool   `json:"force" example:"false"`
+}
+
+type BoardResponse struct {
+	ProjectID string           `json:"projectId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Columns   []BoardColumnDTO `json:"columns"`
+}
+
+type BoardColumnDTO struct {
+	WorkflowStatusDTO
+	Count     int            `json:"count" example:"2"`
+	OverLimit bool           `json:"overLimit" example:"false"`
+	Tasks     []BoardTaskDTO `json:"tasks"`
+}
+
+type BoardTaskDTO struct {
+	TaskDTO
+	Position int `json:"position" example:"0"`
+}
+
+type CreateDependencyRequest struct {
+	DependsOn string `json:"dependsOn" binding:"required" example:"t0"`
+	Type      string `json:"type,omitempty" binding:"omitempty,oneof=finish_to_start start_to_start" example:"finish_to_start"`
+}
+
+type CreateChecklistItemRequest struct {
+	Text string `json:"text" binding:"required,max=500" example:"Review the migration"`
+}
+
+type UpdateChecklistItemRequest struct {
+	Text *string `json:"text,omitempty" binding:"omitempty,max=500" example:"Review the migration"`
+	Done *bool   `json:"done,omitempty" example:"true"`
+}
+
+type ReorderChecklistRequest struct {
+	ItemIDs []string `json:"itemIds" binding:"required" example:"id1,id2,id3"`
+}
+
 type CreateDocumentRequest struct {
 	Name string `json:"name" binding:"required" example:"Spec.pdf"`
 	Type string `json:"type" binding:"required" example:"pdf"`
@@ -68,9 +228,113 @@ type UpdateDocumentRequest struct {
 	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
 }
 
+
 type ProjectResponse struct {
-	ID          string        `json:"id" example:"1"`
-	UserID      string        `json:"userId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ID               string              `json:"id" example:"1"`
+	WorkspaceID      string              `json:"workspaceId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	UserID           string              `json:"userId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name             string              `json:"name" example:"Hệ thống quản lý kho"`
+	Description      string              `json:"description" example:"Xây dựng hệ thống quản lý kho thông minh"`
+	Status           string              `json:"status" example:"active"`
+	Progress         int                 `json:"progress" example:"65"`
+	StartDate        time.Time           `json:"startDate" example:"2024-01-15T00:00:00Z"`
+	EndDate          *time.Time          `json:"endDate,omitempty" example:"2024-06-30T00:00:00Z"`
+	Tasks            []TaskDTO           `json:"tasks"`
+	Documents        []DocumentDTO       `json:"documents"`
+	Revision         int                 `json:"revision" example:"12"`
+	CreatedAt        time.Time           `json:"createdAt" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt        time.Time           `json:"updatedAt" example:"2024-01-01T00:00:00Z"`
+	Role             string              `json:"role,omitempty" example:"owner"`
+	LabelIDs         []string            `json:"labelIds" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Workflow         WorkflowDTO         `json:"workflow"`
+	ProgressSettings ProgressSettingsDTO `json:"progressSettings"`
+}
+
+type SearchResponse struct {
+	Projects []ProjectResponse `json:"projects"`
+	Tasks    []AssignedTaskDTO `json:"tasks"`
+}
+
+type AddMemberRequest struct {
+	Email string `json:"email" binding:"required,email" example:"teammate@example.com"`
+	Role  string `json:"role" binding:"required,oneof=owner editor viewer" example:"editor"`
+}
+
+type UpdateMemberRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=owner editor viewer" example:"viewer"`
+}
+
+type MemberResponse struct {
+	UserID      string    `json:"userId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Email       string    `json:"email" example:"teammate@example.com"`
+	DisplayName string    `json:"displayName" example:"Jane Doe"`
+	Role        string    `json:"role" example:"editor"`
+	CreatedAt   time.Time `json:"createdAt" example:"2024-01-01T00:00:00Z"`
+}
+
+type InviteMemberRequest struct {
+	Email string `json:"email" binding:"required,email" example:"new.teammate@example.com"`
+	Role  string `json:"role" binding:"required,oneof=owner editor viewer" example:"editor"`
+}
+
+type InvitationTokenRequest struct {
+	Token string `json:"token" binding:"required" example:"a1b2c3d4e5f6..."`
+}
+
+type InvitationResponse struct {
+	ID          string     `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ProjectID   string     `json:"projectId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ProjectName string     `json:"projectName" example:"Hệ thống quản lý kho"`
+	Email       string     `json:"email" example:"new.teammate@example.com"`
+	Role        string     `json:"role" example:"editor"`
+	ExpiresAt   time.Time  `json:"expiresAt" example:"2024-01-08T00:00:00Z"`
+	AcceptedAt  *time.Time `json:"acceptedAt,omitempty" example:"2024-01-02T00:00:00Z"`
+	DeclinedAt  *time.Time `json:"declinedAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt" example:"2024-01-01T00:00:00Z"`
+}
+
+type CreateCommentRequest struct {
+	Body     string `json:"body" binding:"required,max=10000" example:"Looks good, @jane can you double-check the totals?"`
+	ParentID string `json:"parentId,omitempty" binding:"omitempty,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+}
+
+type UpdateCommentRequest struct {
+	Body string `json:"body" binding:"required,max=10000" example:"Looks good, @jane.doe can you double-check the totals?"`
+}
+
+// CommentResponse is a comment with its replies; deleted comments keep their place
+// in the thread with an empty body
+type CommentResponse struct {
+	ID         string            `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	TaskID     string            `json:"taskId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ParentID   *string           `json:"parentId,omitempty"`
+	AuthorID   *string           `json:"authorId,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	AuthorName string            `json:"authorName" example:"Jane Doe"`
+	Body       string            `json:"body" example:"Looks good, @jane can you double-check the totals?"`
+	MentionIDs []string          `json:"mentionIds"`
+	Edited     bool              `json:"edited" example:"false"`
+	Deleted    bool              `json:"deleted" example:"false"`
+	EditedAt   *time.Time        `json:"editedAt,omitempty"`
+	CreatedAt  time.Time         `json:"createdAt" example:"2024-01-01T00:00:00Z"`
+	Replies    []CommentResponse `json:"replies,omitempty"`
+}
+
+type CommentEditResponse struct {
+	Body     string    `json:"body" example:"Looks good, can you double-check the totals?"`
+	EditedBy *string   `json:"editedBy,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	EditedAt time.Time `json:"editedAt" example:"2024-01-01T00:00:00Z"`
+}
+
+type RevisionResponse struct {
+	Revision   int       `json:"revision" example:"12"`
+	AuthorID   *string   `json:"authorId,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	AuthorName string    `json:"authorName" example:"Jane Doe"`
+	CreatedAt  time.Time `json:"createdAt" example:"2024-01-01T00:00:00Z"`
+}
+
+// RevisionDetailResponse is a revision with the project as it was saved
+type RevisionDetailResponse struct {
+	RevisionResponse
 	Name        string        `json:"name" example:"Hệ thống quản lý kho"`
 	Description string        `json:"description" example:"Xây dựng hệ thống quản lý kho thông minh"`
 	Status      string        `json:"status" example:"active"`
@@ -79,6 +343,65 @@ type ProjectResponse struct {
 	EndDate     *time.Time    `json:"endDate,omitempty" example:"2024-06-30T00:00:00Z"`
 	Tasks       []TaskDTO     `json:"tasks"`
 	Documents   []DocumentDTO `json:"documents"`
-	CreatedAt   time.Time     `json:"createdAt" example:"2024-01-01T00:00:00Z"`
-	UpdatedAt   time.Time     `json:"updatedAt" example:"2024-01-01T00:00:00Z"`
+}
+
+type FieldChangeDTO struct {
+	Before json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	After  json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+}
+
+type ItemChangeDTO struct {
+	ID      string                    `json:"id" example:"t1"`
+	Changes map[string]FieldChangeDTO `json:"changes"`
+}
+
+type TaskDiffDTO struct {
+	Added     []TaskDTO       `json:"added"`
+	Removed   []TaskDTO       `json:"removed"`
+	Changed   []ItemChangeDTO `json:"changed"`
+	Reordered bool            `json:"reordered" example:"false"`
+}
+
+type DocumentDiffDTO struct {
+	Added     []DocumentDTO   `json:"added"`
+	Removed   []DocumentDTO   `json:"removed"`
+	Changed   []ItemChangeDTO `json:"changed"`
+	Reordered bool            `json:"reordered" example:"false"`
+}
+
+// RevisionDiffResponse is what changed going from one revision to another
+type RevisionDiffResponse struct {
+	From      int                       `json:"from" example:"10"`
+	To        int                       `json:"to" example:"12"`
+	Fields    map[string]FieldChangeDTO `json:"fields"`
+	Tasks     TaskDiffDTO               `json:"tasks"`
+	Documents DocumentDiffDTO           `json:"documents"`
+}
+
+type ScheduledTaskDTO struct {
+	TaskID         string    `json:"taskId" example:"t1"`
+	Title          string    `json:"title" example:"Thiết kế Database"`
+	EstimateHours  float64   `json:"estimateHours" example:"8"`
+	EarliestStart  time.Time `json:"earliestStart" example:"2024-02-01T09:00:00Z"`
+	EarliestFinish time.Time `json:"earliestFinish" example:"2024-02-01T17:00:00Z"`
+	LatestStart    time.Time `json:"latestStart" example:"2024-02-02T09:00:00Z"`
+	LatestFinish   time.Time `json:"latestFinish" example:"2024-02-02T17:00:00Z"`
+	SlackHours     float64   `json:"slackHours" example:"24"`
+	Critical       bool      `json:"critical" example:"false"`
+}
+
+type CriticalPathResponse struct {
+	Start      time.Time          `json:"start" example:"2024-02-01T09:00:00Z"`
+	Finish     time.Time          `json:"finish" example:"2024-03-01T17:00:00Z"`
+	Deadline   time.Time          `json:"deadline" example:"2024-06-30T00:00:00Z"`
+	SlackHours float64            `json:"slackHours" example:"0"`
+	Late       bool               `json:"late" example:"false"`
+	Chain      []string           `json:"chain" example:"t1,t2"`
+	Tasks      []ScheduledTaskDTO `json:"tasks"`
+}
+
+// StreamTicketResponse opens a project's event stream once
+type StreamTicketResponse struct {
+	Ticket    string `json:"ticket" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	ExpiresIn int    `json:"expiresIn" example:"30"` // seconds
 }
@@ -0,0 +1,107 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	projectUC "github.com/tomtom2k/kairo-anchor-server/internal/usecase/project"
+)
+
+// sseHeartbeat keeps idle streams from being closed by proxies
+const sseHeartbeat = 25 * time.Second
+
+type ProjectEventsHandler struct {
+	streamEvents *projectUC.StreamEventsUseCase
+	issueTicket  *projectUC.IssueStreamTicketUseCase
+}
+
+func NewProjectEventsHandler(streamEvents *projectUC.StreamEventsUseCase, issueTicket *projectUC.IssueStreamTicketUseCase) *ProjectEventsHandler {
+	return &ProjectEventsHandler{streamEvents: streamEvents, issueTicket: issueTicket}
+}
+
+// IssueStreamTicket godoc
+// @Summary Get a ticket for the project's event stream
+// @Description Returns a single-use ticket, valid for a few seconds, that opens GET /projects/{id}/events for this
+// @Description project as the ticket query parameter. Browsers need it because EventSource cannot send the
+// @Description Authorization header.
+// @Tags projects
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID (UUID)"
+// @Success 201 {object} APIResponse{data=StreamTicketResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Router /projects/{id}/events/ticket [post]
+func (h *ProjectEventsHandler) IssueStreamTicket(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	ticket, ttl, err := h.issueTicket.Execute(c.Request.Context(), c.Param("id"), userID, GetTokenIssuedAt(c), GetTokenExpiry(c))
+	if err != nil {
+		sendProjectError(c, "ISSUE_STREAM_TICKET_FAILED", err)
+		return
+	}
+	SendSuccess(c, http.StatusCreated, StreamTicketResponse{Ticket: ticket, ExpiresIn: int(ttl.Seconds())}, "Stream ticket issued")
+}
+
+// StreamEvents godoc
+// @Summary Follow project changes live
+// @Description Server-Sent Events stream of changes to a project, its tasks and documents. Each event is named after
+// @Description its type (project.updated, project.deleted, project.restored, task.added, task.updated, task.deleted,
+// @Description tasks.reordered, document.added, document.updated, document.deleted, workflow.updated)
+// @Description and carries the event as JSON. A "ready" event is sent once connected. Browsers, which cannot set
+// @Description headers on EventSource, pass a ticket from POST /projects/{id}/events/ticket instead of the token.
+// @Description The stream ends when the token it was opened with expires or is revoked, or access is lost; reconnect
+// @Description with a fresh token.
+// @Tags projects
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param id path string true "Project ID (UUID)"
+// @Param ticket query string false "Stream ticket, when the Authorization header cannot be set"
+// @Success 200 {string} string "event stream"
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Router /projects/{id}/events [get]
+func (h *ProjectEventsHandler) StreamEvents(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	projectID := c.Param("id")
+	events, err := h.streamEvents.Execute(c.Request.Context(), projectID, userID, GetTokenIssuedAt(c), GetTokenExpiry(c))
+	if err != nil {
+		sendProjectError(c, "STREAM_EVENTS_FAILED", err)
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.SSEvent("ready", gin.H{"projectId": projectID})
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(sseHeartbeat)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent(string(event.Type), event)
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+		}
+		return true
+	})
+}
@@ -1,10 +1,12 @@
 package http
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/workspace"
 	projectUC "github.com/tomtom2k/kairo-anchor-server/internal/usecase/project"
 )
 
@@ -18,9 +20,12 @@ type ProjectHandler struct {
 	updateTask      *projectUC.UpdateTaskUseCase
 	deleteTask      *projectUC.DeleteTaskUseCase
 	reorderTasks    *projectUC.ReorderTasksUseCase
+	rankTask        *projectUC.RankTaskUseCase
+	reorderSubtasks *projectUC.ReorderSubtasksUseCase
 	addDocument     *projectUC.AddDocumentUseCase
 	updateDocument  *projectUC.UpdateDocumentUseCase
 	deleteDocument  *projectUC.DeleteDocumentUseCase
+	listMyTasks     *projectUC.ListMyTasksUseCase
 }
 
 func NewProjectHandler(
@@ -33,9 +38,12 @@ func NewProjectHandler(
 	updateTask *projectUC.UpdateTaskUseCase,
 	deleteTask *projectUC.DeleteTaskUseCase,
 	reorderTasks *projectUC.ReorderTasksUseCase,
+	rankTask *projectUC.RankTaskUseCase,
+	reorderSubtasks *projectUC.ReorderSubtasksUseCase,
 	addDocument *projectUC.AddDocumentUseCase,
 	updateDocument *projectUC.UpdateDocumentUseCase,
 	deleteDocument *projectUC.DeleteDocumentUseCase,
+	listMyTasks *projectUC.ListMyTasksUseCase,
 ) *ProjectHandler {
 	return &ProjectHandler{
 		createProject:  create,
@@ -47,23 +55,28 @@ func NewProjectHandler(
 		updateTask:    updateTask,
 		deleteTask:    deleteTask,
 		reorderTasks:  reorderTasks,
+		rankTask:      rankTask,
+		reorderSubtasks: reorderSubtasks,
 		addDocument:   addDocument,
 		updateDocument: updateDocument,
 		deleteDocument: deleteDocument,
+		listMyTasks:    listMyTasks,
 	}
 }
 
 // CreateProject godoc
 // @Summary Create a new project
-// @Description Create a new project for the authenticated user
+// @Description Create a new project in the current workspace
 // @Tags projects
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param X-Workspace-ID header string false "Workspace ID, defaults to the personal workspace"
 // @Param request body CreateProjectRequest true "Create Project Request"
 // @Success 201 {object} APIResponse{data=ProjectResponse}
 // @Failure 400 {object} APIErrorResponse
 // @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
 // @Router /projects [post]
 func (h *ProjectHandler) CreateProject(c *gin.Context) {
 	userID, err := GetUserID(c)
@@ -72,6 +85,12 @@ func (h *ProjectHandler) CreateProject(c *gin.Context) {
 		return
 	}
 
+	workspaceID, err := GetWorkspaceID(c)
+	if err != nil {
+		SendInternalError(c, err)
+		return
+	}
+
 	var req CreateProjectRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
@@ -79,6 +98,7 @@ func (h *ProjectHandler) CreateProject(c *gin.Context) {
 	}
 
 	input := projectUC.CreateProjectInput{
+		WorkspaceID: workspaceID,
 		UserID:      userID,
 		Name:        req.Name,
 		Description: req.Description,
@@ -86,11 +106,12 @@ func (h *ProjectHandler) CreateProject(c *gin.Context) {
 		Progress:    0, // tiến độ tính từ task hoàn thành
 		StartDate:   req.StartDate,
 		EndDate:     req.EndDate,
+		LabelIDs:    req.LabelIDs,
 	}
 
 	p, err := h.createProject.Execute(c.Request.Context(), input)
 	if err != nil {
-		SendError(c, http.StatusBadRequest, "CREATE_PROJECT_FAILED", err.Error())
+		sendProjectError(c, "CREATE_PROJECT_FAILED", err)
 		return
 	}
 
@@ -110,6 +131,7 @@ func (h *ProjectHandler) CreateProject(c *gin.Context) {
 // @Failure 400 {object} APIErrorResponse
 // @Failure 401 {object} APIErrorResponse
 // @Failure 404 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
 // @Router /projects/{id} [put]
 func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 	userID, err := GetUserID(c)
@@ -133,11 +155,12 @@ func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 		Status:      project.ProjectStatus(req.Status),
 		StartDate:   req.StartDate,
 		EndDate:     req.EndDate,
+		LabelIDs:    req.LabelIDs,
 	}
 
 	p, err := h.updateProject.Execute(c.Request.Context(), input)
 	if err != nil {
-		SendError(c, http.StatusBadRequest, "UPDATE_PROJECT_FAILED", err.Error())
+		sendProjectError(c, "UPDATE_PROJECT_FAILED", err)
 		return
 	}
 
@@ -155,6 +178,7 @@ func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 // @Success 200 {object} APIResponse
 // @Failure 400 {object} APIErrorResponse
 // @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
 // @Router /projects/{id} [delete]
 func (h *ProjectHandler) DeleteProject(c *gin.Context) {
 	userID, err := GetUserID(c)
@@ -166,7 +190,7 @@ func (h *ProjectHandler) DeleteProject(c *gin.Context) {
 	projectID := c.Param("id")
 	err = h.deleteProject.Execute(c.Request.Context(), projectID, userID)
 	if err != nil {
-		SendError(c, http.StatusBadRequest, "DELETE_PROJECT_FAILED", err.Error())
+		sendProjectError(c, "DELETE_PROJECT_FAILED", err)
 		return
 	}
 
@@ -175,6 +199,10 @@ func (h *ProjectHandler) DeleteProject(c *gin.Context) {
 
 // AddTask godoc
 // @Summary Add a task to a project
+// @Description Set parentId to add a subtask. Tasks nest up to three levels deep. A task with a due date can
+// @Description recur: recurrence.rule is an RFC 5545 RRULE (DAILY, WEEKLY or MONTHLY with INTERVAL, BYDAY,
+// @Description BYMONTHDAY, COUNT, UNTIL) and its slots keep their time of day in recurrence.timezone, which
+// @Description defaults to the user's. Completing an occurrence adds the next one.
 // @Tags projects
 // @Accept json
 // @Produce json
@@ -196,16 +224,24 @@ func (h *ProjectHandler) AddTask(c *gin.Context) {
 		return
 	}
 	input := projectUC.AddTaskInput{
-		ProjectID: projectID,
-		UserID:    userID,
-		Title:     req.Title,
-		Status:    project.TaskStatus(req.Status),
-		Priority:  project.TaskPriority(req.Priority),
-		DueDate:   req.DueDate,
+		ProjectID:     projectID,
+		UserID:        userID,
+		ParentID:      req.ParentID,
+		Title:         req.Title,
+		Status:        project.TaskStatus(req.Status),
+		Priority:      project.TaskPriority(req.Priority),
+		DueDate:       req.DueDate,
+		EstimateHours: req.EstimateHours,
+		StoryPoints:   req.StoryPoints,
+		AssigneeIDs:   req.AssigneeIDs,
+		LabelIDs:      req.LabelIDs,
+	}
+	if req.Recurrence != nil {
+		input.Recurrence = &projectUC.RecurrenceInput{Rule: req.Recurrence.Rule, Timezone: req.Recurrence.Timezone}
 	}
 	p, err := h.addTask.Execute(c.Request.Context(), input)
 	if err != nil {
-		SendError(c, http.StatusBadRequest, "ADD_TASK_FAILED", err.Error())
+		sendProjectError(c, "ADD_TASK_FAILED", err)
 		return
 	}
 	SendSuccess(c, http.StatusCreated, toProjectResponse(p), "Task added")
@@ -213,6 +249,12 @@ func (h *ProjectHandler) AddTask(c *gin.Context) {
 
 // UpdateTask godoc
 // @Summary Update a task
+// @Description Setting parentId moves the task and its subtasks under another task; an empty parentId makes it
+// @Description top-level. Depending on the workspace's parentCompletion setting, a task cannot be completed while
+// @Description subtasks are unfinished, and parents are completed and reopened along with their subtasks.
+// @Description Status changes that break the task's dependencies, or those of tasks waiting for it, are refused
+// @Description with 409 unless force is set. For recurring tasks this edits only this occurrence, and completing
+// @Description it adds the next one.
 // @Tags projects
 // @Accept json
 // @Produce json
@@ -221,6 +263,7 @@ func (h *ProjectHandler) AddTask(c *gin.Context) {
 // @Param taskId path string true "Task ID"
 // @Param request body UpdateTaskRequest true "Update Task Request"
 // @Success 200 {object} APIResponse{data=ProjectResponse}
+// @Failure 409 {object} APIErrorResponse
 // @Router /projects/{id}/tasks/{taskId} [put]
 func (h *ProjectHandler) UpdateTask(c *gin.Context) {
 	userID, err := GetUserID(c)
@@ -236,17 +279,23 @@ func (h *ProjectHandler) UpdateTask(c *gin.Context) {
 		return
 	}
 	input := projectUC.UpdateTaskInput{
-		ProjectID: projectID,
-		UserID:    userID,
-		TaskID:    taskID,
-		Title:     req.Title,
-		Status:    ptrToTaskStatus(req.Status),
-		Priority:  ptrToTaskPriority(req.Priority),
-		DueDate:   req.DueDate,
+		ProjectID:     projectID,
+		UserID:        userID,
+		TaskID:        taskID,
+		ParentID:      req.ParentID,
+		Title:         req.Title,
+		Status:        ptrToTaskStatus(req.Status),
+		Priority:      ptrToTaskPriority(req.Priority),
+		DueDate:       req.DueDate,
+		EstimateHours: req.EstimateHours,
+		StoryPoints:   req.StoryPoints,
+		AssigneeIDs:   req.AssigneeIDs,
+		LabelIDs:      req.LabelIDs,
+		Force:         req.Force,
 	}
 	p, err := h.updateTask.Execute(c.Request.Context(), input)
 	if err != nil {
-		SendError(c, http.StatusBadRequest, "UPDATE_TASK_FAILED", err.Error())
+		sendProjectError(c, "UPDATE_TASK_FAILED", err)
 		return
 	}
 	SendSuccess(c, http.StatusOK, toProjectResponse(p), "Task updated")
@@ -254,6 +303,7 @@ func (h *ProjectHandler) UpdateTask(c *gin.Context) {
 
 // DeleteTask godoc
 // @Summary Delete a task
+// @Description Subtasks are deleted along with the task, and tasks waiting for them no longer do
 // @Tags projects
 // @Security BearerAuth
 // @Param id path string true "Project ID"
@@ -270,7 +320,7 @@ func (h *ProjectHandler) DeleteTask(c *gin.Context) {
 	taskID := c.Param("taskId")
 	p, err := h.deleteTask.Execute(c.Request.Context(), projectID, userID, taskID)
 	if err != nil {
-		SendError(c, http.StatusBadRequest, "DELETE_TASK_FAILED", err.Error())
+		sendProjectError(c, "DELETE_TASK_FAILED", err)
 		return
 	}
 	SendSuccess(c, http.StatusOK, toProjectResponse(p), "Task deleted")
@@ -278,6 +328,8 @@ func (h *ProjectHandler) DeleteTask(c *gin.Context) {
 
 // ReorderTasks godoc
 // @Summary Reorder tasks
+// @Description Rewrites the order of the whole task list. Prefer moving single tasks with PUT /projects/{id}/tasks/{taskId}/rank,
+// @Description which does not undo moves other people make at the same time.
 // @Tags projects
 // @Accept json
 // @Produce json
@@ -300,12 +352,89 @@ func (h *ProjectHandler) ReorderTasks(c *gin.Context) {
 	}
 	p, err := h.reorderTasks.Execute(c.Request.Context(), projectID, userID, req.TaskIDs)
 	if err != nil {
-		SendError(c, http.StatusBadRequest, "REORDER_TASKS_FAILED", err.Error())
+		sendProjectError(c, "REORDER_TASKS_FAILED", err)
 		return
 	}
 	SendSuccess(c, http.StatusOK, toProjectResponse(p), "Tasks reordered")
 }
 
+// RankTask godoc
+// @Summary Move a task between two others
+// @Description Moves a task right after the task "after" and before the task "before", which must be next to each other.
+// @Description With only one of them the task goes right next to it, with neither to the end. Only the moved task's rank
+// @Description changes; 409 means the list changed in between and the client should reload it.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param taskId path string true "Task ID"
+// @Param request body RankTaskRequest true "Neighbours to move the task between"
+// @Success 200 {object} APIResponse{data=ProjectResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Failure 409 {object} APIErrorResponse
+// @Router /projects/{id}/tasks/{taskId}/rank [put]
+func (h *ProjectHandler) RankTask(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+	var req RankTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+	p, err := h.rankTask.Execute(c.Request.Context(), projectUC.RankTaskInput{
+		ProjectID: c.Param("id"),
+		UserID:    userID,
+		TaskID:    c.Param("taskId"),
+		AfterID:   req.After,
+		BeforeID:  req.Before,
+	})
+	if err != nil {
+		sendProjectError(c, "RANK_TASK_FAILED", err)
+		return
+	}
+	SendSuccess(c, http.StatusOK, toProjectResponse(p), "Task moved")
+}
+
+// ReorderSubtasks godoc
+// @Summary Reorder subtasks
+// @Description Reorders the direct subtasks of a task; subtasks left out keep their order after the listed ones
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param taskId path string true "Task ID"
+// @Param request body ReorderSubtasksRequest true "Ordered list of subtask IDs"
+// @Success 200 {object} APIResponse{data=ProjectResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /projects/{id}/tasks/{taskId}/subtasks/order [put]
+func (h *ProjectHandler) ReorderSubtasks(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+	var req ReorderSubtasksRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+	p, err := h.reorderSubtasks.Execute(c.Request.Context(), c.Param("id"), userID, c.Param("taskId"), req.SubtaskIDs)
+	if err != nil {
+		sendProjectError(c, "REORDER_SUBTASKS_FAILED", err)
+		return
+	}
+	SendSuccess(c, http.StatusOK, toProjectResponse(p), "Subtasks reordered")
+}
+
 // AddDocument godoc
 // @Summary Add a document to a project
 // @Tags projects
@@ -337,7 +466,7 @@ func (h *ProjectHandler) AddDocument(c *gin.Context) {
 	}
 	p, err := h.addDocument.Execute(c.Request.Context(), input)
 	if err != nil {
-		SendError(c, http.StatusBadRequest, "ADD_DOCUMENT_FAILED", err.Error())
+		sendProjectError(c, "ADD_DOCUMENT_FAILED", err)
 		return
 	}
 	SendSuccess(c, http.StatusCreated, toProjectResponse(p), "Document added")
@@ -377,7 +506,7 @@ func (h *ProjectHandler) UpdateDocument(c *gin.Context) {
 	}
 	p, err := h.updateDocument.Execute(c.Request.Context(), input)
 	if err != nil {
-		SendError(c, http.StatusBadRequest, "UPDATE_DOCUMENT_FAILED", err.Error())
+		sendProjectError(c, "UPDATE_DOCUMENT_FAILED", err)
 		return
 	}
 	SendSuccess(c, http.StatusOK, toProjectResponse(p), "Document updated")
@@ -401,7 +530,7 @@ func (h *ProjectHandler) DeleteDocument(c *gin.Context) {
 	docID := c.Param("docId")
 	p, err := h.deleteDocument.Execute(c.Request.Context(), projectID, userID, docID)
 	if err != nil {
-		SendError(c, http.StatusBadRequest, "DELETE_DOCUMENT_FAILED", err.Error())
+		sendProjectError(c, "DELETE_DOCUMENT_FAILED", err)
 		return
 	}
 	SendSuccess(c, http.StatusOK, toProjectResponse(p), "Document deleted")
@@ -439,11 +568,13 @@ func (h *ProjectHandler) GetProject(c *gin.Context) {
 
 // ListProjects godoc
 // @Summary List all projects
-// @Description Get all projects for the authenticated user
+// @Description Get the projects the authenticated user can see in the current workspace
 // @Tags projects
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param X-Workspace-ID header string false "Workspace ID, defaults to the personal workspace"
+// @Param labels query string false "Comma-separated label IDs; only projects carrying all of them"
 // @Success 200 {object} APIResponse{data=[]ProjectResponse}
 // @Failure 401 {object} APIErrorResponse
 // @Router /projects [get]
@@ -454,7 +585,13 @@ func (h *ProjectHandler) ListProjects(c *gin.Context) {
 		return
 	}
 
-	projects, err := h.listProjects.Execute(c.Request.Context(), userID)
+	workspaceID, err := GetWorkspaceID(c)
+	if err != nil {
+		SendInternalError(c, err)
+		return
+	}
+
+	projects, err := h.listProjects.Execute(c.Request.Context(), userID, workspaceID, queryList(c, "labels"))
 	if err != nil {
 		SendInternalError(c, err)
 		return
@@ -468,47 +605,166 @@ func (h *ProjectHandler) ListProjects(c *gin.Context) {
 	SendSuccess(c, http.StatusOK, response, "")
 }
 
+// ListMyTasks godoc
+// @Summary List tasks assigned to me
+// @Description List tasks assigned to the authenticated user across all of their projects
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Comma-separated status keys, e.g. todo,review"
+// @Param due_before query string false "Only tasks due before this time (RFC3339 or YYYY-MM-DD)"
+// @Param due_after query string false "Only tasks due at or after this time (RFC3339 or YYYY-MM-DD)"
+// @Param labels query string false "Comma-separated label IDs; only tasks carrying all of them"
+// @Success 200 {object} APIResponse{data=[]AssignedTaskDTO}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Router /me/tasks [get]
+func (h *ProjectHandler) ListMyTasks(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Statuses are per project, so any key is accepted
+	var filter project.TaskFilter
+	for _, s := range queryList(c, "status") {
+		filter.Statuses = append(filter.Statuses, project.TaskStatus(s))
+	}
+	if filter.DueBefore, err = queryTime(c, "due_before"); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+	if filter.DueAfter, err = queryTime(c, "due_after"); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	filter.LabelIDs = queryList(c, "labels")
+
+	tasks, err := h.listMyTasks.Execute(c.Request.Context(), userID, filter)
+	if err != nil {
+		SendError(c, http.StatusBadRequest, "LIST_TASKS_FAILED", err.Error())
+		return
+	}
+
+	response := make([]AssignedTaskDTO, len(tasks))
+	for i := range tasks {
+		response[i] = AssignedTaskDTO{
+			TaskDTO:     toTaskDTO(&tasks[i].Task),
+			ProjectID:   tasks[i].ProjectID.String(),
+			ProjectName: tasks[i].ProjectName,
+		}
+	}
+	SendSuccess(c, http.StatusOK, response, "")
+}
+
 // Helper function to convert domain model to response DTO
 func toProjectResponse(p *project.Project) *ProjectResponse {
 	tasks := make([]TaskDTO, len(p.Tasks))
-	for i, t := range p.Tasks {
-		tasks[i] = TaskDTO{
-			ID:       t.ID,
-			Title:    t.Title,
-			Status:   string(t.Status),
-			Priority: string(t.Priority),
-			DueDate:  t.DueDate,
-		}
+	for i := range p.Tasks {
+		tasks[i] = toTaskDTO(&p.Tasks[i])
 	}
 
 	documents := make([]DocumentDTO, len(p.Documents))
-	for i, d := range p.Documents {
-		documents[i] = DocumentDTO{
-			ID:        d.ID,
-			Name:      d.Name,
-			Type:      d.Type,
-			Size:      d.Size,
-			UpdatedAt: d.UpdatedAt,
-		}
+	for i := range p.Documents {
+		documents[i] = toDocumentDTO(&p.Documents[i])
 	}
 
-	// Tiến độ luôn tính từ task hoàn thành
-	progress := project.ProgressFromTasks(p.Tasks)
+	// Tiến độ tính từ task theo cách project đã chọn
+	progress := project.ProgressFromTasks(p.Tasks, p.Workflow, p.ProgressSettings)
 
 	return &ProjectResponse{
-		ID:          p.ID.String(),
-		UserID:      p.UserID.String(),
-		Name:        p.Name,
-		Description: p.Description,
-		Status:      string(p.Status),
-		Progress:    progress,
-		StartDate:   p.StartDate,
-		EndDate:     p.EndDate,
-		Tasks:       tasks,
-		Documents:   documents,
-		CreatedAt:   p.CreatedAt,
-		UpdatedAt:   p.UpdatedAt,
+		ID:               p.ID.String(),
+		WorkspaceID:      p.WorkspaceID.String(),
+		UserID:           p.UserID.String(),
+		Name:             p.Name,
+		Description:      p.Description,
+		Status:           string(p.Status),
+		Progress:         progress,
+		StartDate:        p.StartDate,
+		EndDate:          p.EndDate,
+		Tasks:            tasks,
+		Documents:        documents,
+		CreatedAt:        p.CreatedAt,
+		Revision:         p.Revision,
+		UpdatedAt:        p.UpdatedAt,
+		Role:             string(p.Role),
+		LabelIDs:         nonNil(p.LabelIDs),
+		Workflow:         toWorkflowDTO(p.Workflow),
+		ProgressSettings: toProgressSettingsDTO(p.ProgressSettings),
+	}
+}
+
+func toDocumentDTO(d *project.Document) DocumentDTO {
+	return DocumentDTO{
+		ID:        d.ID,
+		Name:      d.Name,
+		Type:      d.Type,
+		Size:      d.Size,
+		UpdatedAt: d.UpdatedAt,
+	}
+}
+
+func toTaskDTO(t *project.Task) TaskDTO {
+	checklist := make([]ChecklistItemDTO, len(t.Checklist))
+	for i, item := range t.Checklist {
+		checklist[i] = ChecklistItemDTO{ID: item.ID, Text: item.Text, Done: item.Done}
+	}
+	dependencies := make([]DependencyDTO, len(t.Dependencies))
+	for i, d := range t.Dependencies {
+		dependencies[i] = DependencyDTO{TaskID: d.TaskID, Type: string(d.Type)}
+	}
+	return TaskDTO{
+		ID:            t.ID,
+		ParentID:      t.ParentID,
+		Title:         t.Title,
+		Status:        string(t.Status),
+		Priority:      string(t.Priority),
+		DueDate:       t.DueDate,
+		EstimateHours: t.EstimateHours,
+		StoryPoints:   t.StoryPoints,
+		AssigneeIDs:   nonNil(t.AssigneeIDs),
+		LabelIDs:      nonNil(t.LabelIDs),
+		Checklist:     checklist,
+		Dependencies:  dependencies,
+		Recurrence:    toRecurrenceDTO(t.Recurrence),
+		Rank:          t.Rank,
+	}
+}
+
+// nonNil keeps empty ID lists as [] rather than null in responses
+func nonNil(ids []string) []string {
+	if ids == nil {
+		return []string{}
+	}
+	return ids
+}
+
+// sendProjectError reports a failed project operation, using 403 when the member's role is not enough
+// and 409 when a task's dependencies or the project's workflow are in the way
+func sendProjectError(c *gin.Context, code string, err error) {
+	if errors.Is(err, project.ErrForbidden) || errors.Is(err, workspace.ErrForbidden) {
+		SendError(c, http.StatusForbidden, ErrCodeForbidden, err.Error())
+		return
+	}
+	if errors.Is(err, project.ErrBlocked) {
+		SendError(c, http.StatusConflict, ErrCodeTaskBlocked, err.Error())
+		return
+	}
+	if errors.Is(err, project.ErrTransitionNotAllowed) || errors.Is(err, project.ErrWIPLimit) {
+		SendError(c, http.StatusConflict, ErrCodeWorkflow, err.Error())
+		return
+	}
+	if errors.Is(err, project.ErrRankConflict) {
+		SendError(c, http.StatusConflict, ErrCodeRankConflict, err.Error())
+		return
+	}
+	if errors.Is(err, project.ErrConflict) {
+		SendError(c, http.StatusConflict, ErrCodeConflict, err.Error())
+		return
 	}
+	SendError(c, http.StatusBadRequest, code, err.Error())
 }
 
 func ptrToTaskStatus(s *string) *project.TaskStatus {
@@ -0,0 +1,168 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	projectUC "github.com/tomtom2k/kairo-anchor-server/internal/usecase/project"
+)
+
+type RecurrenceHandler struct {
+	editRecurring  *projectUC.EditRecurringTaskUseCase
+	stopRecurrence *projectUC.StopRecurrenceUseCase
+}
+
+func NewRecurrenceHandler(
+	editRecurring *projectUC.EditRecurringTaskUseCase,
+	stopRecurrence *projectUC.StopRecurrenceUseCase,
+) *RecurrenceHandler {
+	return &RecurrenceHandler{editRecurring: editRecurring, stopRecurrence: stopRecurrence}
+}
+
+// UpdateOccurrence godoc
+// @Summary Edit this occurrence of a recurring task
+// @Description Changes only this occurrence; the ones that follow are created as before. A moved due date
+// @Description does not move the series.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param taskId path string true "Task ID"
+// @Param request body UpdateOccurrenceRequest true "Update Occurrence Request"
+// @Success 200 {object} APIResponse{data=ProjectResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /projects/{id}/tasks/{taskId}/occurrence [put]
+func (h *RecurrenceHandler) UpdateOccurrence(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+	var req UpdateOccurrenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	p, err := h.editRecurring.Execute(c.Request.Context(), toEditRecurringInput(c, userID, false, req))
+	if err != nil {
+		sendProjectError(c, "UPDATE_OCCURRENCE_FAILED", err)
+		return
+	}
+	SendSuccess(c, http.StatusOK, toProjectResponse(p), "Occurrence updated")
+}
+
+// UpdateSeries godoc
+// @Summary Edit this and all future occurrences of a recurring task
+// @Description Changes this occurrence and what later ones are created as. A new due date, rule or timezone
+// @Description restarts the series from this occurrence; if only the due date moves, earlier occurrences still
+// @Description count towards the rule's COUNT. Setting a rule on a task that does not recur makes it recur.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param taskId path string true "Task ID"
+// @Param request body UpdateSeriesRequest true "Update Series Request"
+// @Success 200 {object} APIResponse{data=ProjectResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /projects/{id}/tasks/{taskId}/series [put]
+func (h *RecurrenceHandler) UpdateSeries(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+	var req UpdateSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	input := toEditRecurringInput(c, userID, true, req.UpdateOccurrenceRequest)
+	input.Rule = req.Rule
+	input.Timezone = req.Timezone
+	p, err := h.editRecurring.Execute(c.Request.Context(), input)
+	if err != nil {
+		sendProjectError(c, "UPDATE_SERIES_FAILED", err)
+		return
+	}
+	SendSuccess(c, http.StatusOK, toProjectResponse(p), "Series updated")
+}
+
+// StopRecurrence godoc
+// @Summary Stop a task from recurring
+// @Description The task stays as it is, but completing it no longer adds another occurrence
+// @Tags projects
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param taskId path string true "Task ID"
+// @Success 200 {object} APIResponse{data=ProjectResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /projects/{id}/tasks/{taskId}/recurrence [delete]
+func (h *RecurrenceHandler) StopRecurrence(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	p, err := h.stopRecurrence.Execute(c.Request.Context(), c.Param("id"), userID, c.Param("taskId"))
+	if err != nil {
+		sendProjectError(c, "STOP_RECURRENCE_FAILED", err)
+		return
+	}
+	SendSuccess(c, http.StatusOK, toProjectResponse(p), "Task no longer recurs")
+}
+
+func toEditRecurringInput(c *gin.Context, userID string, allFuture bool, req UpdateOccurrenceRequest) projectUC.EditRecurringTaskInput {
+	return projectUC.EditRecurringTaskInput{
+		ProjectID:     c.Param("id"),
+		UserID:        userID,
+		TaskID:        c.Param("taskId"),
+		AllFuture:     allFuture,
+		Title:         req.Title,
+		Priority:      ptrToTaskPriority(req.Priority),
+		DueDate:       req.DueDate,
+		EstimateHours: req.EstimateHours,
+		StoryPoints:   req.StoryPoints,
+		AssigneeIDs:   req.AssigneeIDs,
+	}
+}
+
+func toRecurrenceDTO(r *project.Recurrence) *RecurrenceDTO {
+	if r == nil {
+		return nil
+	}
+	template := TaskTemplateDTO{
+		Title:         r.Template.Title,
+		Priority:      string(r.Template.Priority),
+		EstimateHours: r.Template.EstimateHours,
+		StoryPoints:   r.Template.StoryPoints,
+		AssigneeIDs:   r.Template.AssigneeIDs,
+		Checklist:     r.Template.Checklist,
+	}
+	if template.AssigneeIDs == nil {
+		template.AssigneeIDs = []string{}
+	}
+	if template.Checklist == nil {
+		template.Checklist = []string{}
+	}
+	return &RecurrenceDTO{
+		Rule:       r.Rule,
+		Timezone:   r.Timezone,
+		SeriesID:   r.SeriesID,
+		Start:      r.Start,
+		Occurrence: r.Occurrence,
+		Template:   template,
+	}
+}
@@ -9,12 +9,24 @@ import (
 
 // Error codes
 const (
-	ErrCodeValidation      = "VALIDATION_ERROR"
-	ErrCodeUnauthorized    = "UNAUTHORIZED"
-	ErrCodeNotFound        = "NOT_FOUND"
-	ErrCodeInternal        = "INTERNAL_ERROR"
-	ErrCodeInvalidEmail    = "INVALID_EMAIL"
-	ErrCodeInvalidPassword = "INVALID_PASSWORD"
+	ErrCodeValidation         = "VALIDATION_ERROR"
+	ErrCodeUnauthorized       = "UNAUTHORIZED"
+	ErrCodeForbidden          = "FORBIDDEN"
+	ErrCodeNotFound           = "NOT_FOUND"
+	ErrCodeInternal           = "INTERNAL_ERROR"
+	ErrCodeInvalidEmail       = "INVALID_EMAIL"
+	ErrCodeInvalidPassword    = "INVALID_PASSWORD"
+	ErrCodeEmailTaken         = "EMAIL_TAKEN"
+	ErrCodeAccountBanned      = "ACCOUNT_BANNED"
+	ErrCodeWeakPassword       = "WEAK_PASSWORD"
+	ErrCodeRegistrationDenied = "REGISTRATION_DENIED"
+	ErrCodeInvalidInvite      = "INVALID_INVITE"
+	ErrCodeTooManyRequests    = "TOO_MANY_REQUESTS"
+	ErrCodeTaskBlocked        = "TASK_BLOCKED"
+	ErrCodeLabelTaken         = "LABEL_TAKEN"
+	ErrCodeWorkflow           = "WORKFLOW_VIOLATION"
+	ErrCodeRankConflict       = "RANK_CONFLICT"
+	ErrCodeConflict           = "CONFLICT"
 )
 
 // APIResponse represents a standard successful API response
@@ -32,6 +44,14 @@ type PaginationMeta struct {
 	TotalPages int `json:"total_pages" example:"5"`
 }
 
+func newPaginationMeta(total, page, pageSize int) PaginationMeta {
+	totalPages := 0
+	if pageSize > 0 {
+		totalPages = (total + pageSize - 1) / pageSize
+	}
+	return PaginationMeta{Total: total, Page: page, PageSize: pageSize, TotalPages: totalPages}
+}
+
 // ListData represents data structure for list responses
 type ListData struct {
 	Items interface{}    `json:"items"`
@@ -46,8 +66,9 @@ type APIListResponse struct {
 
 // APIError represents error details
 type APIError struct {
-	Code    string `json:"code" example:"VALIDATION_ERROR"`
-	Message string `json:"message" example:"Invalid input data"`
+	Code    string      `json:"code" example:"VALIDATION_ERROR"`
+	Message string      `json:"message" example:"Invalid input data"`
+	Details interface{} `json:"details,omitempty"`
 }
 
 // APIErrorResponse represents a standard error API response
@@ -87,6 +108,18 @@ func SendError(c *gin.Context, statusCode int, code, message string) {
 	})
 }
 
+// SendValidationError sends a 400 response carrying structured details about what was rejected
+func SendValidationError(c *gin.Context, code, message string, details interface{}) {
+	c.JSON(http.StatusBadRequest, APIErrorResponse{
+		Success: false,
+		Error: APIError{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+	})
+}
+
 // SendInternalError logs the error details server-side and sends a generic 500 error to client
 func SendInternalError(c *gin.Context, err error) {
 	// Log detailed error information server-side
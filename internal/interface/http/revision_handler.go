@@ -0,0 +1,271 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	projectUC "github.com/tomtom2k/kairo-anchor-server/internal/usecase/project"
+)
+
+type RevisionHandler struct {
+	listRevisions   *projectUC.ListRevisionsUseCase
+	getRevision     *projectUC.GetRevisionUseCase
+	diffRevisions   *projectUC.DiffRevisionsUseCase
+	restoreRevision *projectUC.RestoreRevisionUseCase
+}
+
+func NewRevisionHandler(
+	list *projectUC.ListRevisionsUseCase,
+	get *projectUC.GetRevisionUseCase,
+	diff *projectUC.DiffRevisionsUseCase,
+	restore *projectUC.RestoreRevisionUseCase,
+) *RevisionHandler {
+	return &RevisionHandler{
+		listRevisions:   list,
+		getRevision:     get,
+		diffRevisions:   diff,
+		restoreRevision: restore,
+	}
+}
+
+// ListRevisions godoc
+// @Summary List project revisions
+// @Description Every save of a project is kept as a revision, newest first. Revisions older than a day are thinned
+// @Description to one per hour, and older than a month to one per day, so numbers have gaps.
+// @Tags projects
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID (UUID)"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} APIListResponse{data=ListData{items=[]RevisionResponse}}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Router /projects/{id}/revisions [get]
+func (h *RevisionHandler) ListRevisions(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	result, err := h.listRevisions.Execute(c.Request.Context(), projectUC.ListRevisionsInput{
+		ProjectID: c.Param("id"),
+		UserID:    userID,
+		Page:      queryInt(c, "page", 1),
+		PageSize:  queryInt(c, "page_size", 20),
+	})
+	if err != nil {
+		sendProjectError(c, "LIST_REVISIONS_FAILED", err)
+		return
+	}
+
+	items := make([]RevisionResponse, len(result.Revisions))
+	for i := range result.Revisions {
+		items[i] = toRevisionResponse(&result.Revisions[i])
+	}
+	SendSuccessList(c, items, newPaginationMeta(result.Total, result.Page, result.PageSize))
+}
+
+// GetRevision godoc
+// @Summary Get a project revision
+// @Description The project as it was saved in a revision
+// @Tags projects
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID (UUID)"
+// @Param rev path int true "Revision number"
+// @Success 200 {object} APIResponse{data=RevisionDetailResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 404 {object} APIErrorResponse
+// @Router /projects/{id}/revisions/{rev} [get]
+func (h *RevisionHandler) GetRevision(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+	number, err := strconv.Atoi(c.Param("rev"))
+	if err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, "revision must be a number")
+		return
+	}
+
+	rev, err := h.getRevision.Execute(c.Request.Context(), c.Param("id"), userID, number)
+	if err != nil {
+		sendRevisionError(c, "GET_REVISION_FAILED", err)
+		return
+	}
+
+	s := rev.Snapshot
+	tasks := make([]TaskDTO, len(s.Tasks))
+	for i := range s.Tasks {
+		tasks[i] = toTaskDTO(&s.Tasks[i])
+	}
+	documents := make([]DocumentDTO, len(s.Documents))
+	for i := range s.Documents {
+		documents[i] = toDocumentDTO(&s.Documents[i])
+	}
+	SendSuccess(c, http.StatusOK, RevisionDetailResponse{
+		RevisionResponse: toRevisionResponse(rev),
+		Name:             s.Name,
+		Description:      s.Description,
+		Status:           string(s.Status),
+		Progress:         s.Progress,
+		StartDate:        s.StartDate,
+		EndDate:          s.EndDate,
+		Tasks:            tasks,
+		Documents:        documents,
+	}, "")
+}
+
+// DiffRevisions godoc
+// @Summary Compare project revisions
+// @Description What changed going from one revision to another: project fields, and tasks and documents added,
+// @Description removed, changed or reordered. Leave out to to compare against the project as it is now.
+// @Tags projects
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID (UUID)"
+// @Param from query int true "Revision to compare from"
+// @Param to query int false "Revision to compare to, defaults to the latest"
+// @Success 200 {object} APIResponse{data=RevisionDiffResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 404 {object} APIErrorResponse
+// @Router /projects/{id}/revisions/diff [get]
+func (h *RevisionHandler) DiffRevisions(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+	from, err := strconv.Atoi(c.Query("from"))
+	if err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, "from must be a revision number")
+		return
+	}
+	to := 0
+	if raw := c.Query("to"); raw != "" {
+		if to, err = strconv.Atoi(raw); err != nil {
+			SendError(c, http.StatusBadRequest, ErrCodeValidation, "to must be a revision number")
+			return
+		}
+	}
+
+	result, err := h.diffRevisions.Execute(c.Request.Context(), projectUC.DiffRevisionsInput{
+		ProjectID: c.Param("id"),
+		UserID:    userID,
+		From:      from,
+		To:        to,
+	})
+	if err != nil {
+		sendRevisionError(c, "DIFF_REVISIONS_FAILED", err)
+		return
+	}
+
+	d := result.Diff
+	response := RevisionDiffResponse{
+		From:   result.From,
+		To:     result.To,
+		Fields: toFieldChangeDTOs(d.Fields),
+		Tasks: TaskDiffDTO{
+			Added:     make([]TaskDTO, len(d.Tasks.Added)),
+			Removed:   make([]TaskDTO, len(d.Tasks.Removed)),
+			Changed:   toItemChangeDTOs(d.Tasks.Changed),
+			Reordered: d.Tasks.Reordered,
+		},
+		Documents: DocumentDiffDTO{
+			Added:     make([]DocumentDTO, len(d.Documents.Added)),
+			Removed:   make([]DocumentDTO, len(d.Documents.Removed)),
+			Changed:   toItemChangeDTOs(d.Documents.Changed),
+			Reordered: d.Documents.Reordered,
+		},
+	}
+	for i := range d.Tasks.Added {
+		response.Tasks.Added[i] = toTaskDTO(&d.Tasks.Added[i])
+	}
+	for i := range d.Tasks.Removed {
+		response.Tasks.Removed[i] = toTaskDTO(&d.Tasks.Removed[i])
+	}
+	for i := range d.Documents.Added {
+		response.Documents.Added[i] = toDocumentDTO(&d.Documents.Added[i])
+	}
+	for i := range d.Documents.Removed {
+		response.Documents.Removed[i] = toDocumentDTO(&d.Documents.Removed[i])
+	}
+	SendSuccess(c, http.StatusOK, response, "")
+}
+
+// RestoreRevision godoc
+// @Summary Restore a project revision
+// @Description Rolls the project's details, tasks and documents back to a revision. The restore is saved as a new
+// @Description revision, so it can be undone the same way. Members and sharing are not affected, and people who
+// @Description have left the project are not assigned to tasks again. Owners and editors only.
+// @Tags projects
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID (UUID)"
+// @Param rev path int true "Revision number"
+// @Success 200 {object} APIResponse{data=ProjectResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Failure 404 {object} APIErrorResponse
+// @Router /projects/{id}/revisions/{rev}/restore [post]
+func (h *RevisionHandler) RestoreRevision(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+	number, err := strconv.Atoi(c.Param("rev"))
+	if err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, "revision must be a number")
+		return
+	}
+
+	p, err := h.restoreRevision.Execute(c.Request.Context(), c.Param("id"), userID, number)
+	if err != nil {
+		sendRevisionError(c, "RESTORE_REVISION_FAILED", err)
+		return
+	}
+	SendSuccess(c, http.StatusOK, toProjectResponse(p), "Project restored successfully")
+}
+
+func sendRevisionError(c *gin.Context, code string, err error) {
+	if errors.Is(err, project.ErrRevisionNotFound) {
+		SendError(c, http.StatusNotFound, ErrCodeNotFound, err.Error())
+		return
+	}
+	sendProjectError(c, code, err)
+}
+
+func toRevisionResponse(r *project.Revision) RevisionResponse {
+	return RevisionResponse{
+		Revision:   r.Number,
+		AuthorID:   uuidString(r.AuthorID),
+		AuthorName: r.AuthorName,
+		CreatedAt:  r.CreatedAt,
+	}
+}
+
+func toFieldChangeDTOs(changes map[string]project.FieldChange) map[string]FieldChangeDTO {
+	dtos := make(map[string]FieldChangeDTO, len(changes))
+	for field, change := range changes {
+		dtos[field] = FieldChangeDTO{Before: change.Before, After: change.After}
+	}
+	return dtos
+}
+
+func toItemChangeDTOs(changes []project.ItemChange) []ItemChangeDTO {
+	dtos := make([]ItemChangeDTO, len(changes))
+	for i, change := range changes {
+		dtos[i] = ItemChangeDTO{ID: change.ID, Changes: toFieldChangeDTOs(change.Changes)}
+	}
+	return dtos
+}
@@ -0,0 +1,50 @@
+package http
+
+import "time"
+
+// Webhook DTOs
+
+type CreateWebhookRequest struct {
+	ProjectID   string   `json:"projectId,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	URL         string   `json:"url" binding:"required" example:"https://ci.example.com/hooks/kairo"`
+	Events      []string `json:"events,omitempty" example:"task.added,task.updated"`
+	Description string   `json:"description,omitempty" example:"Trigger CI on task changes"`
+}
+
+type UpdateWebhookRequest struct {
+	URL          *string   `json:"url,omitempty" example:"https://ci.example.com/hooks/kairo"`
+	Events       *[]string `json:"events,omitempty" example:"task.added,task.updated"`
+	Description  *string   `json:"description,omitempty" example:"Trigger CI on task changes"`
+	Active       *bool     `json:"active,omitempty" example:"true"`
+	RotateSecret bool      `json:"rotateSecret,omitempty" example:"false"`
+}
+
+type WebhookResponse struct {
+	ID                  string     `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ProjectID           *string    `json:"projectId,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	URL                 string     `json:"url" example:"https://ci.example.com/hooks/kairo"`
+	Secret              string     `json:"secret,omitempty" example:"whsec_4f9c..."` // only when created or rotated
+	Events              []string   `json:"events" example:"task.added,task.updated"`
+	Description         string     `json:"description" example:"Trigger CI on task changes"`
+	Active              bool       `json:"active" example:"true"`
+	ConsecutiveFailures int        `json:"consecutiveFailures" example:"0"`
+	DisabledAt          *time.Time `json:"disabledAt,omitempty"`
+	CreatedAt           time.Time  `json:"createdAt" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt           time.Time  `json:"updatedAt" example:"2024-01-01T00:00:00Z"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             string     `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	EventID        string     `json:"eventId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	EventType      string     `json:"eventType" example:"task.updated"`
+	Payload        string     `json:"payload" example:"{\"id\":\"...\",\"type\":\"task.updated\"}"`
+	Status         string     `json:"status" example:"succeeded"`
+	Attempts       int        `json:"attempts" example:"1"`
+	NextAttemptAt  *time.Time `json:"nextAttemptAt,omitempty"`
+	ResponseStatus *int       `json:"responseStatus,omitempty" example:"200"`
+	ResponseBody   string     `json:"responseBody,omitempty" example:"ok"`
+	LastError      string     `json:"lastError,omitempty" example:"endpoint responded with status 500"`
+	DurationMs     *int       `json:"durationMs,omitempty" example:"120"`
+	CreatedAt      time.Time  `json:"createdAt" example:"2024-01-01T00:00:00Z"`
+	DeliveredAt    *time.Time `json:"deliveredAt,omitempty"`
+}
@@ -0,0 +1,324 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/webhook"
+	webhookUC "github.com/tomtom2k/kairo-anchor-server/internal/usecase/webhook"
+)
+
+type WebhookHandler struct {
+	create         *webhookUC.CreateWebhookUseCase
+	list           *webhookUC.ListWebhooksUseCase
+	get            *webhookUC.GetWebhookUseCase
+	update         *webhookUC.UpdateWebhookUseCase
+	delete         *webhookUC.DeleteWebhookUseCase
+	listDeliveries *webhookUC.ListDeliveriesUseCase
+	redeliver      *webhookUC.RedeliverUseCase
+}
+
+func NewWebhookHandler(
+	create *webhookUC.CreateWebhookUseCase,
+	list *webhookUC.ListWebhooksUseCase,
+	get *webhookUC.GetWebhookUseCase,
+	update *webhookUC.UpdateWebhookUseCase,
+	delete *webhookUC.DeleteWebhookUseCase,
+	listDeliveries *webhookUC.ListDeliveriesUseCase,
+	redeliver *webhookUC.RedeliverUseCase,
+) *WebhookHandler {
+	return &WebhookHandler{
+		create:         create,
+		list:           list,
+		get:            get,
+		update:         update,
+		delete:         delete,
+		listDeliveries: listDeliveries,
+		redeliver:      redeliver,
+	}
+}
+
+// CreateWebhook godoc
+// @Summary Create a webhook
+// @Description Sends project events to a URL as signed JSON POSTs. Without a projectId the webhook covers every
+// @Description project the user is a member of; scoping it to a project requires being its owner. Leave events empty
+// @Description to receive all of them. Each request carries X-Kairo-Event, X-Kairo-Delivery and
+// @Description X-Kairo-Signature: "t=<unix time>,v1=<hex HMAC-SHA256 of "<t>.<body>" keyed with the secret>".
+// @Description The secret is only returned here. Failed deliveries are retried with backoff and webhooks that
+// @Description keep failing are disabled.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateWebhookRequest true "Create Webhook Request"
+// @Success 201 {object} APIResponse{data=WebhookResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	w, err := h.create.Execute(c.Request.Context(), webhookUC.CreateWebhookInput{
+		UserID:      userID,
+		ProjectID:   req.ProjectID,
+		URL:         req.URL,
+		Events:      req.Events,
+		Description: req.Description,
+	})
+	if err != nil {
+		sendWebhookError(c, "CREATE_WEBHOOK_FAILED", err)
+		return
+	}
+	SendSuccess(c, http.StatusCreated, toWebhookResponse(w, true), "Webhook created successfully")
+}
+
+// ListWebhooks godoc
+// @Summary List webhooks
+// @Tags webhooks
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} APIResponse{data=[]WebhookResponse}
+// @Failure 401 {object} APIErrorResponse
+// @Router /webhooks [get]
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	webhooks, err := h.list.Execute(c.Request.Context(), userID)
+	if err != nil {
+		SendInternalError(c, err)
+		return
+	}
+	items := make([]WebhookResponse, len(webhooks))
+	for i := range webhooks {
+		items[i] = toWebhookResponse(&webhooks[i], false)
+	}
+	SendSuccess(c, http.StatusOK, items, "")
+}
+
+// GetWebhook godoc
+// @Summary Get a webhook
+// @Tags webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook ID (UUID)"
+// @Success 200 {object} APIResponse{data=WebhookResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 404 {object} APIErrorResponse
+// @Router /webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	w, err := h.get.Execute(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		sendWebhookError(c, "GET_WEBHOOK_FAILED", err)
+		return
+	}
+	SendSuccess(c, http.StatusOK, toWebhookResponse(w, false), "")
+}
+
+// UpdateWebhook godoc
+// @Summary Update a webhook
+// @Description Fields left out are unchanged. Setting active re-enables a disabled webhook and clears its failure
+// @Description count. With rotateSecret a new secret is generated and returned once.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook ID (UUID)"
+// @Param request body UpdateWebhookRequest true "Update Webhook Request"
+// @Success 200 {object} APIResponse{data=WebhookResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 404 {object} APIErrorResponse
+// @Router /webhooks/{id} [put]
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req UpdateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	w, err := h.update.Execute(c.Request.Context(), webhookUC.UpdateWebhookInput{
+		ID:           c.Param("id"),
+		UserID:       userID,
+		URL:          req.URL,
+		Events:       req.Events,
+		Description:  req.Description,
+		Active:       req.Active,
+		RotateSecret: req.RotateSecret,
+	})
+	if err != nil {
+		sendWebhookError(c, "UPDATE_WEBHOOK_FAILED", err)
+		return
+	}
+	SendSuccess(c, http.StatusOK, toWebhookResponse(w, req.RotateSecret), "Webhook updated successfully")
+}
+
+// DeleteWebhook godoc
+// @Summary Delete a webhook
+// @Description Removes the webhook and its delivery log; queued deliveries are dropped
+// @Tags webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook ID (UUID)"
+// @Success 200 {object} APIResponse
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 404 {object} APIErrorResponse
+// @Router /webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := h.delete.Execute(c.Request.Context(), c.Param("id"), userID); err != nil {
+		sendWebhookError(c, "DELETE_WEBHOOK_FAILED", err)
+		return
+	}
+	SendSuccess(c, http.StatusOK, nil, "Webhook deleted successfully")
+}
+
+// ListDeliveries godoc
+// @Summary List webhook deliveries
+// @Description The webhook's delivery log, newest first, with the outcome of each delivery's latest attempt
+// @Tags webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook ID (UUID)"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} APIListResponse{data=ListData{items=[]WebhookDeliveryResponse}}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 404 {object} APIErrorResponse
+// @Router /webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	result, err := h.listDeliveries.Execute(c.Request.Context(), webhookUC.ListDeliveriesInput{
+		WebhookID: c.Param("id"),
+		UserID:    userID,
+		Page:      queryInt(c, "page", 1),
+		PageSize:  queryInt(c, "page_size", 20),
+	})
+	if err != nil {
+		sendWebhookError(c, "LIST_DELIVERIES_FAILED", err)
+		return
+	}
+
+	items := make([]WebhookDeliveryResponse, len(result.Deliveries))
+	for i := range result.Deliveries {
+		items[i] = toWebhookDeliveryResponse(&result.Deliveries[i])
+	}
+	SendSuccessList(c, items, newPaginationMeta(result.Total, result.Page, result.PageSize))
+}
+
+// Redeliver godoc
+// @Summary Redeliver a webhook delivery
+// @Description Queues the same event and payload again as a new delivery, keeping the event ID
+// @Tags webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook ID (UUID)"
+// @Param deliveryId path string true "Delivery ID (UUID)"
+// @Success 202 {object} APIResponse{data=WebhookDeliveryResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 404 {object} APIErrorResponse
+// @Router /webhooks/{id}/deliveries/{deliveryId}/redeliver [post]
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	d, err := h.redeliver.Execute(c.Request.Context(), c.Param("id"), userID, c.Param("deliveryId"))
+	if err != nil {
+		sendWebhookError(c, "REDELIVER_FAILED", err)
+		return
+	}
+	SendSuccess(c, http.StatusAccepted, toWebhookDeliveryResponse(d), "Delivery queued")
+}
+
+func sendWebhookError(c *gin.Context, code string, err error) {
+	if errors.Is(err, webhook.ErrNotFound) || errors.Is(err, webhook.ErrDeliveryNotFound) {
+		SendError(c, http.StatusNotFound, ErrCodeNotFound, err.Error())
+		return
+	}
+	sendProjectError(c, code, err)
+}
+
+// toWebhookResponse includes the secret only right after it was generated
+func toWebhookResponse(w *webhook.Webhook, withSecret bool) WebhookResponse {
+	r := WebhookResponse{
+		ID:                  w.ID.String(),
+		ProjectID:           uuidString(w.ProjectID),
+		URL:                 w.URL,
+		Events:              w.Events,
+		Description:         w.Description,
+		Active:              w.Active,
+		ConsecutiveFailures: w.ConsecutiveFailures,
+		DisabledAt:          w.DisabledAt,
+		CreatedAt:           w.CreatedAt,
+		UpdatedAt:           w.UpdatedAt,
+	}
+	if withSecret {
+		r.Secret = w.Secret
+	}
+	if r.Events == nil {
+		r.Events = []string{}
+	}
+	return r
+}
+
+func toWebhookDeliveryResponse(d *webhook.Delivery) WebhookDeliveryResponse {
+	return WebhookDeliveryResponse{
+		ID:             d.ID.String(),
+		EventID:        d.EventID.String(),
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		ResponseStatus: d.ResponseStatus,
+		ResponseBody:   d.ResponseBody,
+		LastError:      d.LastError,
+		DurationMs:     d.DurationMs,
+		CreatedAt:      d.CreatedAt,
+		DeliveredAt:    d.DeliveredAt,
+	}
+}
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	projectUC "github.com/tomtom2k/kairo-anchor-server/internal/usecase/project"
+)
+
+type WorkflowHandler struct {
+	updateWorkflow *projectUC.UpdateWorkflowUseCase
+}
+
+func NewWorkflowHandler(updateWorkflow *projectUC.UpdateWorkflowUseCase) *WorkflowHandler {
+	return &WorkflowHandler{updateWorkflow: updateWorkflow}
+}
+
+// UpdateWorkflow godoc
+// @Summary Change a project's workflow
+// @Description Replace the project's ordered task statuses (owners only). Each status has a category of todo, doing
+// @Description or done, which progress, dependencies and reports go by, and an optional WIP limit. Without
+// @Description transitions tasks may move between any two statuses. Tasks on removed statuses must be moved with
+// @Description statusMap.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param request body UpdateWorkflowRequest true "Update Workflow Request"
+// @Success 200 {object} APIResponse{data=ProjectResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /projects/{id}/workflow [put]
+func (h *WorkflowHandler) UpdateWorkflow(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+	var req UpdateWorkflowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	input := projectUC.UpdateWorkflowInput{
+		ProjectID: c.Param("id"),
+		UserID:    userID,
+		Workflow:  fromWorkflowDTO(req.WorkflowDTO),
+		StatusMap: make(map[project.TaskStatus]project.TaskStatus, len(req.StatusMap)),
+	}
+	for from, to := range req.StatusMap {
+		input.StatusMap[project.TaskStatus(from)] = project.TaskStatus(to)
+	}
+
+	p, err := h.updateWorkflow.Execute(c.Request.Context(), input)
+	if err != nil {
+		sendProjectError(c, "UPDATE_WORKFLOW_FAILED", err)
+		return
+	}
+	SendSuccess(c, http.StatusOK, toProjectResponse(p), "Workflow updated")
+}
+
+func toWorkflowDTO(w project.Workflow) WorkflowDTO {
+	dto := WorkflowDTO{
+		Statuses:    make([]WorkflowStatusDTO, len(w.Statuses)),
+		Transitions: make([]TransitionDTO, len(w.Transitions)),
+	}
+	for i, s := range w.Statuses {
+		dto.Statuses[i] = WorkflowStatusDTO{Key: string(s.Key), Name: s.Name, Category: string(s.Category), WIPLimit: s.WIPLimit}
+	}
+	for i, t := range w.Transitions {
+		dto.Transitions[i] = TransitionDTO{From: string(t.From), To: string(t.To)}
+	}
+	return dto
+}
+
+func fromWorkflowDTO(dto WorkflowDTO) project.Workflow {
+	w := project.Workflow{Statuses: make([]project.WorkflowStatus, len(dto.Statuses))}
+	for i, s := range dto.Statuses {
+		w.Statuses[i] = project.WorkflowStatus{
+			Key:      project.TaskStatus(s.Key),
+			Name:     s.Name,
+			Category: project.StatusCategory(s.Category),
+			WIPLimit: s.WIPLimit,
+		}
+	}
+	for _, t := range dto.Transitions {
+		w.Transitions = append(w.Transitions, project.Transition{From: project.TaskStatus(t.From), To: project.TaskStatus(t.To)})
+	}
+	return w
+}
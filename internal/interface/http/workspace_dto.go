@@ -0,0 +1,68 @@
+package http
+
+import "time"
+
+// Workspace DTOs
+
+type CreateWorkspaceRequest struct {
+	Name string `json:"name" binding:"required,max=255" example:"Platform Team"`
+}
+
+type UpdateWorkspaceRequest struct {
+	Name                *string  `json:"name,omitempty" binding:"omitempty,max=255" example:"Platform Team"`
+	DefaultTaskStatuses []string `json:"defaultTaskStatuses,omitempty" binding:"omitempty,dive,oneof=todo in-progress completed" example:"todo,in-progress,completed"`
+	ProjectCreation     *string  `json:"projectCreation,omitempty" binding:"omitempty,oneof=members admins" example:"admins"`
+	ParentCompletion    *string  `json:"parentCompletion,omitempty" binding:"omitempty,oneof=manual require_subtasks auto" example:"auto"`
+}
+
+type AddWorkspaceMemberRequest struct {
+	Email string `json:"email" binding:"required,email" example:"teammate@example.com"`
+	Role  string `json:"role" binding:"required,oneof=owner admin member" example:"member"`
+}
+
+type UpdateWorkspaceMemberRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=owner admin member" example:"admin"`
+}
+
+type WorkspaceSettingsDTO struct {
+	DefaultTaskStatuses []string `json:"defaultTaskStatuses" example:"todo,in-progress,completed"`
+	ProjectCreation     string   `json:"projectCreation" example:"members"`
+	ParentCompletion    string   `json:"parentCompletion" example:"manual"`
+}
+
+type WorkspaceResponse struct {
+	ID        string               `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name      string               `json:"name" example:"Platform Team"`
+	Personal  bool                 `json:"personal" example:"false"`
+	Role      string               `json:"role" example:"owner"`
+	Settings  WorkspaceSettingsDTO `json:"settings"`
+	CreatedAt time.Time            `json:"createdAt" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt time.Time            `json:"updatedAt" example:"2024-01-01T00:00:00Z"`
+}
+
+type WorkspaceMemberResponse struct {
+	UserID      string    `json:"userId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Email       string    `json:"email" example:"teammate@example.com"`
+	DisplayName string    `json:"displayName" example:"Jane Doe"`
+	Role        string    `json:"role" example:"member"`
+	CreatedAt   time.Time `json:"createdAt" example:"2024-01-01T00:00:00Z"`
+}
+
+type CreateLabelRequest struct {
+	Name  string `json:"name" binding:"required,max=50" example:"backend"`
+	Color string `json:"color" binding:"required" example:"#1f6feb"`
+}
+
+type UpdateLabelRequest struct {
+	Name  *string `json:"name,omitempty" binding:"omitempty,max=50" example:"backend"`
+	Color *string `json:"color,omitempty" example:"#1f6feb"`
+}
+
+type LabelResponse struct {
+	ID          string    `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	WorkspaceID string    `json:"workspaceId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name        string    `json:"name" example:"backend"`
+	Color       string    `json:"color" example:"#1f6feb"`
+	CreatedAt   time.Time `json:"createdAt" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt   time.Time `json:"updatedAt" example:"2024-01-01T00:00:00Z"`
+}
@@ -0,0 +1,402 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/label"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/workspace"
+	workspaceUC "github.com/tomtom2k/kairo-anchor-server/internal/usecase/workspace"
+)
+
+type WorkspaceHandler struct {
+	create       *workspaceUC.CreateWorkspaceUseCase
+	list         *workspaceUC.ListWorkspacesUseCase
+	resolve      *workspaceUC.ResolveWorkspaceUseCase
+	update       *workspaceUC.UpdateWorkspaceUseCase
+	delete       *workspaceUC.DeleteWorkspaceUseCase
+	listMembers  *workspaceUC.ListMembersUseCase
+	addMember    *workspaceUC.AddMemberUseCase
+	updateRole   *workspaceUC.UpdateMemberRoleUseCase
+	removeMember *workspaceUC.RemoveMemberUseCase
+}
+
+func NewWorkspaceHandler(
+	create *workspaceUC.CreateWorkspaceUseCase,
+	list *workspaceUC.ListWorkspacesUseCase,
+	resolve *workspaceUC.ResolveWorkspaceUseCase,
+	update *workspaceUC.UpdateWorkspaceUseCase,
+	delete *workspaceUC.DeleteWorkspaceUseCase,
+	listMembers *workspaceUC.ListMembersUseCase,
+	addMember *workspaceUC.AddMemberUseCase,
+	updateRole *workspaceUC.UpdateMemberRoleUseCase,
+	removeMember *workspaceUC.RemoveMemberUseCase,
+) *WorkspaceHandler {
+	return &WorkspaceHandler{
+		create:       create,
+		list:         list,
+		resolve:      resolve,
+		update:       update,
+		delete:       delete,
+		listMembers:  listMembers,
+		addMember:    addMember,
+		updateRole:   updateRole,
+		removeMember: removeMember,
+	}
+}
+
+// ListWorkspaces godoc
+// @Summary List workspaces
+// @Description List the workspaces the user belongs to, personal workspace first
+// @Tags workspaces
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} APIResponse{data=[]WorkspaceResponse}
+// @Failure 401 {object} APIErrorResponse
+// @Router /workspaces [get]
+func (h *WorkspaceHandler) ListWorkspaces(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	workspaces, err := h.list.Execute(c.Request.Context(), userID)
+	if err != nil {
+		SendInternalError(c, err)
+		return
+	}
+
+	response := make([]WorkspaceResponse, len(workspaces))
+	for i := range workspaces {
+		response[i] = toWorkspaceResponse(&workspaces[i])
+	}
+	SendSuccess(c, http.StatusOK, response, "")
+}
+
+// CreateWorkspace godoc
+// @Summary Create a workspace
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateWorkspaceRequest true "Create Workspace Request"
+// @Success 201 {object} APIResponse{data=WorkspaceResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Router /workspaces [post]
+func (h *WorkspaceHandler) CreateWorkspace(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req CreateWorkspaceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	w, err := h.create.Execute(c.Request.Context(), userID, req.Name)
+	if err != nil {
+		sendWorkspaceError(c, "CREATE_WORKSPACE_FAILED", err)
+		return
+	}
+
+	SendSuccess(c, http.StatusCreated, toWorkspaceResponse(w), "Workspace created successfully")
+}
+
+// GetWorkspace godoc
+// @Summary Get a workspace
+// @Tags workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Workspace ID (UUID)"
+// @Success 200 {object} APIResponse{data=WorkspaceResponse}
+// @Failure 401 {object} APIErrorResponse
+// @Failure 404 {object} APIErrorResponse
+// @Router /workspaces/{id} [get]
+func (h *WorkspaceHandler) GetWorkspace(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	w, err := h.resolve.Execute(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		SendError(c, http.StatusNotFound, ErrCodeNotFound, err.Error())
+		return
+	}
+
+	SendSuccess(c, http.StatusOK, toWorkspaceResponse(w), "")
+}
+
+// UpdateWorkspace godoc
+// @Summary Update a workspace
+// @Description Rename a workspace or change its settings (owners and admins)
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Workspace ID (UUID)"
+// @Param request body UpdateWorkspaceRequest true "Update Workspace Request"
+// @Success 200 {object} APIResponse{data=WorkspaceResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /workspaces/{id} [patch]
+func (h *WorkspaceHandler) UpdateWorkspace(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req UpdateWorkspaceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	input := workspaceUC.UpdateWorkspaceInput{
+		WorkspaceID: c.Param("id"),
+		UserID:      userID,
+		Name:        req.Name,
+	}
+	if req.DefaultTaskStatuses != nil {
+		input.DefaultTaskStatuses = make([]project.TaskStatus, len(req.DefaultTaskStatuses))
+		for i, s := range req.DefaultTaskStatuses {
+			input.DefaultTaskStatuses[i] = project.TaskStatus(s)
+		}
+	}
+	if req.ProjectCreation != nil {
+		policy := workspace.ProjectCreationPolicy(*req.ProjectCreation)
+		input.ProjectCreation = &policy
+	}
+	if req.ParentCompletion != nil {
+		rule := project.ParentCompletion(*req.ParentCompletion)
+		input.ParentCompletion = &rule
+	}
+
+	w, err := h.update.Execute(c.Request.Context(), input)
+	if err != nil {
+		sendWorkspaceError(c, "UPDATE_WORKSPACE_FAILED", err)
+		return
+	}
+
+	SendSuccess(c, http.StatusOK, toWorkspaceResponse(w), "Workspace updated successfully")
+}
+
+// DeleteWorkspace godoc
+// @Summary Delete a workspace
+// @Description Delete an empty team workspace (owners only)
+// @Tags workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Workspace ID (UUID)"
+// @Success 200 {object} APIResponse
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /workspaces/{id} [delete]
+func (h *WorkspaceHandler) DeleteWorkspace(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := h.delete.Execute(c.Request.Context(), c.Param("id"), userID); err != nil {
+		sendWorkspaceError(c, "DELETE_WORKSPACE_FAILED", err)
+		return
+	}
+
+	SendSuccess(c, http.StatusOK, nil, "Workspace deleted successfully")
+}
+
+// ListWorkspaceMembers godoc
+// @Summary List workspace members
+// @Tags workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Workspace ID (UUID)"
+// @Success 200 {object} APIResponse{data=[]WorkspaceMemberResponse}
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /workspaces/{id}/members [get]
+func (h *WorkspaceHandler) ListMembers(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	members, err := h.listMembers.Execute(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		sendWorkspaceError(c, "LIST_MEMBERS_FAILED", err)
+		return
+	}
+
+	response := make([]WorkspaceMemberResponse, len(members))
+	for i := range members {
+		response[i] = toWorkspaceMemberResponse(&members[i])
+	}
+	SendSuccess(c, http.StatusOK, response, "")
+}
+
+// AddWorkspaceMember godoc
+// @Summary Add a workspace member
+// @Description Add an existing user to a team workspace (owners and admins)
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Workspace ID (UUID)"
+// @Param request body AddWorkspaceMemberRequest true "Add Member Request"
+// @Success 201 {object} APIResponse{data=WorkspaceMemberResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /workspaces/{id}/members [post]
+func (h *WorkspaceHandler) AddMember(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req AddWorkspaceMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	m, err := h.addMember.Execute(c.Request.Context(), workspaceUC.AddMemberInput{
+		WorkspaceID: c.Param("id"),
+		UserID:      userID,
+		Email:       req.Email,
+		Role:        workspace.Role(req.Role),
+	})
+	if err != nil {
+		sendWorkspaceError(c, "ADD_MEMBER_FAILED", err)
+		return
+	}
+
+	SendSuccess(c, http.StatusCreated, toWorkspaceMemberResponse(m), "Member added successfully")
+}
+
+// UpdateWorkspaceMemberRole godoc
+// @Summary Change a workspace member's role
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Workspace ID (UUID)"
+// @Param userId path string true "Member user ID (UUID)"
+// @Param request body UpdateWorkspaceMemberRoleRequest true "Update Member Role Request"
+// @Success 200 {object} APIResponse{data=WorkspaceMemberResponse}
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /workspaces/{id}/members/{userId} [put]
+func (h *WorkspaceHandler) UpdateMemberRole(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req UpdateWorkspaceMemberRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		SendError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	m, err := h.updateRole.Execute(c.Request.Context(), workspaceUC.UpdateMemberRoleInput{
+		WorkspaceID:  c.Param("id"),
+		UserID:       userID,
+		MemberUserID: c.Param("userId"),
+		Role:         workspace.Role(req.Role),
+	})
+	if err != nil {
+		sendWorkspaceError(c, "UPDATE_MEMBER_FAILED", err)
+		return
+	}
+
+	SendSuccess(c, http.StatusOK, toWorkspaceMemberResponse(m), "Member role updated successfully")
+}
+
+// RemoveWorkspaceMember godoc
+// @Summary Remove a workspace member
+// @Description Owners and admins may remove members; anyone may remove themselves to leave
+// @Tags workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Workspace ID (UUID)"
+// @Param userId path string true "Member user ID (UUID)"
+// @Success 200 {object} APIResponse
+// @Failure 400 {object} APIErrorResponse
+// @Failure 401 {object} APIErrorResponse
+// @Failure 403 {object} APIErrorResponse
+// @Router /workspaces/{id}/members/{userId} [delete]
+func (h *WorkspaceHandler) RemoveMember(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		SendError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := h.removeMember.Execute(c.Request.Context(), c.Param("id"), userID, c.Param("userId")); err != nil {
+		sendWorkspaceError(c, "REMOVE_MEMBER_FAILED", err)
+		return
+	}
+
+	SendSuccess(c, http.StatusOK, nil, "Member removed successfully")
+}
+
+func sendWorkspaceError(c *gin.Context, code string, err error) {
+	switch {
+	case errors.Is(err, workspace.ErrForbidden):
+		SendError(c, http.StatusForbidden, ErrCodeForbidden, err.Error())
+	case errors.Is(err, workspace.ErrNotMember), errors.Is(err, label.ErrNotFound):
+		SendError(c, http.StatusNotFound, ErrCodeNotFound, err.Error())
+	case errors.Is(err, label.ErrNameTaken):
+		SendError(c, http.StatusConflict, ErrCodeLabelTaken, err.Error())
+	default:
+		SendError(c, http.StatusBadRequest, code, err.Error())
+	}
+}
+
+func toWorkspaceResponse(w *workspace.Workspace) WorkspaceResponse {
+	statuses := make([]string, len(w.Settings.DefaultTaskStatuses))
+	for i, s := range w.Settings.DefaultTaskStatuses {
+		statuses[i] = string(s)
+	}
+	return WorkspaceResponse{
+		ID:       w.ID.String(),
+		Name:     w.Name,
+		Personal: w.Personal,
+		Role:     string(w.Role),
+		Settings: WorkspaceSettingsDTO{
+			DefaultTaskStatuses: statuses,
+			ProjectCreation:     string(w.Settings.ProjectCreation),
+			ParentCompletion:    string(w.Settings.ParentCompletion),
+		},
+		CreatedAt: w.CreatedAt,
+		UpdatedAt: w.UpdatedAt,
+	}
+}
+
+func toWorkspaceMemberResponse(m *workspace.Member) WorkspaceMemberResponse {
+	return WorkspaceMemberResponse{
+		UserID:      m.UserID.String(),
+		Email:       m.Email,
+		DisplayName: m.DisplayName,
+		Role:        string(m.Role),
+		CreatedAt:   m.CreatedAt,
+	}
+}
@@ -0,0 +1,33 @@
+package account
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+)
+
+type CancelDeletionUseCase struct {
+	repo user.Repository
+}
+
+func NewCancelDeletionUseCase(r user.Repository) *CancelDeletionUseCase {
+	return &CancelDeletionUseCase{repo: r}
+}
+
+func (uc *CancelDeletionUseCase) Execute(ctx context.Context, userID string) error {
+	u, err := uc.repo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if u == nil {
+		return errors.New("user not found")
+	}
+
+	if u.DeletionScheduledAt == nil {
+		return errors.New("account deletion is not scheduled")
+	}
+
+	u.DeletionScheduledAt = nil
+	return uc.repo.Update(ctx, u)
+}
@@ -0,0 +1,252 @@
+package account
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/audit"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/notification"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/webhook"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/workspace"
+)
+
+type ExportDataUseCase struct {
+	userRepo      user.Repository
+	projectRepo   project.Repository
+	comments      project.CommentRepository
+	workspaces    workspace.Repository
+	notifications notification.Repository
+	webhooks      webhook.Repository
+	audit         audit.Repository
+}
+
+func NewExportDataUseCase(
+	u user.Repository,
+	p project.Repository,
+	c project.CommentRepository,
+	w workspace.Repository,
+	n notification.Repository,
+	wh webhook.Repository,
+	a audit.Repository,
+) *ExportDataUseCase {
+	return &ExportDataUseCase{
+		userRepo: u, projectRepo: p, comments: c, workspaces: w, notifications: n, webhooks: wh, audit: a,
+	}
+}
+
+// exportPageSize is how many rows are read at a time from paged listings
+const exportPageSize = 500
+
+// ExportResult is a ready-to-download zip archive of the user's data
+type ExportResult struct {
+	FileName string
+	Data     []byte
+}
+
+// exportedProfile deliberately leaves out the password hash and every kind of token
+type exportedProfile struct {
+	ID                  string     `json:"id"`
+	Email               string     `json:"email"`
+	IsActive            bool       `json:"isActive"`
+	DisplayName         string     `json:"displayName"`
+	AvatarURL           *string    `json:"avatarUrl,omitempty"`
+	Timezone            string     `json:"timezone"`
+	Locale              string     `json:"locale"`
+	WeekStart           string     `json:"weekStart"`
+	PendingEmail        *string    `json:"pendingEmail,omitempty"`
+	DeletionScheduledAt *time.Time `json:"deletionScheduledAt,omitempty"`
+	CreatedAt           time.Time  `json:"createdAt"`
+	UpdatedAt           time.Time  `json:"updatedAt"`
+}
+
+func (uc *ExportDataUseCase) Execute(ctx context.Context, userID string) (*ExportResult, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	u, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, errors.New("user not found")
+	}
+
+	// Owned and shared projects, each with the user's role in it
+	projects, err := uc.projectRepo.FindAllByUserID(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	if projects == nil {
+		projects = []project.Project{}
+	}
+	tasks, err := uc.projectRepo.FindTasksAssignedTo(ctx, userUUID, project.TaskFilter{})
+	if err != nil {
+		return nil, err
+	}
+	if tasks == nil {
+		tasks = []project.AssignedTask{}
+	}
+	comments, err := uc.comments.ListByAuthor(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	if comments == nil {
+		comments = []project.Comment{}
+	}
+
+	// Workspaces the user belongs to, each with their role in it
+	workspaces, err := uc.workspaces.FindAllByUserID(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	if workspaces == nil {
+		workspaces = []workspace.Workspace{}
+	}
+
+	notifications, err := allPages(func(offset, limit int) ([]notification.Notification, int, error) {
+		return uc.notifications.List(ctx, userUUID, false, offset, limit)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Only the notification preferences the user changed; the rest are the defaults
+	notificationPrefs, err := uc.notifications.Preferences(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	if notificationPrefs == nil {
+		notificationPrefs = []notification.Preference{}
+	}
+
+	// Webhooks without their signing secrets, and what was sent to them
+	webhooks, err := uc.webhooks.ListByUser(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	if webhooks == nil {
+		webhooks = []webhook.Webhook{}
+	}
+	deliveries := []webhook.Delivery{}
+	for _, w := range webhooks {
+		page, err := allPages(func(offset, limit int) ([]webhook.Delivery, int, error) {
+			return uc.webhooks.ListDeliveries(ctx, w.ID, offset, limit)
+		})
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, page...)
+	}
+
+	// What the user did in projects, and the security log of their account
+	activity, err := allPages(func(offset, limit int) ([]audit.Activity, int, error) {
+		return uc.audit.ListActivityByActor(ctx, userUUID, offset, limit)
+	})
+	if err != nil {
+		return nil, err
+	}
+	securityEvents, err := allPages(func(offset, limit int) ([]audit.SecurityEvent, int, error) {
+		return uc.audit.ListSecurityEvents(ctx, userUUID, offset, limit)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	prefs := u.Preferences()
+	profile := exportedProfile{
+		ID:                  u.ID,
+		Email:               u.Email,
+		IsActive:            u.IsActive,
+		DisplayName:         u.DisplayName,
+		AvatarURL:           u.AvatarURL,
+		Timezone:            prefs.Timezone,
+		Locale:              prefs.Locale,
+		WeekStart:           strings.ToLower(prefs.WeekStart.String()),
+		PendingEmail:        u.PendingEmail,
+		DeletionScheduledAt: u.DeletionScheduledAt,
+		CreatedAt:           u.CreatedAt,
+		UpdatedAt:           u.UpdatedAt,
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	if err := writeJSONFile(zw, "profile.json", profile); err != nil {
+		return nil, err
+	}
+	// Projects already embed their tasks and document metadata
+	if err := writeJSONFile(zw, "projects.json", projects); err != nil {
+		return nil, err
+	}
+	// Tasks assigned to the user, whoever's project they are in
+	if err := writeJSONFile(zw, "tasks.json", tasks); err != nil {
+		return nil, err
+	}
+	// Comments the user wrote, including those on other people's projects
+	if err := writeJSONFile(zw, "comments.json", comments); err != nil {
+		return nil, err
+	}
+	if err := writeJSONFile(zw, "workspaces.json", workspaces); err != nil {
+		return nil, err
+	}
+	if err := writeJSONFile(zw, "notifications.json", notifications); err != nil {
+		return nil, err
+	}
+	if err := writeJSONFile(zw, "notification_preferences.json", notificationPrefs); err != nil {
+		return nil, err
+	}
+	if err := writeJSONFile(zw, "webhooks.json", webhooks); err != nil {
+		return nil, err
+	}
+	if err := writeJSONFile(zw, "webhook_deliveries.json", deliveries); err != nil {
+		return nil, err
+	}
+	if err := writeJSONFile(zw, "activity.json", activity); err != nil {
+		return nil, err
+	}
+	if err := writeJSONFile(zw, "security_events.json", securityEvents); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return &ExportResult{
+		FileName: fmt.Sprintf("kairo-export-%s.zip", time.Now().UTC().Format("20060102-150405")),
+		Data:     buf.Bytes(),
+	}, nil
+}
+
+func writeJSONFile(zw *zip.Writer, name string, v any) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// allPages reads a paged listing to the end
+func allPages[T any](list func(offset, limit int) ([]T, int, error)) ([]T, error) {
+	all := []T{}
+	for {
+		page, total, err := list(len(all), exportPageSize)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) == 0 || len(all) >= total {
+			return all, nil
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+)
+
+// PurgeAccountsUseCase permanently removes accounts whose deletion grace period has ended.
+// It is meant to be run periodically.
+type PurgeAccountsUseCase struct {
+	userRepo user.Repository
+}
+
+func NewPurgeAccountsUseCase(u user.Repository) *PurgeAccountsUseCase {
+	return &PurgeAccountsUseCase{userRepo: u}
+}
+
+func (uc *PurgeAccountsUseCase) Execute(ctx context.Context) error {
+	users, err := uc.userRepo.FindDueForDeletion(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, u := range users {
+		if err := uc.userRepo.Purge(ctx, u.ID); err != nil {
+			return fmt.Errorf("purge user %s: %w", u.ID, err)
+		}
+		log.Printf("Purged account %s", u.ID)
+	}
+	return nil
+}
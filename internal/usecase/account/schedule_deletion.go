@@ -0,0 +1,50 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+)
+
+type ScheduleDeletionUseCase struct {
+	repo        user.Repository
+	hasher      user.PasswordHasher
+	events      event.Publisher
+	gracePeriod time.Duration
+}
+
+func NewScheduleDeletionUseCase(r user.Repository, h user.PasswordHasher, e event.Publisher, gracePeriod time.Duration) *ScheduleDeletionUseCase {
+	return &ScheduleDeletionUseCase{repo: r, hasher: h, events: e, gracePeriod: gracePeriod}
+}
+
+// Execute marks the account for deletion after the grace period and returns the deletion time.
+func (uc *ScheduleDeletionUseCase) Execute(ctx context.Context, userID, password string) (*time.Time, error) {
+	u, err := uc.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, errors.New("user not found")
+	}
+
+	if !uc.hasher.Compare(u.Password, password) {
+		return nil, errors.New("invalid password")
+	}
+
+	if u.DeletionScheduledAt != nil {
+		return nil, errors.New("account deletion is already scheduled")
+	}
+
+	deleteAt := time.Now().Add(uc.gracePeriod)
+	u.DeletionScheduledAt = &deleteAt
+
+	if err := uc.repo.Update(ctx, u); err != nil {
+		return nil, err
+	}
+
+	uc.events.Publish(ctx, event.AccountDeletionScheduled{UserID: u.ID, Email: u.Email, DeleteAt: deleteAt})
+	return &deleteAt, nil
+}
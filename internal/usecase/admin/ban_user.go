@@ -0,0 +1,59 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+)
+
+type BanUserUseCase struct {
+	repo user.Repository
+}
+
+func NewBanUserUseCase(r user.Repository) *BanUserUseCase {
+	return &BanUserUseCase{repo: r}
+}
+
+// Ban suspends the account; the user is locked out immediately, including existing sessions.
+func (uc *BanUserUseCase) Ban(ctx context.Context, adminID, userID, reason string) (*user.User, error) {
+	if adminID == userID {
+		return nil, errors.New("you cannot ban yourself")
+	}
+
+	u, err := uc.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, errors.New("user not found")
+	}
+
+	now := time.Now()
+	u.BannedAt = &now
+	u.BanReason = reason
+
+	if err := uc.repo.Update(ctx, u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func (uc *BanUserUseCase) Unban(ctx context.Context, userID string) (*user.User, error) {
+	u, err := uc.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, errors.New("user not found")
+	}
+
+	u.BannedAt = nil
+	u.BanReason = ""
+
+	if err := uc.repo.Update(ctx, u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
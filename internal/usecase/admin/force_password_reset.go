@@ -0,0 +1,62 @@
+package admin
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+)
+
+const forcedResetTokenTTL = 24 * time.Hour
+
+type ForcePasswordResetUseCase struct {
+	repo   user.Repository
+	events event.Publisher
+}
+
+func NewForcePasswordResetUseCase(r user.Repository, e event.Publisher) *ForcePasswordResetUseCase {
+	return &ForcePasswordResetUseCase{repo: r, events: e}
+}
+
+// Execute blocks password login until the user sets a new password through the emailed reset link,
+// and signs the user out everywhere in case a session was compromised.
+func (uc *ForcePasswordResetUseCase) Execute(ctx context.Context, userID string) error {
+	u, err := uc.repo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if u == nil {
+		return errors.New("user not found")
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(forcedResetTokenTTL)
+	u.ResetToken = &token
+	u.ResetTokenExpires = &expiresAt
+	u.PasswordResetRequired = true
+	u.TokensValidAfter = &now
+
+	if err := uc.repo.Update(ctx, u); err != nil {
+		return err
+	}
+
+	uc.events.Publish(ctx, event.PasswordResetRequested{UserID: u.ID, Email: u.Email, Token: token, ByAdmin: true})
+	return nil
+}
+
+func generateToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
@@ -0,0 +1,53 @@
+package admin
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+)
+
+type GetUserUseCase struct {
+	userRepo    user.Repository
+	projectRepo project.Repository
+}
+
+func NewGetUserUseCase(u user.Repository, p project.Repository) *GetUserUseCase {
+	return &GetUserUseCase{userRepo: u, projectRepo: p}
+}
+
+// UserDetails is a user together with how many projects they own, per status
+type UserDetails struct {
+	User          *user.User
+	ProjectCounts map[project.ProjectStatus]int
+	TotalProjects int
+}
+
+func (uc *GetUserUseCase) Execute(ctx context.Context, userID string) (*UserDetails, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	u, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, errors.New("user not found")
+	}
+
+	counts, err := uc.projectRepo.CountByStatusForUser(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+
+	return &UserDetails{User: u, ProjectCounts: counts, TotalProjects: total}, nil
+}
@@ -0,0 +1,86 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+)
+
+type CreateInviteCodeUseCase struct {
+	repo user.InviteCodeRepository
+}
+
+func NewCreateInviteCodeUseCase(r user.InviteCodeRepository) *CreateInviteCodeUseCase {
+	return &CreateInviteCodeUseCase{repo: r}
+}
+
+type CreateInviteCodeInput struct {
+	CreatedBy string
+	Email     *string
+	MaxUses   int
+	ExpiresAt *time.Time
+}
+
+func (uc *CreateInviteCodeUseCase) Execute(ctx context.Context, input CreateInviteCodeInput) (*user.InviteCode, error) {
+	if input.MaxUses <= 0 {
+		input.MaxUses = 1
+	}
+	if input.ExpiresAt != nil && input.ExpiresAt.Before(time.Now()) {
+		return nil, errors.New("expiry date must be in the future")
+	}
+	if input.Email != nil && strings.TrimSpace(*input.Email) == "" {
+		input.Email = nil
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	code := &user.InviteCode{
+		// Short enough to type, long enough not to guess
+		Code:      strings.ToUpper(token[:16]),
+		Email:     input.Email,
+		MaxUses:   input.MaxUses,
+		ExpiresAt: input.ExpiresAt,
+		CreatedBy: input.CreatedBy,
+	}
+	if err := uc.repo.Create(ctx, code); err != nil {
+		return nil, err
+	}
+	return code, nil
+}
+
+type ListInviteCodesUseCase struct {
+	repo user.InviteCodeRepository
+}
+
+func NewListInviteCodesUseCase(r user.InviteCodeRepository) *ListInviteCodesUseCase {
+	return &ListInviteCodesUseCase{repo: r}
+}
+
+func (uc *ListInviteCodesUseCase) Execute(ctx context.Context) ([]user.InviteCode, error) {
+	codes, err := uc.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if codes == nil {
+		return []user.InviteCode{}, nil
+	}
+	return codes, nil
+}
+
+type RevokeInviteCodeUseCase struct {
+	repo user.InviteCodeRepository
+}
+
+func NewRevokeInviteCodeUseCase(r user.InviteCodeRepository) *RevokeInviteCodeUseCase {
+	return &RevokeInviteCodeUseCase{repo: r}
+}
+
+func (uc *RevokeInviteCodeUseCase) Execute(ctx context.Context, id string) error {
+	return uc.repo.Revoke(ctx, id)
+}
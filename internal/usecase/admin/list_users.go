@@ -0,0 +1,70 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+type ListUsersUseCase struct {
+	repo user.Repository
+}
+
+func NewListUsersUseCase(r user.Repository) *ListUsersUseCase {
+	return &ListUsersUseCase{repo: r}
+}
+
+type ListUsersInput struct {
+	Query    string
+	Role     user.Role
+	Active   *bool
+	Banned   *bool
+	Page     int
+	PageSize int
+}
+
+type ListUsersResult struct {
+	Users    []user.User
+	Total    int
+	Page     int
+	PageSize int
+}
+
+func (uc *ListUsersUseCase) Execute(ctx context.Context, input ListUsersInput) (*ListUsersResult, error) {
+	if input.Page < 1 {
+		input.Page = 1
+	}
+	if input.PageSize < 1 {
+		input.PageSize = defaultPageSize
+	}
+	if input.PageSize > maxPageSize {
+		input.PageSize = maxPageSize
+	}
+
+	users, total, err := uc.repo.Search(ctx, user.SearchFilter{
+		Query:  input.Query,
+		Role:   input.Role,
+		Active: input.Active,
+		Banned: input.Banned,
+		Offset: (input.Page - 1) * input.PageSize,
+		Limit:  input.PageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if users == nil {
+		users = []user.User{}
+	}
+
+	return &ListUsersResult{
+		Users:    users,
+		Total:    total,
+		Page:     input.Page,
+		PageSize: input.PageSize,
+	}, nil
+}
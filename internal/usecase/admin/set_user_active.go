@@ -0,0 +1,43 @@
+package admin
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+)
+
+type SetUserActiveUseCase struct {
+	repo   user.Repository
+	events event.Publisher
+}
+
+func NewSetUserActiveUseCase(r user.Repository, e event.Publisher) *SetUserActiveUseCase {
+	return &SetUserActiveUseCase{repo: r, events: e}
+}
+
+// Execute activates or deactivates an account. Activating also discards the pending activation token.
+func (uc *SetUserActiveUseCase) Execute(ctx context.Context, userID string, active bool) (*user.User, error) {
+	u, err := uc.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, errors.New("user not found")
+	}
+
+	wasActive := u.IsActive
+	u.IsActive = active
+	if active {
+		u.ActivationToken = nil
+	}
+
+	if err := uc.repo.Update(ctx, u); err != nil {
+		return nil, err
+	}
+	if active && !wasActive {
+		uc.events.Publish(ctx, event.UserActivated{UserID: u.ID, Email: u.Email})
+	}
+	return u, nil
+}
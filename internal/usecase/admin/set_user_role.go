@@ -0,0 +1,40 @@
+package admin
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+)
+
+type SetUserRoleUseCase struct {
+	repo user.Repository
+}
+
+func NewSetUserRoleUseCase(r user.Repository) *SetUserRoleUseCase {
+	return &SetUserRoleUseCase{repo: r}
+}
+
+func (uc *SetUserRoleUseCase) Execute(ctx context.Context, adminID, userID string, role user.Role) (*user.User, error) {
+	if !role.IsValid() {
+		return nil, errors.New("invalid role")
+	}
+	// Keeps at least the acting admin around; there is no other way back in without psql
+	if adminID == userID && role != user.RoleAdmin {
+		return nil, errors.New("you cannot remove your own admin role")
+	}
+
+	u, err := uc.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, errors.New("user not found")
+	}
+
+	u.Role = role
+	if err := uc.repo.Update(ctx, u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
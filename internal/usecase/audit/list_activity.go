@@ -0,0 +1,92 @@
+package audit
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/audit"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+type ListActivityUseCase struct {
+	projects project.Repository
+	repo     audit.Repository
+}
+
+func NewListActivityUseCase(projects project.Repository, repo audit.Repository) *ListActivityUseCase {
+	return &ListActivityUseCase{projects: projects, repo: repo}
+}
+
+type ListActivityInput struct {
+	ProjectID string
+	UserID    string
+	Filter    audit.ActivityFilter
+	Page      int
+	PageSize  int
+}
+
+type ListActivityResult struct {
+	Activity []audit.Activity
+	Total    int
+	Page     int
+	PageSize int
+}
+
+// Execute returns a page of a project's activity log, newest first. Any member may read it.
+func (uc *ListActivityUseCase) Execute(ctx context.Context, input ListActivityInput) (*ListActivityResult, error) {
+	if input.Filter.EntityType != "" && !input.Filter.EntityType.IsValid() {
+		return nil, errors.New("invalid entity type")
+	}
+	if input.Filter.From != nil && input.Filter.To != nil && !input.Filter.From.Before(*input.Filter.To) {
+		return nil, errors.New("from must be before to")
+	}
+	if input.Page < 1 {
+		input.Page = 1
+	}
+	if input.PageSize < 1 {
+		input.PageSize = defaultPageSize
+	}
+	if input.PageSize > maxPageSize {
+		input.PageSize = maxPageSize
+	}
+
+	p, err := loadProject(ctx, uc.projects, input.ProjectID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	activity, total, err := uc.repo.ListActivity(ctx, p.ID, input.Filter, (input.Page-1)*input.PageSize, input.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	if activity == nil {
+		activity = []audit.Activity{}
+	}
+	return &ListActivityResult{Activity: activity, Total: total, Page: input.Page, PageSize: input.PageSize}, nil
+}
+
+func loadProject(ctx context.Context, projects project.Repository, projectID, userID string) (*project.Project, error) {
+	pid, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, errors.New("invalid project ID format")
+	}
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	p, err := projects.FindByID(ctx, pid, uid)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, errors.New("project not found")
+	}
+	return p, nil
+}
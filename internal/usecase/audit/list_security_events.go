@@ -0,0 +1,56 @@
+package audit
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/audit"
+)
+
+type ListSecurityEventsUseCase struct {
+	repo audit.Repository
+}
+
+func NewListSecurityEventsUseCase(repo audit.Repository) *ListSecurityEventsUseCase {
+	return &ListSecurityEventsUseCase{repo: repo}
+}
+
+type ListSecurityEventsInput struct {
+	UserID   string
+	Page     int
+	PageSize int
+}
+
+type ListSecurityEventsResult struct {
+	Events   []audit.SecurityEvent
+	Total    int
+	Page     int
+	PageSize int
+}
+
+// Execute returns a page of the user's own security log, newest first
+func (uc *ListSecurityEventsUseCase) Execute(ctx context.Context, input ListSecurityEventsInput) (*ListSecurityEventsResult, error) {
+	userID, err := uuid.Parse(input.UserID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+	if input.Page < 1 {
+		input.Page = 1
+	}
+	if input.PageSize < 1 {
+		input.PageSize = defaultPageSize
+	}
+	if input.PageSize > maxPageSize {
+		input.PageSize = maxPageSize
+	}
+
+	events, total, err := uc.repo.ListSecurityEvents(ctx, userID, (input.Page-1)*input.PageSize, input.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	if events == nil {
+		events = []audit.SecurityEvent{}
+	}
+	return &ListSecurityEventsResult{Events: events, Total: total, Page: input.Page, PageSize: input.PageSize}, nil
+}
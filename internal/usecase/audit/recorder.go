@@ -0,0 +1,180 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/audit"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+	"github.com/tomtom2k/kairo-anchor-server/pkg/requestinfo"
+)
+
+// Recorder writes project activity and account security events to the audit log
+type Recorder struct {
+	repo audit.Repository
+}
+
+func NewRecorder(repo audit.Repository) *Recorder {
+	return &Recorder{repo: repo}
+}
+
+// Subscribe records events as they are published, before the request that caused them
+// returns, so every change a client sees is already in the log
+func (r *Recorder) Subscribe(bus event.Bus) {
+	bus.Subscribe(event.All, r.record)
+}
+
+func (r *Recorder) record(ctx context.Context, e event.Event) error {
+	info := requestinfo.FromContext(ctx)
+	if a := activityFor(e); a != nil {
+		a.RequestID = info.ID
+		return r.repo.AppendActivity(ctx, a)
+	}
+	if s := securityEventFor(e); s != nil {
+		s.IP = info.IP
+		s.UserAgent = info.UserAgent
+		s.RequestID = info.ID
+		return r.repo.AppendSecurityEvent(ctx, s)
+	}
+	return nil
+}
+
+// activityFor turns a project change into an activity entry, or returns nil for events
+// that are not changes to a project, or changes that changed nothing
+func activityFor(e event.Event) *audit.Activity {
+	var change event.ProjectChange
+	var entity audit.EntityType
+	var entityID string
+	var changes map[string]audit.Change
+
+	switch e := e.(type) {
+	case event.ProjectCreated:
+		change, entity, entityID, changes = e.ProjectChange, audit.EntityProject, e.ProjectID.String(), diff(nil, e.Project)
+	case event.ProjectUpdated:
+		change, entity, entityID, changes = e.ProjectChange, audit.EntityProject, e.ProjectID.String(), diff(e.Previous, e.Project)
+	case event.ProjectDeleted:
+		change, entity, entityID, changes = e.ProjectChange, audit.EntityProject, e.ProjectID.String(), diff(e.Project, nil)
+	case event.ProjectRestored:
+		change, entity, entityID, changes = e.ProjectChange, audit.EntityProject, e.ProjectID.String(), diff(e.Previous, e.Snapshot)
+		changes["restoredRevision"] = audit.Change{After: marshal(e.RestoredRevision)}
+	case event.TasksReordered:
+		change, entity, entityID = e.ProjectChange, audit.EntityProject, e.ProjectID.String()
+		changes = map[string]audit.Change{"taskOrder": {Before: marshal(e.Previous), After: marshal(e.TaskIDs)}}
+	case event.TaskAdded:
+		change, entity, entityID, changes = e.ProjectChange, audit.EntityTask, e.Task.ID, diff(nil, e.Task)
+	case event.TaskUpdated:
+		change, entity, entityID, changes = e.ProjectChange, audit.EntityTask, e.Task.ID, diff(e.Previous, e.Task)
+	case event.TaskDeleted:
+		change, entity, entityID, changes = e.ProjectChange, audit.EntityTask, e.Task.ID, diff(e.Task, nil)
+	case event.WorkflowUpdated:
+		change, entity, entityID = e.ProjectChange, audit.EntityProject, e.ProjectID.String()
+		changes = map[string]audit.Change{"workflow": {Before: marshal(e.Previous), After: marshal(e.Workflow)}}
+	case event.DocumentAdded:
+		change, entity, entityID, changes = e.ProjectChange, audit.EntityDocument, e.Document.ID, diff(nil, e.Document)
+	case event.DocumentUpdated:
+		change, entity, entityID, changes = e.ProjectChange, audit.EntityDocument, e.Document.ID, diff(e.Previous, e.Document)
+	case event.DocumentDeleted:
+		change, entity, entityID, changes = e.ProjectChange, audit.EntityDocument, e.Document.ID, diff(e.Document, nil)
+	default:
+		return nil
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	a := &audit.Activity{
+		ProjectID:  change.ProjectID,
+		Action:     e.EventName(),
+		EntityType: entity,
+		EntityID:   entityID,
+		Changes:    changes,
+	}
+	if actorID, err := uuid.Parse(change.ActorID); err == nil {
+		a.ActorID = &actorID
+	}
+	return a
+}
+
+// securityEventFor picks out the events that belong on an account's security log
+func securityEventFor(e event.Event) *audit.SecurityEvent {
+	var userID string
+	var details map[string]string
+
+	switch e := e.(type) {
+	case event.UserRegistered:
+		userID = e.UserID
+	case event.UserActivated:
+		userID = e.UserID
+	case event.UserLoggedIn:
+		userID, details = e.UserID, map[string]string{"method": e.Method}
+	case event.LoginFailed:
+		userID, details = e.UserID, map[string]string{"reason": e.Reason}
+	case event.PasswordChanged:
+		userID = e.UserID
+	case event.PasswordReset:
+		userID = e.UserID
+	case event.PasswordResetRequested:
+		userID = e.UserID
+		if e.ByAdmin {
+			details = map[string]string{"requested_by": "admin"}
+		}
+	case event.EmailChangeRequested:
+		userID, details = e.UserID, map[string]string{"new_email": e.NewEmail}
+	case event.EmailChanged:
+		userID, details = e.UserID, map[string]string{"old_email": e.OldEmail, "new_email": e.NewEmail}
+	case event.EmailChangeReverted:
+		userID, details = e.UserID, map[string]string{"email": e.Email}
+	case event.MagicLinkRequested:
+		userID = e.UserID
+	case event.AccountDeletionScheduled:
+		userID, details = e.UserID, map[string]string{"delete_at": e.DeleteAt.UTC().Format(time.RFC3339)}
+	default:
+		return nil
+	}
+
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil
+	}
+	if details == nil {
+		details = map[string]string{}
+	}
+	return &audit.SecurityEvent{UserID: id, Event: e.EventName(), Details: details}
+}
+
+// diff compares the JSON fields of two values and returns those that differ.
+// A nil before or after means the value was created or deleted.
+func diff(before, after any) map[string]audit.Change {
+	b, a := fields(before), fields(after)
+	changes := make(map[string]audit.Change)
+	for name, value := range a {
+		if !bytes.Equal(b[name], value) {
+			changes[name] = audit.Change{Before: b[name], After: value}
+		}
+	}
+	for name, value := range b {
+		if _, ok := a[name]; !ok {
+			changes[name] = audit.Change{Before: value}
+		}
+	}
+	return changes
+}
+
+func fields(v any) map[string]json.RawMessage {
+	if v == nil {
+		return nil
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(marshal(v), &m); err != nil {
+		return nil
+	}
+	return m
+}
+
+func marshal(v any) json.RawMessage {
+	raw, _ := json.Marshal(v)
+	return raw
+}
@@ -3,15 +3,17 @@ package auth
 import (
 	"context"
 
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
 	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
 )
 
 type ActivateAccountUseCase struct {
-	repo user.Repository
+	repo   user.Repository
+	events event.Publisher
 }
 
-func NewActivateAccountUseCase(r user.Repository) *ActivateAccountUseCase {
-	return &ActivateAccountUseCase{r}
+func NewActivateAccountUseCase(r user.Repository, e event.Publisher) *ActivateAccountUseCase {
+	return &ActivateAccountUseCase{r, e}
 }
 
 func (a *ActivateAccountUseCase) Execute(ctx context.Context, token string) error {
@@ -25,5 +27,10 @@ func (a *ActivateAccountUseCase) Execute(ctx context.Context, token string) erro
 	u.IsActive = true
 	u.ActivationToken = nil
 
-	return a.repo.Update(ctx, u)
+	if err := a.repo.Update(ctx, u); err != nil {
+		return err
+	}
+
+	a.events.Publish(ctx, event.UserActivated{UserID: u.ID, Email: u.Email})
+	return nil
 }
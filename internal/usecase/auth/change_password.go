@@ -5,16 +5,19 @@ import (
 	"errors"
 	"time"
 
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
 	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
 )
 
 type ChangePasswordUseCase struct {
-	repo   user.Repository
-	hasher user.PasswordHasher
+	repo           user.Repository
+	hasher         user.PasswordHasher
+	passwordPolicy user.PasswordPolicy
+	events         event.Publisher
 }
 
-func NewChangePasswordUseCase(r user.Repository, h user.PasswordHasher) *ChangePasswordUseCase {
-	return &ChangePasswordUseCase{r, h}
+func NewChangePasswordUseCase(r user.Repository, h user.PasswordHasher, pp user.PasswordPolicy, e event.Publisher) *ChangePasswordUseCase {
+	return &ChangePasswordUseCase{r, h, pp, e}
 }
 
 func (c *ChangePasswordUseCase) Execute(ctx context.Context, token, newPassword string) error {
@@ -29,6 +32,10 @@ func (c *ChangePasswordUseCase) Execute(ctx context.Context, token, newPassword
 		return errors.New("reset token has expired")
 	}
 
+	if err := c.passwordPolicy.Validate(newPassword); err != nil {
+		return err
+	}
+
 	// Hash new password
 	passwordHash, err := c.hasher.Hash(newPassword)
 	if err != nil {
@@ -39,6 +46,12 @@ func (c *ChangePasswordUseCase) Execute(ctx context.Context, token, newPassword
 	u.Password = passwordHash
 	u.ResetToken = nil
 	u.ResetTokenExpires = nil
+	u.PasswordResetRequired = false
+
+	if err := c.repo.Update(ctx, u); err != nil {
+		return err
+	}
 
-	return c.repo.Update(ctx, u)
+	c.events.Publish(ctx, event.PasswordReset{UserID: u.ID, Email: u.Email})
+	return nil
 }
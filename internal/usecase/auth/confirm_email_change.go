@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+)
+
+const emailRevertTokenTTL = 7 * 24 * time.Hour
+
+type ConfirmEmailChangeUseCase struct {
+	repo   user.Repository
+	events event.Publisher
+}
+
+func NewConfirmEmailChangeUseCase(r user.Repository, e event.Publisher) *ConfirmEmailChangeUseCase {
+	return &ConfirmEmailChangeUseCase{r, e}
+}
+
+func (uc *ConfirmEmailChangeUseCase) Execute(ctx context.Context, token string) error {
+	u, err := uc.repo.FindByEmailChangeToken(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	if u.PendingEmail == nil {
+		return errors.New("no pending email change")
+	}
+	if u.EmailChangeTokenExpires != nil && u.EmailChangeTokenExpires.Before(time.Now()) {
+		return errors.New("email change token has expired")
+	}
+
+	// The address may have been taken since the change was requested
+	existing, err := uc.repo.FindByEmail(ctx, *u.PendingEmail)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != u.ID {
+		return user.ErrEmailTaken
+	}
+
+	revertToken, err := generateToken()
+	if err != nil {
+		return err
+	}
+
+	oldEmail := u.Email
+	revertExpires := time.Now().Add(emailRevertTokenTTL)
+
+	u.Email = *u.PendingEmail
+	u.PendingEmail = nil
+	u.EmailChangeToken = nil
+	u.EmailChangeTokenExpires = nil
+	u.PreviousEmail = &oldEmail
+	u.EmailRevertToken = &revertToken
+	u.EmailRevertTokenExpires = &revertExpires
+
+	if err := uc.repo.Update(ctx, u); err != nil {
+		return err
+	}
+
+	uc.events.Publish(ctx, event.EmailChanged{UserID: u.ID, OldEmail: oldEmail, NewEmail: u.Email, RevertToken: revertToken})
+	return nil
+}
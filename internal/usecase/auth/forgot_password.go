@@ -7,15 +7,16 @@ import (
 	"errors"
 	"time"
 
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
 	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
 )
 
 type ForgotPasswordUseCase struct {
-	repo         user.Repository
-	emailService user.EmailService
+	repo   user.Repository
+	events event.Publisher
 }
 
-func NewForgotPasswordUseCase(r user.Repository, e user.EmailService) *ForgotPasswordUseCase {
+func NewForgotPasswordUseCase(r user.Repository, e event.Publisher) *ForgotPasswordUseCase {
 	return &ForgotPasswordUseCase{r, e}
 }
 
@@ -44,8 +45,8 @@ func (f *ForgotPasswordUseCase) Execute(ctx context.Context, email string) error
 		return err
 	}
 
-	// Send password reset email
-	return f.emailService.SendPasswordResetEmail(email, resetToken)
+	f.events.Publish(ctx, event.PasswordResetRequested{UserID: u.ID, Email: u.Email, Token: resetToken})
+	return nil
 }
 
 func generateResetToken() (string, error) {
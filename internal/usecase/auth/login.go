@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
 	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
 )
 
@@ -11,10 +12,11 @@ type LoginUseCase struct {
 	repo         user.Repository
 	hasher       user.PasswordHasher
 	tokenService user.TokenService
+	events       event.Publisher
 }
 
-func NewLoginUseCase(r user.Repository, h user.PasswordHasher, t user.TokenService) *LoginUseCase {
-	return &LoginUseCase{r, h, t}
+func NewLoginUseCase(r user.Repository, h user.PasswordHasher, t user.TokenService, e event.Publisher) *LoginUseCase {
+	return &LoginUseCase{r, h, t, e}
 }
 
 type LoginResult struct {
@@ -34,12 +36,20 @@ func (l *LoginUseCase) Execute(ctx context.Context, email, password string) (*Lo
 
 	// Verify password
 	if !l.hasher.Compare(u.Password, password) {
-		return nil, errors.New("invalid email or password")
+		return nil, l.refuse(ctx, u, "invalid_password", errors.New("invalid email or password"))
 	}
 
 	// Check if account is active
 	if !u.IsActive {
-		return nil, errors.New("account not activated, please check your email")
+		return nil, l.refuse(ctx, u, "not_activated", errors.New("account not activated, please check your email"))
+	}
+
+	if u.IsBanned() {
+		return nil, l.refuse(ctx, u, "banned", errors.New("account has been suspended"))
+	}
+
+	if u.PasswordResetRequired {
+		return nil, l.refuse(ctx, u, "password_reset_required", errors.New("password reset required, please check your email"))
 	}
 
 	// Generate JWT token
@@ -47,9 +57,16 @@ func (l *LoginUseCase) Execute(ctx context.Context, email, password string) (*Lo
 	if err != nil {
 		return nil, err
 	}
+	l.events.Publish(ctx, event.UserLoggedIn{UserID: u.ID, Email: u.Email, Method: "password"})
 
 	return &LoginResult{
 		Token: token,
 		User:  u,
 	}, nil
 }
+
+// refuse records a failed login on the account's security log and returns err
+func (l *LoginUseCase) refuse(ctx context.Context, u *user.User, reason string, err error) error {
+	l.events.Publish(ctx, event.LoginFailed{UserID: u.ID, Email: u.Email, Reason: reason})
+	return err
+}
@@ -5,56 +5,186 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"errors"
+	"time"
 
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
 	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+	projectUC "github.com/tomtom2k/kairo-anchor-server/internal/usecase/project"
 )
 
 type RegisterUseCase struct {
-	repo         user.Repository
-	hasher       user.PasswordHasher
-	emailService user.EmailService
+	repo           user.Repository
+	hasher         user.PasswordHasher
+	events         event.Publisher
+	inviteCodes    user.InviteCodeRepository
+	invitations    project.InvitationRepository
+	members        project.MemberRepository
+	policy         user.RegistrationPolicy
+	passwordPolicy user.PasswordPolicy
 }
 
-func NewRegisterUseCase(r user.Repository, h user.PasswordHasher, e user.EmailService) *RegisterUseCase {
-	return &RegisterUseCase{r, h, e}
+func NewRegisterUseCase(
+	r user.Repository,
+	h user.PasswordHasher,
+	e event.Publisher,
+	ic user.InviteCodeRepository,
+	invitations project.InvitationRepository,
+	members project.MemberRepository,
+	policy user.RegistrationPolicy,
+	pp user.PasswordPolicy,
+) *RegisterUseCase {
+	return &RegisterUseCase{
+		repo:           r,
+		hasher:         h,
+		events:         e,
+		inviteCodes:    ic,
+		invitations:    invitations,
+		members:        members,
+		policy:         policy,
+		passwordPolicy: pp,
+	}
+}
+
+type RegisterInput struct {
+	Email      string
+	Password   string
+	InviteCode string
+	// ProjectInvitation is the token from a project invitation email
+	ProjectInvitation string
+}
+
+type RegisterResult struct {
+	// Activated is true when the account could be used right away
+	Activated bool
+	// ProjectID is the project joined through the invitation, if any
+	ProjectID string
 }
 
-func (r *RegisterUseCase) Execute(ctx context.Context, email, password string) error {
+// Execute creates an account. A valid project invitation lets the invited address register
+// whatever the registration mode, since a project owner vouched for it.
+func (r *RegisterUseCase) Execute(ctx context.Context, input RegisterInput) (*RegisterResult, error) {
+	if r.policy.Mode == user.RegistrationClosed && input.ProjectInvitation == "" {
+		return nil, user.ErrRegistrationClosed
+	}
+
+	if err := r.policy.CheckEmail(input.Email); err != nil {
+		return nil, err
+	}
+
+	if err := r.passwordPolicy.Validate(input.Password); err != nil {
+		return nil, err
+	}
+
 	// Check if user already exists
-	existingUser, err := r.repo.FindByEmail(ctx, email)
+	existingUser, err := r.repo.FindByEmail(ctx, input.Email)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if existingUser != nil {
-		return errors.New("user already exists")
+		return nil, errors.New("user already exists")
 	}
 
-	// Hash password
-	passwordHash, err := r.hasher.Hash(password)
-	if err != nil {
-		return err
+	// A project invitation proves the email address and stands in for an invite code, even
+	// while registration is closed
+	var invitation *project.Invitation
+	if input.ProjectInvitation != "" {
+		invitation, err = r.invitations.FindByToken(ctx, input.ProjectInvitation)
+		if err != nil {
+			return nil, err
+		}
+		if invitation == nil || !invitation.IsPending(time.Now()) || !invitation.IsFor(input.Email) {
+			return nil, project.ErrInvalidInvitation
+		}
 	}
 
-	// Generate activation token
-	activationToken, err := generateToken()
+	// In invite-only mode the code is checked now and only consumed once the account exists
+	var invite *user.InviteCode
+	if r.policy.Mode == user.RegistrationInviteOnly && invitation == nil {
+		invite, err = r.checkInviteCode(ctx, input.InviteCode, input.Email)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Hash password
+	passwordHash, err := r.hasher.Hash(input.Password)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Create user (inactive by default)
+	// Create user (inactive by default, invited users skip activation)
 	u := &user.User{
-		Email:           email,
-		Password:        passwordHash,
-		IsActive:        false,
-		ActivationToken: &activationToken,
+		Email:     input.Email,
+		Password:  passwordHash,
+		IsActive:  invitation != nil,
+		Timezone:  user.DefaultTimezone,
+		Locale:    user.DefaultLocale,
+		WeekStart: user.DefaultWeekStart,
+	}
+
+	var activationToken string
+	if !u.IsActive {
+		activationToken, err = generateToken()
+		if err != nil {
+			return nil, err
+		}
+		u.ActivationToken = &activationToken
 	}
 
 	if err := r.repo.Create(ctx, u); err != nil {
-		return err
+		return nil, err
+	}
+
+	if invite != nil {
+		// Another sign-up may have used the code up in the meantime; the account goes again
+		// so the address can register once it has a new code
+		if err := r.inviteCodes.Redeem(ctx, invite.ID); err != nil {
+			return nil, r.discard(ctx, u, err)
+		}
+	}
+
+	if invitation != nil {
+		// Likewise if the invitation was accepted or withdrawn in the meantime, rather than
+		// leaving an active account outside the project it was invited to
+		if err := projectUC.AcceptInvitation(ctx, r.invitations, r.members, invitation, u.ID); err != nil {
+			return nil, r.discard(ctx, u, err)
+		}
+		r.events.Publish(ctx,
+			event.UserRegistered{UserID: u.ID, Email: u.Email},
+			event.UserActivated{UserID: u.ID, Email: u.Email},
+		)
+		return &RegisterResult{Activated: true, ProjectID: invitation.ProjectID.String()}, nil
+	}
+
+	// Subscribers send the activation email
+	r.events.Publish(ctx, event.UserRegistered{UserID: u.ID, Email: u.Email, ActivationToken: activationToken})
+	return &RegisterResult{}, nil
+}
+
+// discard deletes an account whose registration could not be completed, so the address can
+// register again, and returns err
+func (r *RegisterUseCase) discard(ctx context.Context, u *user.User, err error) error {
+	if delErr := r.repo.Delete(ctx, u.ID); delErr != nil {
+		return errors.Join(err, delErr)
+	}
+	return err
+}
+
+// checkInviteCode returns the code if the address may register with it, without using it
+func (r *RegisterUseCase) checkInviteCode(ctx context.Context, code, email string) (*user.InviteCode, error) {
+	if code == "" {
+		return nil, user.ErrInviteCodeRequired
 	}
 
-	// Send activation email
-	return r.emailService.SendActivationEmail(email, activationToken)
+	invite, err := r.inviteCodes.FindByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if invite == nil || !invite.UsableBy(email, time.Now()) {
+		return nil, user.ErrInvalidInviteCode
+	}
+	return invite, nil
 }
 
 func generateToken() (string, error) {
@@ -63,4 +193,4 @@ func generateToken() (string, error) {
 		return "", err
 	}
 	return hex.EncodeToString(bytes), nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+)
+
+const emailChangeTokenTTL = 24 * time.Hour
+
+type RequestEmailChangeUseCase struct {
+	repo   user.Repository
+	hasher user.PasswordHasher
+	events event.Publisher
+}
+
+func NewRequestEmailChangeUseCase(r user.Repository, h user.PasswordHasher, e event.Publisher) *RequestEmailChangeUseCase {
+	return &RequestEmailChangeUseCase{r, h, e}
+}
+
+func (uc *RequestEmailChangeUseCase) Execute(ctx context.Context, userID, newEmail, password string) error {
+	newEmail = strings.TrimSpace(newEmail)
+
+	u, err := uc.repo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if u == nil {
+		return errors.New("user not found")
+	}
+
+	// Re-confirm identity before touching the login address
+	if !uc.hasher.Compare(u.Password, password) {
+		return errors.New("invalid password")
+	}
+
+	if user.SameEmail(u.Email, newEmail) {
+		return errors.New("new email must be different from the current one")
+	}
+
+	existing, err := uc.repo.FindByEmail(ctx, newEmail)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return user.ErrEmailTaken
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return err
+	}
+
+	// A new request replaces any previous pending change
+	expiresAt := time.Now().Add(emailChangeTokenTTL)
+	u.PendingEmail = &newEmail
+	u.EmailChangeToken = &token
+	u.EmailChangeTokenExpires = &expiresAt
+
+	if err := uc.repo.Update(ctx, u); err != nil {
+		return err
+	}
+
+	uc.events.Publish(ctx, event.EmailChangeRequested{UserID: u.ID, NewEmail: newEmail, Token: token})
+	return nil
+}
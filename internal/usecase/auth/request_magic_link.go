@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+)
+
+type RequestMagicLinkUseCase struct {
+	repo       user.Repository
+	links      user.MagicLinkRepository
+	events     event.Publisher
+	ttl        time.Duration
+	maxPerHour int // per email address
+	maxPerIP   int // per hour from one IP
+}
+
+func NewRequestMagicLinkUseCase(
+	r user.Repository,
+	l user.MagicLinkRepository,
+	e event.Publisher,
+	ttl time.Duration,
+	maxPerHour int,
+	maxPerIP int,
+) *RequestMagicLinkUseCase {
+	return &RequestMagicLinkUseCase{repo: r, links: l, events: e, ttl: ttl, maxPerHour: maxPerHour, maxPerIP: maxPerIP}
+}
+
+type RequestMagicLinkResult struct {
+	// DeviceSecret must be stored in the requesting browser when device binding was asked for
+	DeviceSecret string
+	ExpiresIn    time.Duration
+}
+
+// Execute emails a sign-in link if the address has an account. Requests are limited per email
+// and per IP before the account is looked up, so the limits apply the same to every address.
+func (uc *RequestMagicLinkUseCase) Execute(ctx context.Context, email, ip string, bindDevice bool) (*RequestMagicLinkResult, error) {
+	// Recorded before counting so concurrent requests can't all slip under the limit
+	if err := uc.links.RecordAttempt(ctx, email, ip); err != nil {
+		return nil, err
+	}
+	byEmail, byIP, err := uc.links.CountRecentAttempts(ctx, email, ip, time.Now().Add(-time.Hour))
+	if err != nil {
+		return nil, err
+	}
+	if byEmail > uc.maxPerHour || byIP > uc.maxPerIP {
+		return nil, user.ErrTooManyRequests
+	}
+
+	result := &RequestMagicLinkResult{ExpiresIn: uc.ttl}
+	var deviceHash *string
+	if bindDevice {
+		secret, err := generateToken()
+		if err != nil {
+			return nil, err
+		}
+		h := hashToken(secret)
+		result.DeviceSecret = secret
+		deviceHash = &h
+	}
+
+	// Respond the same way whether or not the account exists, so emails can't be probed
+	u, err := uc.repo.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil || u.IsBanned() {
+		return result, nil
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	link := &user.MagicLink{
+		UserID:     u.ID,
+		Email:      u.Email,
+		TokenHash:  hashToken(token),
+		DeviceHash: deviceHash,
+		ExpiresAt:  time.Now().Add(uc.ttl),
+	}
+	if err := uc.links.Create(ctx, link); err != nil {
+		return nil, err
+	}
+
+	uc.events.Publish(ctx, event.MagicLinkRequested{UserID: u.ID, Email: u.Email, Token: token, ExpiresIn: uc.ttl})
+	return result, nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -4,18 +4,23 @@ import (
 	"context"
 	"errors"
 
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
 	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
 )
 
 type ResetPasswordUseCase struct {
-	userRepo user.Repository
-	hasher   user.PasswordHasher
+	userRepo       user.Repository
+	hasher         user.PasswordHasher
+	passwordPolicy user.PasswordPolicy
+	events         event.Publisher
 }
 
-func NewResetPasswordUseCase(r user.Repository, h user.PasswordHasher) *ResetPasswordUseCase {
+func NewResetPasswordUseCase(r user.Repository, h user.PasswordHasher, pp user.PasswordPolicy, e event.Publisher) *ResetPasswordUseCase {
 	return &ResetPasswordUseCase{
-		userRepo: r,
-		hasher:   h,
+		userRepo:       r,
+		hasher:         h,
+		passwordPolicy: pp,
+		events:         e,
 	}
 }
 
@@ -35,6 +40,9 @@ func (uc *ResetPasswordUseCase) Execute(ctx context.Context, userID string, oldP
 		return errors.New("invalid old password")
 	}
 
+	if err := uc.passwordPolicy.Validate(newPassword); err != nil {
+		return err
+	}
 
 	// Hash new password
 	hashedPassword, err := uc.hasher.Hash(newPassword)
@@ -44,5 +52,10 @@ func (uc *ResetPasswordUseCase) Execute(ctx context.Context, userID string, oldP
 
 	// Update password
 	u.Password = hashedPassword
-	return uc.userRepo.Update(ctx, u)
+	if err := uc.userRepo.Update(ctx, u); err != nil {
+		return err
+	}
+
+	uc.events.Publish(ctx, event.PasswordChanged{UserID: u.ID, Email: u.Email})
+	return nil
 }
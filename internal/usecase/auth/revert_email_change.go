@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+)
+
+type RevertEmailChangeUseCase struct {
+	repo   user.Repository
+	events event.Publisher
+}
+
+func NewRevertEmailChangeUseCase(r user.Repository, e event.Publisher) *RevertEmailChangeUseCase {
+	return &RevertEmailChangeUseCase{r, e}
+}
+
+func (uc *RevertEmailChangeUseCase) Execute(ctx context.Context, token string) error {
+	u, err := uc.repo.FindByEmailRevertToken(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	if u.PreviousEmail == nil {
+		return errors.New("no email change to revert")
+	}
+	if u.EmailRevertTokenExpires != nil && u.EmailRevertTokenExpires.Before(time.Now()) {
+		return errors.New("email revert token has expired")
+	}
+
+	existing, err := uc.repo.FindByEmail(ctx, *u.PreviousEmail)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != u.ID {
+		return user.ErrEmailTaken
+	}
+
+	// Restore the old address and drop anything issued while the account may have been hijacked
+	u.Email = *u.PreviousEmail
+	u.PreviousEmail = nil
+	u.EmailRevertToken = nil
+	u.EmailRevertTokenExpires = nil
+	u.PendingEmail = nil
+	u.EmailChangeToken = nil
+	u.EmailChangeTokenExpires = nil
+	u.ResetToken = nil
+	u.ResetTokenExpires = nil
+
+	if err := uc.repo.Update(ctx, u); err != nil {
+		return err
+	}
+
+	uc.events.Publish(ctx, event.EmailChangeReverted{UserID: u.ID, Email: u.Email})
+	return nil
+}
@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+)
+
+type UpdateProfileUseCase struct {
+	repo user.Repository
+}
+
+func NewUpdateProfileUseCase(r user.Repository) *UpdateProfileUseCase {
+	return &UpdateProfileUseCase{r}
+}
+
+// UpdateProfileInput only changes the fields that are set.
+// An empty AvatarURL removes the avatar.
+type UpdateProfileInput struct {
+	UserID      string
+	DisplayName *string
+	AvatarURL   *string
+	Timezone    *string
+	Locale      *string
+	WeekStart   *string
+}
+
+func (uc *UpdateProfileUseCase) Execute(ctx context.Context, input UpdateProfileInput) (*user.User, error) {
+	u, err := uc.repo.FindByID(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, errors.New("user not found")
+	}
+
+	if input.DisplayName != nil {
+		u.DisplayName = strings.TrimSpace(*input.DisplayName)
+	}
+	if input.AvatarURL != nil {
+		if *input.AvatarURL == "" {
+			u.AvatarURL = nil
+		} else {
+			if err := user.ValidateAvatarURL(*input.AvatarURL); err != nil {
+				return nil, err
+			}
+			u.AvatarURL = input.AvatarURL
+		}
+	}
+	if input.Timezone != nil {
+		if err := user.ValidateTimezone(*input.Timezone); err != nil {
+			return nil, err
+		}
+		u.Timezone = *input.Timezone
+	}
+	if input.Locale != nil {
+		if err := user.ValidateLocale(*input.Locale); err != nil {
+			return nil, err
+		}
+		u.Locale = *input.Locale
+	}
+	if input.WeekStart != nil {
+		day, err := user.ParseWeekday(*input.WeekStart)
+		if err != nil {
+			return nil, err
+		}
+		u.WeekStart = day
+	}
+
+	if err := uc.repo.Update(ctx, u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
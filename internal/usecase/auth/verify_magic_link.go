@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"time"
+
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+)
+
+type VerifyMagicLinkUseCase struct {
+	repo         user.Repository
+	links        user.MagicLinkRepository
+	tokenService user.TokenService
+	events       event.Publisher
+}
+
+func NewVerifyMagicLinkUseCase(r user.Repository, l user.MagicLinkRepository, t user.TokenService, e event.Publisher) *VerifyMagicLinkUseCase {
+	return &VerifyMagicLinkUseCase{repo: r, links: l, tokenService: t, events: e}
+}
+
+// Execute exchanges a magic link token for a regular JWT.
+// deviceSecret is the value stored in the browser when the link was requested with device binding.
+func (uc *VerifyMagicLinkUseCase) Execute(ctx context.Context, token, deviceSecret string) (*LoginResult, error) {
+	link, err := uc.links.FindByTokenHash(ctx, hashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if link == nil || link.UsedAt != nil || link.ExpiresAt.Before(time.Now()) {
+		return nil, user.ErrInvalidMagicLink
+	}
+
+	if link.DeviceHash != nil {
+		if deviceSecret == "" || subtle.ConstantTimeCompare([]byte(*link.DeviceHash), []byte(hashToken(deviceSecret))) != 1 {
+			return nil, errors.New("this sign-in link must be opened in the browser that requested it")
+		}
+	}
+
+	u, err := uc.repo.FindByID(ctx, link.UserID)
+	if err != nil {
+		return nil, err
+	}
+	// The address may have changed since the link was sent
+	if u == nil || !user.SameEmail(u.Email, link.Email) {
+		return nil, user.ErrInvalidMagicLink
+	}
+	if u.IsBanned() {
+		return nil, errors.New("account has been suspended")
+	}
+	// A reset forced by support can't be sidestepped by signing in without the password
+	if u.PasswordResetRequired {
+		return nil, errors.New("password reset required, please check your email")
+	}
+
+	// Single use, even under concurrent clicks
+	if err := uc.links.MarkUsed(ctx, link.ID); err != nil {
+		return nil, err
+	}
+
+	// Opening the link proves ownership of the address, which is all activation checks
+	if !u.IsActive {
+		u.IsActive = true
+		u.ActivationToken = nil
+		if err := uc.repo.Update(ctx, u); err != nil {
+			return nil, err
+		}
+		uc.events.Publish(ctx, event.UserActivated{UserID: u.ID, Email: u.Email})
+	}
+
+	jwtToken, err := uc.tokenService.Generate(u.ID)
+	if err != nil {
+		return nil, err
+	}
+	uc.events.Publish(ctx, event.UserLoggedIn{UserID: u.ID, Email: u.Email, Method: "magic_link"})
+
+	return &LoginResult{Token: jwtToken, User: u}, nil
+}
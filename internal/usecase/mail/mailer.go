@@ -0,0 +1,66 @@
+// Package mail sends transactional emails in response to domain events.
+package mail
+
+import (
+	"context"
+
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+)
+
+type Mailer struct {
+	emailService user.EmailService
+}
+
+func NewMailer(emailService user.EmailService) *Mailer {
+	return &Mailer{emailService: emailService}
+}
+
+// Subscribe registers the mailer on the bus. Emails go out in the background so a slow
+// mail server never holds up a request; failures are logged by the bus.
+func (m *Mailer) Subscribe(bus event.Bus) {
+	event.OnAsync(bus, m.userRegistered)
+	event.OnAsync(bus, m.passwordResetRequested)
+	event.OnAsync(bus, m.emailChangeRequested)
+	event.OnAsync(bus, m.emailChanged)
+	event.OnAsync(bus, m.magicLinkRequested)
+	event.OnAsync(bus, m.accountDeletionScheduled)
+	event.OnAsync(bus, m.memberInvited)
+}
+
+func (m *Mailer) userRegistered(_ context.Context, e event.UserRegistered) error {
+	// Invited users are active from the start and have nothing to confirm
+	if e.ActivationToken == "" {
+		return nil
+	}
+	return m.emailService.SendActivationEmail(e.Email, e.ActivationToken)
+}
+
+func (m *Mailer) passwordResetRequested(_ context.Context, e event.PasswordResetRequested) error {
+	return m.emailService.SendPasswordResetEmail(e.Email, e.Token)
+}
+
+// emailChangeRequested sends the confirmation to the new address to prove the user owns it
+func (m *Mailer) emailChangeRequested(_ context.Context, e event.EmailChangeRequested) error {
+	return m.emailService.SendEmailChangeConfirmation(e.NewEmail, e.Token)
+}
+
+// emailChanged lets the old address know, with a way to undo the change if it wasn't the owner
+func (m *Mailer) emailChanged(_ context.Context, e event.EmailChanged) error {
+	return m.emailService.SendEmailChangedNotice(e.OldEmail, e.NewEmail, e.RevertToken)
+}
+
+func (m *Mailer) magicLinkRequested(_ context.Context, e event.MagicLinkRequested) error {
+	return m.emailService.SendMagicLinkEmail(e.Email, e.Token, e.ExpiresIn)
+}
+
+func (m *Mailer) accountDeletionScheduled(_ context.Context, e event.AccountDeletionScheduled) error {
+	return m.emailService.SendAccountDeletionScheduled(e.Email, e.DeleteAt)
+}
+
+func (m *Mailer) memberInvited(_ context.Context, e event.MemberInvited) error {
+	inv := e.Invitation
+	return m.emailService.SendProjectInvitation(
+		inv.Email, e.InviterName, inv.ProjectName, string(inv.Role), inv.Token, inv.ExpiresAt,
+	)
+}
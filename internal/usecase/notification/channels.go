@@ -0,0 +1,53 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/notification"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+)
+
+// InAppChannel stores notifications in the user's inbox
+type InAppChannel struct {
+	repo notification.Repository
+}
+
+func NewInAppChannel(repo notification.Repository) *InAppChannel {
+	return &InAppChannel{repo: repo}
+}
+
+func (c *InAppChannel) Kind() notification.ChannelKind {
+	return notification.ChannelInApp
+}
+
+func (c *InAppChannel) Deliver(ctx context.Context, to notification.Recipient, n *notification.Notification) error {
+	return c.repo.Create(ctx, n)
+}
+
+// EmailChannel emails notifications, using a dedicated template where one exists
+type EmailChannel struct {
+	emailService user.EmailService
+}
+
+func NewEmailChannel(e user.EmailService) *EmailChannel {
+	return &EmailChannel{emailService: e}
+}
+
+func (c *EmailChannel) Kind() notification.ChannelKind {
+	return notification.ChannelEmail
+}
+
+func (c *EmailChannel) Deliver(ctx context.Context, to notification.Recipient, n *notification.Notification) error {
+	if to.Email == "" {
+		return nil
+	}
+	d := n.Data
+	switch n.Type {
+	case notification.TypeTaskAssigned:
+		return c.emailService.SendTaskAssigned(to.Email, d.ActorName, d.ProjectName, d.TaskTitle)
+	case notification.TypeMention:
+		return c.emailService.SendCommentMention(to.Email, d.ActorName, d.ProjectName, d.TaskTitle, d.Excerpt)
+	default:
+		return c.emailService.SendNotification(to.Email, n.Title, n.Body)
+	}
+}
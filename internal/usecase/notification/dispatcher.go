@@ -0,0 +1,52 @@
+package notification
+
+import (
+	"context"
+	"log"
+
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/notification"
+)
+
+// Dispatcher is the notification.Notifier used across the app. It honours each
+// recipient's preferences and hands notifications to the enabled channels.
+type Dispatcher struct {
+	repo     notification.Repository
+	channels []notification.Channel
+}
+
+func NewDispatcher(repo notification.Repository, channels ...notification.Channel) *Dispatcher {
+	return &Dispatcher{repo: repo, channels: channels}
+}
+
+func (d *Dispatcher) Notify(ctx context.Context, to notification.Recipient, n *notification.Notification) {
+	n.UserID = to.UserID
+
+	pref, err := preferenceFor(ctx, d.repo, to, n.Type)
+	if err != nil {
+		// Missing a notification is worse than sending one the user turned off
+		log.Printf("Failed to load notification preferences for %s: %v", to.UserID, err)
+		pref = notification.DefaultPreference(n.Type)
+	}
+
+	for _, ch := range d.channels {
+		if !pref.Allows(ch.Kind()) {
+			continue
+		}
+		if err := ch.Deliver(ctx, to, n); err != nil {
+			log.Printf("Failed to deliver %s notification to %s via %s: %v", n.Type, to.UserID, ch.Kind(), err)
+		}
+	}
+}
+
+func preferenceFor(ctx context.Context, repo notification.Repository, to notification.Recipient, t notification.Type) (notification.Preference, error) {
+	prefs, err := repo.Preferences(ctx, to.UserID)
+	if err != nil {
+		return notification.Preference{}, err
+	}
+	for _, p := range prefs {
+		if p.Type == t {
+			return p, nil
+		}
+	}
+	return notification.DefaultPreference(t), nil
+}
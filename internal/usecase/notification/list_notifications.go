@@ -0,0 +1,89 @@
+package notification
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/notification"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+type ListNotificationsUseCase struct {
+	repo notification.Repository
+}
+
+func NewListNotificationsUseCase(repo notification.Repository) *ListNotificationsUseCase {
+	return &ListNotificationsUseCase{repo: repo}
+}
+
+type ListNotificationsInput struct {
+	UserID     string
+	UnreadOnly bool
+	Page       int
+	PageSize   int
+}
+
+type ListNotificationsResult struct {
+	Notifications []notification.Notification
+	Total         int
+	Unread        int
+	Page          int
+	PageSize      int
+}
+
+func (uc *ListNotificationsUseCase) Execute(ctx context.Context, input ListNotificationsInput) (*ListNotificationsResult, error) {
+	userID, err := uuid.Parse(input.UserID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+	if input.Page < 1 {
+		input.Page = 1
+	}
+	if input.PageSize < 1 {
+		input.PageSize = defaultPageSize
+	}
+	if input.PageSize > maxPageSize {
+		input.PageSize = maxPageSize
+	}
+
+	items, total, err := uc.repo.List(ctx, userID, input.UnreadOnly, (input.Page-1)*input.PageSize, input.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	unread, err := uc.repo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []notification.Notification{}
+	}
+
+	return &ListNotificationsResult{
+		Notifications: items,
+		Total:         total,
+		Unread:        unread,
+		Page:          input.Page,
+		PageSize:      input.PageSize,
+	}, nil
+}
+
+type CountUnreadUseCase struct {
+	repo notification.Repository
+}
+
+func NewCountUnreadUseCase(repo notification.Repository) *CountUnreadUseCase {
+	return &CountUnreadUseCase{repo: repo}
+}
+
+func (uc *CountUnreadUseCase) Execute(ctx context.Context, userID string) (int, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return 0, errors.New("invalid user ID format")
+	}
+	return uc.repo.CountUnread(ctx, uid)
+}
@@ -0,0 +1,46 @@
+package notification
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/notification"
+)
+
+type MarkReadUseCase struct {
+	repo notification.Repository
+}
+
+func NewMarkReadUseCase(repo notification.Repository) *MarkReadUseCase {
+	return &MarkReadUseCase{repo: repo}
+}
+
+func (uc *MarkReadUseCase) Execute(ctx context.Context, userID, notificationID string) error {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return errors.New("invalid user ID format")
+	}
+	id, err := uuid.Parse(notificationID)
+	if err != nil {
+		return errors.New("invalid notification ID format")
+	}
+	return uc.repo.MarkRead(ctx, uid, id)
+}
+
+type MarkAllReadUseCase struct {
+	repo notification.Repository
+}
+
+func NewMarkAllReadUseCase(repo notification.Repository) *MarkAllReadUseCase {
+	return &MarkAllReadUseCase{repo: repo}
+}
+
+// Execute marks every notification of the user as read and returns how many were unread
+func (uc *MarkAllReadUseCase) Execute(ctx context.Context, userID string) (int, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return 0, errors.New("invalid user ID format")
+	}
+	return uc.repo.MarkAllRead(ctx, uid)
+}
@@ -0,0 +1,87 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/notification"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+)
+
+// NotifyDueSoonUseCase reminds assignees of unfinished tasks that are due within the lead time.
+// It is meant to run periodically; each assignee hears about a due date once.
+type NotifyDueSoonUseCase struct {
+	projects project.Repository
+	members  project.MemberRepository
+	repo     notification.Repository
+	notifier notification.Notifier
+	lead     time.Duration
+}
+
+func NewNotifyDueSoonUseCase(
+	projects project.Repository,
+	members project.MemberRepository,
+	repo notification.Repository,
+	notifier notification.Notifier,
+	lead time.Duration,
+) *NotifyDueSoonUseCase {
+	return &NotifyDueSoonUseCase{projects: projects, members: members, repo: repo, notifier: notifier, lead: lead}
+}
+
+func (uc *NotifyDueSoonUseCase) Execute(ctx context.Context) error {
+	now := time.Now()
+	tasks, err := uc.projects.FindOpenTasksDueBetween(ctx, now, now.Add(uc.lead))
+	if err != nil {
+		return err
+	}
+
+	sent := 0
+	members := make(map[uuid.UUID]map[string]project.Member)
+	for _, t := range tasks {
+		projectMembers, ok := members[t.ProjectID]
+		if !ok {
+			list, err := uc.members.ListMembers(ctx, t.ProjectID)
+			if err != nil {
+				return err
+			}
+			projectMembers = make(map[string]project.Member, len(list))
+			for _, m := range list {
+				projectMembers[m.UserID.String()] = m
+			}
+			members[t.ProjectID] = projectMembers
+		}
+
+		for _, id := range t.AssigneeIDs {
+			m, ok := projectMembers[id]
+			if !ok {
+				continue
+			}
+			claimed, err := uc.repo.ClaimDueSoon(ctx, m.UserID, t.ProjectID, t.ID, *t.DueDate)
+			if err != nil {
+				return err
+			}
+			if !claimed {
+				continue
+			}
+
+			projectID := t.ProjectID
+			uc.notifier.Notify(ctx, notification.Recipient{UserID: m.UserID, Email: m.Email}, &notification.Notification{
+				Type:      notification.TypeTaskDueSoon,
+				Title:     fmt.Sprintf("%q is due soon", t.Title),
+				Body:      fmt.Sprintf("%q in %s is due on %s.", t.Title, t.ProjectName, t.DueDate.UTC().Format(time.RFC1123)),
+				ProjectID: &projectID,
+				TaskID:    t.ID,
+				Data:      notification.Data{ProjectName: t.ProjectName, TaskTitle: t.Title},
+			})
+			sent++
+		}
+	}
+
+	if sent > 0 {
+		log.Printf("Sent %d due-soon reminders", sent)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package notification
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/notification"
+)
+
+type GetPreferencesUseCase struct {
+	repo notification.Repository
+}
+
+func NewGetPreferencesUseCase(repo notification.Repository) *GetPreferencesUseCase {
+	return &GetPreferencesUseCase{repo: repo}
+}
+
+// Execute returns a preference for every notification type, filling in defaults
+func (uc *GetPreferencesUseCase) Execute(ctx context.Context, userID string) ([]notification.Preference, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+	return allPreferences(ctx, uc.repo, uid)
+}
+
+type UpdatePreferencesUseCase struct {
+	repo notification.Repository
+}
+
+func NewUpdatePreferencesUseCase(repo notification.Repository) *UpdatePreferencesUseCase {
+	return &UpdatePreferencesUseCase{repo: repo}
+}
+
+// Execute saves the given preferences; types left out keep their current setting
+func (uc *UpdatePreferencesUseCase) Execute(ctx context.Context, userID string, prefs []notification.Preference) ([]notification.Preference, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+	seen := make(map[notification.Type]bool, len(prefs))
+	for _, p := range prefs {
+		if !p.Type.IsValid() {
+			return nil, errors.New("unknown notification type: " + string(p.Type))
+		}
+		if seen[p.Type] {
+			return nil, errors.New("duplicate notification type: " + string(p.Type))
+		}
+		seen[p.Type] = true
+	}
+
+	if err := uc.repo.SavePreferences(ctx, uid, prefs); err != nil {
+		return nil, err
+	}
+	return allPreferences(ctx, uc.repo, uid)
+}
+
+func allPreferences(ctx context.Context, repo notification.Repository, userID uuid.UUID) ([]notification.Preference, error) {
+	stored, err := repo.Preferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	byType := make(map[notification.Type]notification.Preference, len(stored))
+	for _, p := range stored {
+		byType[p.Type] = p
+	}
+
+	prefs := make([]notification.Preference, len(notification.Types))
+	for i, t := range notification.Types {
+		if p, ok := byType[t]; ok {
+			prefs[i] = p
+		} else {
+			prefs[i] = notification.DefaultPreference(t)
+		}
+	}
+	return prefs, nil
+}
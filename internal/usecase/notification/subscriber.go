@@ -0,0 +1,247 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/notification"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+)
+
+const mentionExcerptLength = 200
+
+// Subscriber notifies people about project changes that concern them: being assigned to a
+// task, mentioned in a comment, or given, changed or removed from access to a project
+type Subscriber struct {
+	notifier notification.Notifier
+	projects project.Repository
+	members  project.MemberRepository
+}
+
+func NewSubscriber(notifier notification.Notifier, projects project.Repository, members project.MemberRepository) *Subscriber {
+	return &Subscriber{notifier: notifier, projects: projects, members: members}
+}
+
+// Subscribe registers the subscriber on the bus. Like emails, notifications are sent in the
+// background once the change is saved.
+func (s *Subscriber) Subscribe(bus event.Bus) {
+	event.OnAsync(bus, s.taskAdded)
+	event.OnAsync(bus, s.taskUpdated)
+	event.OnAsync(bus, s.memberAdded)
+	event.OnAsync(bus, s.memberRoleChanged)
+	event.OnAsync(bus, s.memberRemoved)
+	event.OnAsync(bus, s.commentAdded)
+	event.OnAsync(bus, s.commentEdited)
+}
+
+func (s *Subscriber) taskAdded(ctx context.Context, e event.TaskAdded) error {
+	// Assignees of a recurring task heard about it when they were assigned to the series
+	if e.Occurrence {
+		return nil
+	}
+	return s.notifyAssigned(ctx, e.ProjectChange, nil, e.Task)
+}
+
+func (s *Subscriber) taskUpdated(ctx context.Context, e event.TaskUpdated) error {
+	return s.notifyAssigned(ctx, e.ProjectChange, e.Previous.AssigneeIDs, e.Task)
+}
+
+// notifyAssigned tells users they were newly assigned to a task. Assigning yourself needs no notice.
+func (s *Subscriber) notifyAssigned(ctx context.Context, change event.ProjectChange, before []string, task project.Task) error {
+	assigned := added(task.AssigneeIDs, before, change.ActorID)
+	if len(assigned) == 0 {
+		return nil
+	}
+
+	members, err := s.memberIndex(ctx, change.ProjectID)
+	if err != nil {
+		return err
+	}
+	projectName, err := s.projectName(ctx, change)
+	if err != nil {
+		return err
+	}
+	assignerName := "Someone"
+	if assigner, ok := members[change.ActorID]; ok {
+		assignerName = memberName(assigner)
+	}
+
+	for _, id := range assigned {
+		m, ok := members[id]
+		if !ok {
+			continue
+		}
+		s.notifier.Notify(ctx, recipient(m), &notification.Notification{
+			Type:      notification.TypeTaskAssigned,
+			Title:     fmt.Sprintf("%s assigned you to %q", assignerName, task.Title),
+			Body:      fmt.Sprintf("You were assigned to %q in %s.", task.Title, projectName),
+			ProjectID: &change.ProjectID,
+			TaskID:    task.ID,
+			Data:      notification.Data{ActorName: assignerName, ProjectName: projectName, TaskTitle: task.Title},
+		})
+	}
+	return nil
+}
+
+func (s *Subscriber) memberAdded(ctx context.Context, e event.MemberAdded) error {
+	s.notifyMembership(ctx, e.ProjectChange, e.Member, e.ProjectName, e.ActorName,
+		fmt.Sprintf("%s added you to %s", e.ActorName, e.ProjectName),
+		fmt.Sprintf("You now have %s access to the project %s.", e.Member.Role, e.ProjectName))
+	return nil
+}
+
+func (s *Subscriber) memberRoleChanged(ctx context.Context, e event.MemberRoleChanged) error {
+	s.notifyMembership(ctx, e.ProjectChange, e.Member, e.ProjectName, e.ActorName,
+		fmt.Sprintf("Your role in %s changed", e.ProjectName),
+		fmt.Sprintf("%s made you %s of the project %s.", e.ActorName, articled(e.Member.Role), e.ProjectName))
+	return nil
+}
+
+func (s *Subscriber) memberRemoved(ctx context.Context, e event.MemberRemoved) error {
+	s.notifyMembership(ctx, e.ProjectChange, e.Member, e.ProjectName, e.ActorName,
+		fmt.Sprintf("%s removed you from %s", e.ActorName, e.ProjectName),
+		fmt.Sprintf("You no longer have access to the project %s.", e.ProjectName))
+	return nil
+}
+
+// notifyMembership tells a member about a change to their access made by someone else
+func (s *Subscriber) notifyMembership(ctx context.Context, change event.ProjectChange, m project.Member, projectName, actor, title, body string) {
+	if m.UserID.String() == change.ActorID {
+		return
+	}
+	s.notifier.Notify(ctx, recipient(m), &notification.Notification{
+		Type:      notification.TypeMembership,
+		Title:     title,
+		Body:      body,
+		ProjectID: &change.ProjectID,
+		Data:      notification.Data{ActorName: actor, ProjectName: projectName},
+	})
+}
+
+func (s *Subscriber) commentAdded(ctx context.Context, e event.CommentAdded) error {
+	return s.notifyMentioned(ctx, e.ProjectChange, e.Comment, nil, e.TaskTitle, e.ProjectName)
+}
+
+// commentEdited only notifies the people an edit newly mentions
+func (s *Subscriber) commentEdited(ctx context.Context, e event.CommentEdited) error {
+	return s.notifyMentioned(ctx, e.ProjectChange, e.Comment, e.PreviousMentions, e.TaskTitle, e.ProjectName)
+}
+
+// notifyMentioned tells members mentioned in a comment who were not mentioned before.
+// Authors are never notified about their own comments.
+func (s *Subscriber) notifyMentioned(ctx context.Context, change event.ProjectChange, c project.Comment, before []string, taskTitle, projectName string) error {
+	author := ""
+	if c.AuthorID != nil {
+		author = c.AuthorID.String()
+	}
+	mentioned := added(c.MentionIDs, before, author)
+	if len(mentioned) == 0 {
+		return nil
+	}
+
+	members, err := s.memberIndex(ctx, change.ProjectID)
+	if err != nil {
+		return err
+	}
+	authorName := c.AuthorName
+	if authorName == "" {
+		authorName = "Someone"
+	}
+	excerpt := commentExcerpt(c.Body)
+
+	for _, id := range mentioned {
+		m, ok := members[id]
+		if !ok {
+			continue
+		}
+		s.notifier.Notify(ctx, recipient(m), &notification.Notification{
+			Type:      notification.TypeMention,
+			Title:     fmt.Sprintf("%s mentioned you on %q", authorName, taskTitle),
+			Body:      excerpt,
+			ProjectID: &change.ProjectID,
+			TaskID:    c.TaskID,
+			Data: notification.Data{
+				ActorName:   authorName,
+				ProjectName: projectName,
+				TaskTitle:   taskTitle,
+				Excerpt:     excerpt,
+			},
+		})
+	}
+	return nil
+}
+
+// memberIndex maps the user IDs of a project's members to their membership
+func (s *Subscriber) memberIndex(ctx context.Context, projectID uuid.UUID) (map[string]project.Member, error) {
+	list, err := s.members.ListMembers(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[string]project.Member, len(list))
+	for _, m := range list {
+		index[m.UserID.String()] = m
+	}
+	return index, nil
+}
+
+// projectName looks the project up as the member who changed it
+func (s *Subscriber) projectName(ctx context.Context, change event.ProjectChange) (string, error) {
+	actorID, err := uuid.Parse(change.ActorID)
+	if err != nil {
+		return "", err
+	}
+	p, err := s.projects.FindByID(ctx, change.ProjectID, actorID)
+	if err != nil {
+		return "", err
+	}
+	if p == nil {
+		return "", errors.New("project not found")
+	}
+	return p.Name, nil
+}
+
+// added returns the IDs in ids that are not in before, leaving out actorID
+func added(ids, before []string, actorID string) []string {
+	previous := make(map[string]bool, len(before))
+	for _, id := range before {
+		previous[id] = true
+	}
+	var result []string
+	for _, id := range ids {
+		if !previous[id] && id != actorID {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+func recipient(m project.Member) notification.Recipient {
+	return notification.Recipient{UserID: m.UserID, Email: m.Email}
+}
+
+func memberName(m project.Member) string {
+	if m.DisplayName != "" {
+		return m.DisplayName
+	}
+	return m.Email
+}
+
+func articled(role project.MemberRole) string {
+	if role == project.RoleOwner || role == project.RoleEditor {
+		return "an " + string(role)
+	}
+	return "a " + string(role)
+}
+
+func commentExcerpt(body string) string {
+	if utf8.RuneCountInString(body) <= mentionExcerptLength {
+		return body
+	}
+	runes := []rune(body)
+	return strings.TrimSpace(string(runes[:mentionExcerptLength])) + "…"
+}
@@ -0,0 +1,18 @@
+package project
+
+import "github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+
+// requireEditor checks the caller's role on a project loaded with Repository.FindByID
+func requireEditor(p *project.Project) error {
+	if !p.Role.CanEdit() {
+		return project.ErrForbidden
+	}
+	return nil
+}
+
+func requireOwner(p *project.Project) error {
+	if !p.Role.CanManage() {
+		return project.ErrForbidden
+	}
+	return nil
+}
@@ -6,15 +6,17 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
 	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
 )
 
 type AddDocumentUseCase struct {
-	repo project.Repository
+	repo   project.Repository
+	events event.Publisher
 }
 
-func NewAddDocumentUseCase(repo project.Repository) *AddDocumentUseCase {
-	return &AddDocumentUseCase{repo: repo}
+func NewAddDocumentUseCase(repo project.Repository, events event.Publisher) *AddDocumentUseCase {
+	return &AddDocumentUseCase{repo: repo, events: events}
 }
 
 type AddDocumentInput struct {
@@ -46,6 +48,9 @@ func (uc *AddDocumentUseCase) Execute(ctx context.Context, input AddDocumentInpu
 	if err != nil || p == nil {
 		return nil, errors.New("project not found")
 	}
+	if err := requireEditor(p); err != nil {
+		return nil, err
+	}
 
 	now := time.Now()
 	newDoc := project.Document{
@@ -60,8 +65,9 @@ func (uc *AddDocumentUseCase) Execute(ctx context.Context, input AddDocumentInpu
 	}
 	p.Documents = append(p.Documents, newDoc)
 
-	if err := uc.repo.Update(ctx, p); err != nil {
+	if err := uc.repo.Update(ctx, p, userID); err != nil {
 		return nil, err
 	}
+	uc.events.Publish(ctx, event.DocumentAdded{ProjectChange: event.ChangeBy(p.ID, userID), Document: newDoc})
 	return p, nil
 }
@@ -6,24 +6,53 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/label"
 	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/workspace"
 )
 
 type AddTaskUseCase struct {
-	repo project.Repository
+	repo        project.Repository
+	workspaces  workspace.Repository
+	members     project.MemberRepository
+	labels      label.Repository
+	preferences user.PreferencesReader
+	events      event.Publisher
 }
 
-func NewAddTaskUseCase(repo project.Repository) *AddTaskUseCase {
-	return &AddTaskUseCase{repo: repo}
+func NewAddTaskUseCase(
+	repo project.Repository,
+	workspaces workspace.Repository,
+	members project.MemberRepository,
+	labels label.Repository,
+	preferences user.PreferencesReader,
+	events event.Publisher,
+) *AddTaskUseCase {
+	return &AddTaskUseCase{
+		repo:        repo,
+		workspaces:  workspaces,
+		members:     members,
+		labels:      labels,
+		preferences: preferences,
+		events:      events,
+	}
 }
 
 type AddTaskInput struct {
-	ProjectID string
-	UserID    string
-	Title     string
-	Status    project.TaskStatus
-	Priority  project.TaskPriority
-	DueDate   *time.Time
+	ProjectID     string
+	UserID        string
+	ParentID      string // makes the task a subtask of another task
+	Title         string
+	Status        project.TaskStatus
+	Priority      project.TaskPriority
+	DueDate       *time.Time
+	EstimateHours float64
+	StoryPoints   float64
+	AssigneeIDs   []string // user IDs of project members
+	LabelIDs      []string // labels of the project's workspace
+	Recurrence    *RecurrenceInput
 }
 
 func (uc *AddTaskUseCase) Execute(ctx context.Context, input AddTaskInput) (*project.Project, error) {
@@ -33,6 +62,12 @@ func (uc *AddTaskUseCase) Execute(ctx context.Context, input AddTaskInput) (*pro
 	if input.Title == "" {
 		return nil, errors.New("task title is required")
 	}
+	if err := validateEstimate(input.EstimateHours); err != nil {
+		return nil, err
+	}
+	if err := validateStoryPoints(input.StoryPoints); err != nil {
+		return nil, err
+	}
 
 	projectID, err := uuid.Parse(input.ProjectID)
 	if err != nil {
@@ -47,22 +82,72 @@ func (uc *AddTaskUseCase) Execute(ctx context.Context, input AddTaskInput) (*pro
 	if err != nil || p == nil {
 		return nil, errors.New("project not found")
 	}
+	if err := requireEditor(p); err != nil {
+		return nil, err
+	}
+
+	// Tasks without a status start in the workflow's first status
+	settings, err := uc.workspaces.SettingsFor(ctx, p.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if input.Status == "" {
+		input.Status = p.Workflow.Initial()
+	}
+	if !p.Workflow.Allows(input.Status) {
+		return nil, project.ErrUnknownStatus
+	}
+
+	if err := project.CheckParent(p.Tasks, "", input.ParentID); err != nil {
+		return nil, err
+	}
+
+	members, err := memberIndex(ctx, uc.members, p.ID)
+	if err != nil {
+		return nil, err
+	}
+	assignees, err := validateAssignees(input.AssigneeIDs, members)
+	if err != nil {
+		return nil, err
+	}
+	labelIDs, err := validateLabels(ctx, uc.labels, p.WorkspaceID, input.LabelIDs)
+	if err != nil {
+		return nil, err
+	}
 
 	newTask := project.Task{
-		ID:       uuid.New().String(),
-		Title:    input.Title,
-		Status:   input.Status,
-		Priority: input.Priority,
-		DueDate:  input.DueDate,
+		ID:            uuid.New().String(),
+		ParentID:      input.ParentID,
+		Title:         input.Title,
+		Status:        input.Status,
+		Priority:      input.Priority,
+		DueDate:       input.DueDate,
+		EstimateHours: input.EstimateHours,
+		StoryPoints:   input.StoryPoints,
+		AssigneeIDs:   assignees,
+		LabelIDs:      labelIDs,
+	}
+	if err := p.Workflow.CheckWIP(p.Tasks, &newTask); err != nil {
+		return nil, err
+	}
+	if input.Recurrence != nil {
+		if newTask.Recurrence, err = newRecurrence(ctx, uc.preferences, input.UserID, input.Recurrence, &newTask); err != nil {
+			return nil, err
+		}
 	}
 	if p.Tasks == nil {
 		p.Tasks = []project.Task{}
 	}
 	p.Tasks = append(p.Tasks, newTask)
-	p.Progress = project.ProgressFromTasks(p.Tasks)
+	parents := completeParents(p, settings, newTask.ParentID)
+	p.Progress = project.ProgressFromTasks(p.Tasks, p.Workflow, p.ProgressSettings)
 
-	if err := uc.repo.Update(ctx, p); err != nil {
+	if err := uc.repo.Update(ctx, p, userID); err != nil {
 		return nil, err
 	}
+
+	change := event.ChangeBy(p.ID, userID)
+	uc.events.Publish(ctx, append([]event.Event{event.TaskAdded{ProjectChange: change, Task: newTask}},
+		taskUpdates(change, p, parents)...)...)
 	return p, nil
 }
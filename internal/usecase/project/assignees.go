@@ -0,0 +1,48 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+)
+
+// memberIndex maps the user IDs of a project's members to their membership
+func memberIndex(ctx context.Context, members project.MemberRepository, projectID uuid.UUID) (map[string]project.Member, error) {
+	list, err := members.ListMembers(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[string]project.Member, len(list))
+	for _, m := range list {
+		index[m.UserID.String()] = m
+	}
+	return index, nil
+}
+
+// validateAssignees checks every assignee is a project member and drops duplicates
+func validateAssignees(ids []string, members map[string]project.Member) ([]string, error) {
+	seen := make(map[string]bool, len(ids))
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		id = strings.ToLower(strings.TrimSpace(id))
+		if seen[id] {
+			continue
+		}
+		if _, ok := members[id]; !ok {
+			return nil, errors.New("assignee " + id + " is not a member of this project")
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result, nil
+}
+
+func memberName(m project.Member) string {
+	if m.DisplayName != "" {
+		return m.DisplayName
+	}
+	return m.Email
+}
@@ -0,0 +1,106 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/workspace"
+)
+
+type MoveTaskUseCase struct {
+	repo       project.Repository
+	workspaces workspace.Repository
+	events     event.Publisher
+}
+
+func NewMoveTaskUseCase(repo project.Repository, workspaces workspace.Repository, events event.Publisher) *MoveTaskUseCase {
+	return &MoveTaskUseCase{repo: repo, workspaces: workspaces, events: events}
+}
+
+type MoveTaskInput struct {
+	ProjectID string
+	UserID    string
+	TaskID    string
+	Status    project.TaskStatus // the board column to move to, which may be the current one
+	Position  int                // position within the column counted from zero, past the end puts the task last
+	Force     bool               // changes the status even when dependencies do not allow it
+}
+
+// moveAttempts is how often a move is tried on a freshly loaded project when someone else
+// saved the project in between
+const moveAttempts = 3
+
+// Execute moves a task on the project's board: it changes the task's status and its position
+// within the new column in one save. Status changes follow the same rules as task updates,
+// including WIP limits. Tasks in other columns keep their order. A move says where the task
+// should end up, so when the project changed in the meantime it is simply made again on the
+// saved project.
+func (uc *MoveTaskUseCase) Execute(ctx context.Context, input MoveTaskInput) (*project.Project, error) {
+	var err error
+	for range moveAttempts {
+		var p *project.Project
+		p, err = uc.move(ctx, input)
+		if !errors.Is(err, project.ErrConflict) {
+			return p, err
+		}
+	}
+	return nil, err
+}
+
+func (uc *MoveTaskUseCase) move(ctx context.Context, input MoveTaskInput) (*project.Project, error) {
+	p, err := loadProject(ctx, uc.repo, input.ProjectID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireEditor(p); err != nil {
+		return nil, err
+	}
+	settings, err := uc.workspaces.SettingsFor(ctx, p.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	task := findTask(p, input.TaskID)
+	if task == nil {
+		return nil, errors.New("task not found")
+	}
+
+	previous := *task
+	order := taskOrder(p)
+	if err := changeStatus(p, settings, task, input.Status, input.Force); err != nil {
+		return nil, err
+	}
+	project.PlaceInColumn(p.Tasks, input.TaskID, input.Position)
+	reordered := taskOrder(p)
+
+	var changed, added []project.Task
+	if input.Status != previous.Status {
+		changed = append([]project.Task{previous}, completeParents(p, settings, previous.ParentID)...)
+		added = spawnOccurrences(p, changed)
+	}
+	p.Progress = project.ProgressFromTasks(p.Tasks, p.Workflow, p.ProgressSettings)
+
+	actorID, _ := uuid.Parse(input.UserID)
+	if err := uc.repo.Update(ctx, p, actorID); err != nil {
+		return nil, err
+	}
+
+	change := event.ChangeBy(p.ID, actorID)
+	published := taskUpdates(change, p, changed)
+	if !slices.Equal(reordered, order) {
+		published = append(published, event.TasksReordered{ProjectChange: change, TaskIDs: reordered, Previous: order})
+	}
+	uc.events.Publish(ctx, append(published, occurrencesAdded(change, added)...)...)
+	return p, nil
+}
+
+func taskOrder(p *project.Project) []string {
+	order := make([]string, len(p.Tasks))
+	for i, t := range p.Tasks {
+		order[i] = t.ID
+	}
+	return order
+}
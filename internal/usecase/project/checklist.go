@@ -0,0 +1,197 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+)
+
+const (
+	maxChecklistItems      = 100
+	maxChecklistItemLength = 500
+)
+
+var errChecklistItemNotFound = errors.New("checklist item not found")
+
+type AddChecklistItemUseCase struct {
+	repo   project.Repository
+	events event.Publisher
+}
+
+func NewAddChecklistItemUseCase(repo project.Repository, events event.Publisher) *AddChecklistItemUseCase {
+	return &AddChecklistItemUseCase{repo: repo, events: events}
+}
+
+// Execute adds an item to the end of a task's checklist
+func (uc *AddChecklistItemUseCase) Execute(ctx context.Context, projectID, userID, taskID, text string) (*project.Project, error) {
+	text, err := validateChecklistText(text)
+	if err != nil {
+		return nil, err
+	}
+	return editTask(ctx, uc.repo, uc.events, projectID, userID, taskID, func(_ *project.Project, t *project.Task) error {
+		if len(t.Checklist) >= maxChecklistItems {
+			return errors.New("checklist is full")
+		}
+		t.Checklist = append(t.Checklist, project.ChecklistItem{ID: uuid.New().String(), Text: text})
+		return nil
+	})
+}
+
+type UpdateChecklistItemUseCase struct {
+	repo   project.Repository
+	events event.Publisher
+}
+
+func NewUpdateChecklistItemUseCase(repo project.Repository, events event.Publisher) *UpdateChecklistItemUseCase {
+	return &UpdateChecklistItemUseCase{repo: repo, events: events}
+}
+
+type UpdateChecklistItemInput struct {
+	ProjectID string
+	UserID    string
+	TaskID    string
+	ItemID    string
+	Text      *string
+	Done      *bool
+}
+
+// Execute renames or checks off a checklist item
+func (uc *UpdateChecklistItemUseCase) Execute(ctx context.Context, input UpdateChecklistItemInput) (*project.Project, error) {
+	var text string
+	if input.Text != nil {
+		var err error
+		if text, err = validateChecklistText(*input.Text); err != nil {
+			return nil, err
+		}
+	}
+	return editTask(ctx, uc.repo, uc.events, input.ProjectID, input.UserID, input.TaskID, func(_ *project.Project, t *project.Task) error {
+		item := findChecklistItem(t, input.ItemID)
+		if item == nil {
+			return errChecklistItemNotFound
+		}
+		if input.Text != nil {
+			item.Text = text
+		}
+		if input.Done != nil {
+			item.Done = *input.Done
+		}
+		return nil
+	})
+}
+
+type DeleteChecklistItemUseCase struct {
+	repo   project.Repository
+	events event.Publisher
+}
+
+func NewDeleteChecklistItemUseCase(repo project.Repository, events event.Publisher) *DeleteChecklistItemUseCase {
+	return &DeleteChecklistItemUseCase{repo: repo, events: events}
+}
+
+func (uc *DeleteChecklistItemUseCase) Execute(ctx context.Context, projectID, userID, taskID, itemID string) (*project.Project, error) {
+	return editTask(ctx, uc.repo, uc.events, projectID, userID, taskID, func(_ *project.Project, t *project.Task) error {
+		if findChecklistItem(t, itemID) == nil {
+			return errChecklistItemNotFound
+		}
+		kept := make([]project.ChecklistItem, 0, len(t.Checklist)-1)
+		for _, item := range t.Checklist {
+			if item.ID != itemID {
+				kept = append(kept, item)
+			}
+		}
+		t.Checklist = kept
+		return nil
+	})
+}
+
+type ReorderChecklistUseCase struct {
+	repo   project.Repository
+	events event.Publisher
+}
+
+func NewReorderChecklistUseCase(repo project.Repository, events event.Publisher) *ReorderChecklistUseCase {
+	return &ReorderChecklistUseCase{repo: repo, events: events}
+}
+
+// Execute reorders a task's checklist to match itemIDs. Items not listed keep their order
+// after the listed ones.
+func (uc *ReorderChecklistUseCase) Execute(ctx context.Context, projectID, userID, taskID string, itemIDs []string) (*project.Project, error) {
+	return editTask(ctx, uc.repo, uc.events, projectID, userID, taskID, func(_ *project.Project, t *project.Task) error {
+		ordered := make([]project.ChecklistItem, 0, len(t.Checklist))
+		placed := make(map[string]bool, len(t.Checklist))
+		for _, id := range itemIDs {
+			if item := findChecklistItem(t, id); item != nil && !placed[id] {
+				ordered = append(ordered, *item)
+				placed[id] = true
+			}
+		}
+		for _, item := range t.Checklist {
+			if !placed[item.ID] {
+				ordered = append(ordered, item)
+			}
+		}
+		t.Checklist = ordered
+		return nil
+	})
+}
+
+// editTask applies a change to one task of a project for an editor, saves it and announces it
+func editTask(
+	ctx context.Context,
+	repo project.Repository,
+	events event.Publisher,
+	projectID, userID, taskID string,
+	change func(p *project.Project, t *project.Task) error,
+) (*project.Project, error) {
+	p, err := loadProject(ctx, repo, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireEditor(p); err != nil {
+		return nil, err
+	}
+	task := findTask(p, taskID)
+	if task == nil {
+		return nil, errors.New("task not found")
+	}
+
+	previous := *task
+	previous.Checklist = append([]project.ChecklistItem(nil), task.Checklist...)
+	previous.Dependencies = append([]project.Dependency(nil), task.Dependencies...)
+	if err := change(p, task); err != nil {
+		return nil, err
+	}
+	p.Progress = project.ProgressFromTasks(p.Tasks, p.Workflow, p.ProgressSettings)
+
+	actorID, _ := uuid.Parse(userID)
+	if err := repo.Update(ctx, p, actorID); err != nil {
+		return nil, err
+	}
+	events.Publish(ctx, event.TaskUpdated{ProjectChange: event.ChangeBy(p.ID, actorID), Task: *task, Previous: previous})
+	return p, nil
+}
+
+func findChecklistItem(t *project.Task, itemID string) *project.ChecklistItem {
+	for i := range t.Checklist {
+		if t.Checklist[i].ID == itemID {
+			return &t.Checklist[i]
+		}
+	}
+	return nil
+}
+
+func validateChecklistText(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", errors.New("checklist item text is required")
+	}
+	if utf8.RuneCountInString(text) > maxChecklistItemLength {
+		return "", errors.New("checklist item text is too long")
+	}
+	return text, nil
+}
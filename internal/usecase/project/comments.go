@@ -0,0 +1,357 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	"github.com/tomtom2k/kairo-anchor-server/pkg/mention"
+)
+
+const (
+	defaultCommentPageSize = 20
+	maxCommentPageSize     = 100
+	maxCommentLength       = 10000
+)
+
+type ListCommentsUseCase struct {
+	repo     project.Repository
+	comments project.CommentRepository
+}
+
+func NewListCommentsUseCase(repo project.Repository, comments project.CommentRepository) *ListCommentsUseCase {
+	return &ListCommentsUseCase{repo: repo, comments: comments}
+}
+
+type ListCommentsInput struct {
+	ProjectID string
+	UserID    string
+	TaskID    string
+	Page      int
+	PageSize  int
+}
+
+type ListCommentsResult struct {
+	Threads  []project.Comment
+	Total    int
+	Page     int
+	PageSize int
+}
+
+// Execute returns a page of comment threads on a task, oldest first. Pages count
+// top-level comments; replies always come with their thread.
+func (uc *ListCommentsUseCase) Execute(ctx context.Context, input ListCommentsInput) (*ListCommentsResult, error) {
+	if input.Page < 1 {
+		input.Page = 1
+	}
+	if input.PageSize < 1 {
+		input.PageSize = defaultCommentPageSize
+	}
+	if input.PageSize > maxCommentPageSize {
+		input.PageSize = maxCommentPageSize
+	}
+
+	p, err := loadProject(ctx, uc.repo, input.ProjectID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if findTask(p, input.TaskID) == nil {
+		return nil, errors.New("task not found")
+	}
+
+	threads, total, err := uc.comments.ListThreads(ctx, p.ID, input.TaskID, (input.Page-1)*input.PageSize, input.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	if threads == nil {
+		threads = []project.Comment{}
+	}
+	return &ListCommentsResult{Threads: threads, Total: total, Page: input.Page, PageSize: input.PageSize}, nil
+}
+
+type AddCommentUseCase struct {
+	repo     project.Repository
+	comments project.CommentRepository
+	members  project.MemberRepository
+	events   event.Publisher
+}
+
+func NewAddCommentUseCase(
+	repo project.Repository,
+	comments project.CommentRepository,
+	members project.MemberRepository,
+	events event.Publisher,
+) *AddCommentUseCase {
+	return &AddCommentUseCase{repo: repo, comments: comments, members: members, events: events}
+}
+
+type AddCommentInput struct {
+	ProjectID string
+	UserID    string
+	TaskID    string
+	ParentID  string // comment being replied to, empty starts a new thread
+	Body      string
+}
+
+// Execute comments on a task. Any member may comment, including viewers.
+func (uc *AddCommentUseCase) Execute(ctx context.Context, input AddCommentInput) (*project.Comment, error) {
+	body, err := validateCommentBody(input.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := loadProject(ctx, uc.repo, input.ProjectID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	task := findTask(p, input.TaskID)
+	if task == nil {
+		return nil, errors.New("task not found")
+	}
+	authorID, _ := uuid.Parse(input.UserID)
+
+	var parentID *uuid.UUID
+	if input.ParentID != "" {
+		parent, err := loadComment(ctx, uc.comments, p, task.ID, input.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		// Threads are one level deep, so replying to a reply joins the same thread
+		root := parent.ID
+		if parent.ParentID != nil {
+			root = *parent.ParentID
+		}
+		parentID = &root
+	}
+
+	members, err := memberIndex(ctx, uc.members, p.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &project.Comment{
+		ProjectID:  p.ID,
+		TaskID:     task.ID,
+		ParentID:   parentID,
+		AuthorID:   &authorID,
+		Body:       body,
+		MentionIDs: resolveMentions(body, members),
+	}
+	if author, ok := members[authorID.String()]; ok {
+		c.AuthorName = memberName(author)
+	}
+	if err := uc.comments.CreateComment(ctx, c); err != nil {
+		return nil, err
+	}
+
+	uc.events.Publish(ctx, event.CommentAdded{
+		ProjectChange: event.ChangeBy(p.ID, authorID),
+		Comment:       *c,
+		TaskTitle:     task.Title,
+		ProjectName:   p.Name,
+	})
+	return c, nil
+}
+
+type EditCommentUseCase struct {
+	repo     project.Repository
+	comments project.CommentRepository
+	members  project.MemberRepository
+	events   event.Publisher
+}
+
+func NewEditCommentUseCase(
+	repo project.Repository,
+	comments project.CommentRepository,
+	members project.MemberRepository,
+	events event.Publisher,
+) *EditCommentUseCase {
+	return &EditCommentUseCase{repo: repo, comments: comments, members: members, events: events}
+}
+
+type EditCommentInput struct {
+	ProjectID string
+	UserID    string
+	TaskID    string
+	CommentID string
+	Body      string
+}
+
+// Execute replaces the body of a comment; only its author may edit it. The previous
+// body is kept in the history and only people newly mentioned are notified.
+func (uc *EditCommentUseCase) Execute(ctx context.Context, input EditCommentInput) (*project.Comment, error) {
+	body, err := validateCommentBody(input.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := loadProject(ctx, uc.repo, input.ProjectID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	task := findTask(p, input.TaskID)
+	if task == nil {
+		return nil, errors.New("task not found")
+	}
+	c, err := loadComment(ctx, uc.comments, p, task.ID, input.CommentID)
+	if err != nil {
+		return nil, err
+	}
+	userID, _ := uuid.Parse(input.UserID)
+	if !c.IsAuthoredBy(userID) {
+		return nil, project.ErrForbidden
+	}
+	if c.DeletedAt != nil {
+		return nil, errors.New("deleted comments cannot be edited")
+	}
+	if c.Body == body {
+		return c, nil
+	}
+
+	members, err := memberIndex(ctx, uc.members, p.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	previousBody, previousMentions := c.Body, c.MentionIDs
+	c.Body = body
+	c.MentionIDs = resolveMentions(body, members)
+	if err := uc.comments.UpdateComment(ctx, c, previousBody, userID); err != nil {
+		return nil, err
+	}
+
+	uc.events.Publish(ctx, event.CommentEdited{
+		ProjectChange:    event.ChangeBy(p.ID, userID),
+		Comment:          *c,
+		PreviousMentions: previousMentions,
+		TaskTitle:        task.Title,
+		ProjectName:      p.Name,
+	})
+	return c, nil
+}
+
+type DeleteCommentUseCase struct {
+	repo     project.Repository
+	comments project.CommentRepository
+}
+
+func NewDeleteCommentUseCase(repo project.Repository, comments project.CommentRepository) *DeleteCommentUseCase {
+	return &DeleteCommentUseCase{repo: repo, comments: comments}
+}
+
+// Execute deletes a comment. Authors may delete their own comments and owners may
+// delete any. Replies stay in place under a blanked comment.
+func (uc *DeleteCommentUseCase) Execute(ctx context.Context, projectID, userID, taskID, commentID string) error {
+	p, err := loadProject(ctx, uc.repo, projectID, userID)
+	if err != nil {
+		return err
+	}
+	c, err := loadComment(ctx, uc.comments, p, taskID, commentID)
+	if err != nil {
+		return err
+	}
+	uid, _ := uuid.Parse(userID)
+	if !c.IsAuthoredBy(uid) {
+		if err := requireOwner(p); err != nil {
+			return err
+		}
+	}
+	return uc.comments.DeleteComment(ctx, c.ID)
+}
+
+type ListCommentHistoryUseCase struct {
+	repo     project.Repository
+	comments project.CommentRepository
+}
+
+func NewListCommentHistoryUseCase(repo project.Repository, comments project.CommentRepository) *ListCommentHistoryUseCase {
+	return &ListCommentHistoryUseCase{repo: repo, comments: comments}
+}
+
+// Execute lists the earlier versions of a comment, newest first
+func (uc *ListCommentHistoryUseCase) Execute(ctx context.Context, projectID, userID, taskID, commentID string) ([]project.CommentEdit, error) {
+	p, err := loadProject(ctx, uc.repo, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	c, err := loadComment(ctx, uc.comments, p, taskID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	edits, err := uc.comments.ListEdits(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	if edits == nil {
+		edits = []project.CommentEdit{}
+	}
+	return edits, nil
+}
+
+func findTask(p *project.Project, taskID string) *project.Task {
+	for i := range p.Tasks {
+		if p.Tasks[i].ID == taskID {
+			return &p.Tasks[i]
+		}
+	}
+	return nil
+}
+
+// loadComment finds a comment and makes sure it belongs to the given task
+func loadComment(ctx context.Context, comments project.CommentRepository, p *project.Project, taskID, commentID string) (*project.Comment, error) {
+	id, err := uuid.Parse(commentID)
+	if err != nil {
+		return nil, errors.New("invalid comment ID format")
+	}
+	c, err := comments.FindComment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil || c.ProjectID != p.ID || c.TaskID != taskID {
+		return nil, project.ErrCommentNotFound
+	}
+	return c, nil
+}
+
+func validateCommentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", errors.New("comment body is required")
+	}
+	if utf8.RuneCountInString(body) > maxCommentLength {
+		return "", errors.New("comment body is too long")
+	}
+	return body, nil
+}
+
+// resolveMentions maps the @handles in a body to project members. A handle matches
+// a member's email, the local part of their email, or their display name without spaces.
+func resolveMentions(body string, members map[string]project.Member) []string {
+	ids := []string{}
+	seen := make(map[string]bool)
+	for _, handle := range mention.Parse(body) {
+		for id, m := range members {
+			if seen[id] || !mentionsMember(handle, m) {
+				continue
+			}
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func mentionsMember(handle string, m project.Member) bool {
+	if mention.IsEmail(handle) {
+		return strings.EqualFold(handle, m.Email)
+	}
+	if local, _, ok := strings.Cut(m.Email, "@"); ok && strings.EqualFold(handle, local) {
+		return true
+	}
+	name := strings.Join(strings.Fields(m.DisplayName), "")
+	return name != "" && strings.EqualFold(handle, name)
+}
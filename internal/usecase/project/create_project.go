@@ -6,18 +6,25 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/label"
 	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/workspace"
 )
 
 type CreateProjectUseCase struct {
-	repo project.Repository
+	repo       project.Repository
+	workspaces workspace.Repository
+	labels     label.Repository
+	events     event.Publisher
 }
 
-func NewCreateProjectUseCase(repo project.Repository) *CreateProjectUseCase {
-	return &CreateProjectUseCase{repo: repo}
+func NewCreateProjectUseCase(repo project.Repository, workspaces workspace.Repository, labels label.Repository, events event.Publisher) *CreateProjectUseCase {
+	return &CreateProjectUseCase{repo: repo, workspaces: workspaces, labels: labels, events: events}
 }
 
 type CreateProjectInput struct {
+	WorkspaceID string             `json:"workspaceId"`
 	UserID      string             `json:"userId"`
 	Name        string             `json:"name" binding:"required"`
 	Description string             `json:"description"`
@@ -25,6 +32,7 @@ type CreateProjectInput struct {
 	Progress    int                `json:"progress" binding:"min=0,max=100"`
 	StartDate   time.Time          `json:"startDate" binding:"required"`
 	EndDate     *time.Time         `json:"endDate"`
+	LabelIDs    []string           `json:"labelIds"`
 }
 
 func (uc *CreateProjectUseCase) Execute(ctx context.Context, input CreateProjectInput) (*project.Project, error) {
@@ -41,8 +49,29 @@ func (uc *CreateProjectUseCase) Execute(ctx context.Context, input CreateProject
 	if err != nil {
 		return nil, errors.New("invalid user ID format")
 	}
+	workspaceID, err := uuid.Parse(input.WorkspaceID)
+	if err != nil {
+		return nil, errors.New("invalid workspace ID format")
+	}
+
+	w, err := uc.workspaces.FindByID(ctx, workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if w == nil {
+		return nil, workspace.ErrNotMember
+	}
+	if !w.CanCreateProjects() {
+		return nil, workspace.ErrForbidden
+	}
+
+	labelIDs, err := validateLabels(ctx, uc.labels, w.ID, input.LabelIDs)
+	if err != nil {
+		return nil, err
+	}
 
 	p := &project.Project{
+		WorkspaceID: w.ID,
 		UserID:      userID,
 		Name:        input.Name,
 		Description: input.Description,
@@ -52,11 +81,15 @@ func (uc *CreateProjectUseCase) Execute(ctx context.Context, input CreateProject
 		EndDate:     input.EndDate,
 		Tasks:       []project.Task{},
 		Documents:   []project.Document{},
+		LabelIDs:    labelIDs,
+		Workflow:    w.Settings.Workflow(),
+		ProgressSettings: project.ProgressSettings{Mode: project.ProgressByCount},
 	}
 
 	if err := uc.repo.Create(ctx, p); err != nil {
 		return nil, err
 	}
+	uc.events.Publish(ctx, event.ProjectCreated{ProjectChange: event.ChangeBy(p.ID, userID), Project: event.SummarizeProject(p)})
 
 	return p, nil
 }
@@ -5,15 +5,17 @@ import (
 	"errors"
 
 	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
 	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
 )
 
 type DeleteDocumentUseCase struct {
-	repo project.Repository
+	repo   project.Repository
+	events event.Publisher
 }
 
-func NewDeleteDocumentUseCase(repo project.Repository) *DeleteDocumentUseCase {
-	return &DeleteDocumentUseCase{repo: repo}
+func NewDeleteDocumentUseCase(repo project.Repository, events event.Publisher) *DeleteDocumentUseCase {
+	return &DeleteDocumentUseCase{repo: repo, events: events}
 }
 
 func (uc *DeleteDocumentUseCase) Execute(ctx context.Context, projectIDStr, userIDStr, documentID string) (*project.Project, error) {
@@ -34,17 +36,26 @@ func (uc *DeleteDocumentUseCase) Execute(ctx context.Context, projectIDStr, user
 	if err != nil || p == nil {
 		return nil, errors.New("project not found")
 	}
+	if err := requireEditor(p); err != nil {
+		return nil, err
+	}
 
+	var deleted *project.Document
 	newDocs := make([]project.Document, 0, len(p.Documents))
 	for _, d := range p.Documents {
 		if d.ID != documentID {
 			newDocs = append(newDocs, d)
+		} else {
+			deleted = &d
 		}
 	}
 	p.Documents = newDocs
 
-	if err := uc.repo.Update(ctx, p); err != nil {
+	if err := uc.repo.Update(ctx, p, userID); err != nil {
 		return nil, err
 	}
+	if deleted != nil {
+		uc.events.Publish(ctx, event.DocumentDeleted{ProjectChange: event.ChangeBy(p.ID, userID), Document: *deleted})
+	}
 	return p, nil
 }
@@ -5,15 +5,17 @@ import (
 	"errors"
 
 	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
 	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
 )
 
 type DeleteProjectUseCase struct {
-	repo project.Repository
+	repo   project.Repository
+	events event.Publisher
 }
 
-func NewDeleteProjectUseCase(repo project.Repository) *DeleteProjectUseCase {
-	return &DeleteProjectUseCase{repo: repo}
+func NewDeleteProjectUseCase(repo project.Repository, events event.Publisher) *DeleteProjectUseCase {
+	return &DeleteProjectUseCase{repo: repo, events: events}
 }
 
 func (uc *DeleteProjectUseCase) Execute(ctx context.Context, id string, userID string) error {
@@ -32,5 +34,20 @@ func (uc *DeleteProjectUseCase) Execute(ctx context.Context, id string, userID s
 		return errors.New("invalid user ID format")
 	}
 
-	return uc.repo.Delete(ctx, projectID, userUUID)
+	p, err := uc.repo.FindByID(ctx, projectID, userUUID)
+	if err != nil {
+		return err
+	}
+	if p == nil {
+		return errors.New("project not found")
+	}
+	if err := requireOwner(p); err != nil {
+		return err
+	}
+
+	if err := uc.repo.Delete(ctx, projectID, userUUID); err != nil {
+		return err
+	}
+	uc.events.Publish(ctx, event.ProjectDeleted{ProjectChange: event.ChangeBy(p.ID, userUUID), Project: event.SummarizeProject(p)})
+	return nil
 }
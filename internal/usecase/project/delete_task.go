@@ -5,17 +5,29 @@ import (
 	"errors"
 
 	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
 	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/workspace"
 )
 
 type DeleteTaskUseCase struct {
-	repo project.Repository
+	repo       project.Repository
+	workspaces workspace.Repository
+	comments   project.CommentRepository
+	events     event.Publisher
 }
 
-func NewDeleteTaskUseCase(repo project.Repository) *DeleteTaskUseCase {
-	return &DeleteTaskUseCase{repo: repo}
+func NewDeleteTaskUseCase(
+	repo project.Repository,
+	workspaces workspace.Repository,
+	comments project.CommentRepository,
+	events event.Publisher,
+) *DeleteTaskUseCase {
+	return &DeleteTaskUseCase{repo: repo, workspaces: workspaces, comments: comments, events: events}
 }
 
+// Execute deletes a task together with its subtasks and their comments. Tasks that waited
+// for them no longer do.
 func (uc *DeleteTaskUseCase) Execute(ctx context.Context, projectIDStr, userIDStr, taskID string) (*project.Project, error) {
 	if projectIDStr == "" || taskID == "" {
 		return nil, errors.New("project ID and task ID are required")
@@ -34,18 +46,38 @@ func (uc *DeleteTaskUseCase) Execute(ctx context.Context, projectIDStr, userIDSt
 	if err != nil || p == nil {
 		return nil, errors.New("project not found")
 	}
+	if err := requireEditor(p); err != nil {
+		return nil, err
+	}
 
-	newTasks := make([]project.Task, 0, len(p.Tasks))
-	for _, t := range p.Tasks {
-		if t.ID != taskID {
-			newTasks = append(newTasks, t)
-		}
+	task := findTask(p, taskID)
+	if task == nil {
+		return p, nil
 	}
-	p.Tasks = newTasks
-	p.Progress = project.ProgressFromTasks(p.Tasks)
+	settings, err := uc.workspaces.SettingsFor(ctx, p.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	parentID := task.ParentID
+	var deleted []project.Task
+	p.Tasks, deleted = project.RemoveTask(p.Tasks, taskID)
+	updated := append(project.DropDependencies(p.Tasks, deleted), completeParents(p, settings, parentID)...)
+	added := spawnOccurrences(p, updated)
+	p.Progress = project.ProgressFromTasks(p.Tasks, p.Workflow, p.ProgressSettings)
 
-	if err := uc.repo.Update(ctx, p); err != nil {
+	if err := uc.repo.Update(ctx, p, userID); err != nil {
 		return nil, err
 	}
+	change := event.ChangeBy(p.ID, userID)
+	published := make([]event.Event, 0, len(deleted)+len(updated))
+	for _, t := range deleted {
+		if err := uc.comments.DeleteTaskComments(ctx, p.ID, t.ID); err != nil {
+			return nil, err
+		}
+		published = append(published, event.TaskDeleted{ProjectChange: change, Task: t})
+	}
+	published = append(published, taskUpdates(change, p, updated)...)
+	uc.events.Publish(ctx, append(published, occurrencesAdded(change, added)...)...)
 	return p, nil
 }
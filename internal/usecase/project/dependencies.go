@@ -0,0 +1,112 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+)
+
+const (
+	maxTaskDependencies = 50
+	maxEstimateHours    = 10000
+)
+
+var errDependencyNotFound = errors.New("dependency not found")
+
+type AddDependencyUseCase struct {
+	repo   project.Repository
+	events event.Publisher
+}
+
+func NewAddDependencyUseCase(repo project.Repository, events event.Publisher) *AddDependencyUseCase {
+	return &AddDependencyUseCase{repo: repo, events: events}
+}
+
+type AddDependencyInput struct {
+	ProjectID string
+	UserID    string
+	TaskID    string // the task that waits
+	DependsOn string // the task it waits for
+	Type      project.DependencyType
+}
+
+// Execute makes a task wait for another one. Adding a dependency the task already has
+// changes its type.
+func (uc *AddDependencyUseCase) Execute(ctx context.Context, input AddDependencyInput) (*project.Project, error) {
+	if input.Type == "" {
+		input.Type = project.FinishToStart
+	}
+	if !input.Type.IsValid() {
+		return nil, errors.New("invalid dependency type")
+	}
+	return editTask(ctx, uc.repo, uc.events, input.ProjectID, input.UserID, input.TaskID, func(p *project.Project, t *project.Task) error {
+		if err := project.CheckDependency(p.Tasks, t.ID, input.DependsOn); err != nil {
+			return err
+		}
+		for i := range t.Dependencies {
+			if t.Dependencies[i].TaskID == input.DependsOn {
+				t.Dependencies[i].Type = input.Type
+				return nil
+			}
+		}
+		if len(t.Dependencies) >= maxTaskDependencies {
+			return errors.New("task has too many dependencies")
+		}
+		t.Dependencies = append(t.Dependencies, project.Dependency{TaskID: input.DependsOn, Type: input.Type})
+		return nil
+	})
+}
+
+type RemoveDependencyUseCase struct {
+	repo   project.Repository
+	events event.Publisher
+}
+
+func NewRemoveDependencyUseCase(repo project.Repository, events event.Publisher) *RemoveDependencyUseCase {
+	return &RemoveDependencyUseCase{repo: repo, events: events}
+}
+
+// Execute stops a task from waiting for another one
+func (uc *RemoveDependencyUseCase) Execute(ctx context.Context, projectID, userID, taskID, dependsOn string) (*project.Project, error) {
+	return editTask(ctx, uc.repo, uc.events, projectID, userID, taskID, func(_ *project.Project, t *project.Task) error {
+		for i := range t.Dependencies {
+			if t.Dependencies[i].TaskID == dependsOn {
+				t.Dependencies = append(t.Dependencies[:i], t.Dependencies[i+1:]...)
+				return nil
+			}
+		}
+		return errDependencyNotFound
+	})
+}
+
+type GetCriticalPathUseCase struct {
+	repo project.Repository
+}
+
+func NewGetCriticalPathUseCase(repo project.Repository) *GetCriticalPathUseCase {
+	return &GetCriticalPathUseCase{repo: repo}
+}
+
+// Execute schedules the project's unfinished tasks from now, or from the project's start
+// date when it lies ahead
+func (uc *GetCriticalPathUseCase) Execute(ctx context.Context, projectID, userID string) (*project.CriticalPath, error) {
+	p, err := loadProject(ctx, uc.repo, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now().UTC().Truncate(time.Minute)
+	if p.StartDate.After(start) {
+		start = p.StartDate
+	}
+	return project.ComputeCriticalPath(p.Tasks, p.Workflow, start, p.EndDate)
+}
+
+func validateEstimate(hours float64) error {
+	if hours < 0 || hours > maxEstimateHours {
+		return errors.New("estimate must be between 0 and 10000 hours")
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+package project
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+)
+
+type InviteMemberUseCase struct {
+	repo        project.Repository
+	members     project.MemberRepository
+	invitations project.InvitationRepository
+	users       user.Repository
+	events      event.Publisher
+	ttl         time.Duration
+}
+
+func NewInviteMemberUseCase(
+	repo project.Repository,
+	members project.MemberRepository,
+	invitations project.InvitationRepository,
+	users user.Repository,
+	events event.Publisher,
+	ttl time.Duration,
+) *InviteMemberUseCase {
+	return &InviteMemberUseCase{
+		repo:        repo,
+		members:     members,
+		invitations: invitations,
+		users:       users,
+		events:      events,
+		ttl:         ttl,
+	}
+}
+
+type InviteMemberInput struct {
+	ProjectID string
+	UserID    string
+	Email     string
+	Role      project.MemberRole
+}
+
+// Execute emails an invitation to join the project; the address does not need an account yet
+func (uc *InviteMemberUseCase) Execute(ctx context.Context, input InviteMemberInput) (*project.Invitation, error) {
+	if !input.Role.IsValid() {
+		return nil, errors.New("invalid member role")
+	}
+	email := strings.TrimSpace(input.Email)
+
+	p, err := loadProject(ctx, uc.repo, input.ProjectID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireOwner(p); err != nil {
+		return nil, err
+	}
+
+	inviter, err := uc.users.FindByID(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if inviter == nil {
+		return nil, errors.New("user not found")
+	}
+
+	invitee, err := uc.users.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if invitee != nil {
+		inviteeID, err := uuid.Parse(invitee.ID)
+		if err != nil {
+			return nil, err
+		}
+		existing, err := uc.members.FindMember(ctx, p.ID, inviteeID)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return nil, errors.New("user is already a member of this project")
+		}
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+	inviterID, err := uuid.Parse(inviter.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation := &project.Invitation{
+		ProjectID:   p.ID,
+		ProjectName: p.Name,
+		Email:       email,
+		Role:        input.Role,
+		Token:       token,
+		InvitedBy:   &inviterID,
+		ExpiresAt:   time.Now().Add(uc.ttl),
+	}
+	if err := uc.invitations.Create(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	uc.events.Publish(ctx, event.MemberInvited{
+		ProjectChange: event.ChangeBy(p.ID, inviterID),
+		Invitation:    *invitation,
+		InviterName:   inviter.Name(),
+	})
+	return invitation, nil
+}
+
+type ListInvitationsUseCase struct {
+	repo        project.Repository
+	invitations project.InvitationRepository
+}
+
+func NewListInvitationsUseCase(repo project.Repository, invitations project.InvitationRepository) *ListInvitationsUseCase {
+	return &ListInvitationsUseCase{repo: repo, invitations: invitations}
+}
+
+func (uc *ListInvitationsUseCase) Execute(ctx context.Context, projectID, userID string) ([]project.Invitation, error) {
+	p, err := loadProject(ctx, uc.repo, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireOwner(p); err != nil {
+		return nil, err
+	}
+	return uc.invitations.ListByProject(ctx, p.ID)
+}
+
+type AcceptInvitationUseCase struct {
+	invitations project.InvitationRepository
+	members     project.MemberRepository
+	users       user.Repository
+}
+
+func NewAcceptInvitationUseCase(
+	invitations project.InvitationRepository,
+	members project.MemberRepository,
+	users user.Repository,
+) *AcceptInvitationUseCase {
+	return &AcceptInvitationUseCase{invitations: invitations, members: members, users: users}
+}
+
+// Execute adds the signed-in user to the project, provided the invitation was sent to their email
+func (uc *AcceptInvitationUseCase) Execute(ctx context.Context, token, userID string) (*project.Invitation, error) {
+	invitation, err := uc.invitations.FindByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if invitation == nil || !invitation.IsPending(time.Now()) {
+		return nil, project.ErrInvalidInvitation
+	}
+
+	u, err := uc.users.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, errors.New("user not found")
+	}
+	if !invitation.IsFor(u.Email) {
+		return nil, errors.New("this invitation was sent to a different email address")
+	}
+
+	if err := AcceptInvitation(ctx, uc.invitations, uc.members, invitation, u.ID); err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+type DeclineInvitationUseCase struct {
+	invitations project.InvitationRepository
+}
+
+func NewDeclineInvitationUseCase(invitations project.InvitationRepository) *DeclineInvitationUseCase {
+	return &DeclineInvitationUseCase{invitations: invitations}
+}
+
+// Execute declines an invitation; holding the emailed token is enough, no account needed
+func (uc *DeclineInvitationUseCase) Execute(ctx context.Context, token string) error {
+	invitation, err := uc.invitations.FindByToken(ctx, token)
+	if err != nil {
+		return err
+	}
+	if invitation == nil || !invitation.IsPending(time.Now()) {
+		return project.ErrInvalidInvitation
+	}
+	return uc.invitations.MarkDeclined(ctx, invitation.ID)
+}
+
+// AcceptInvitation claims a pending invitation and makes userID a member with the invited role.
+// Users who are already members keep their current role.
+func AcceptInvitation(
+	ctx context.Context,
+	invitations project.InvitationRepository,
+	members project.MemberRepository,
+	invitation *project.Invitation,
+	userID string,
+) error {
+	memberID, err := uuid.Parse(userID)
+	if err != nil {
+		return errors.New("invalid user ID format")
+	}
+
+	// Claiming first means a token can only ever add one member
+	if err := invitations.MarkAccepted(ctx, invitation.ID); err != nil {
+		return err
+	}
+	now := time.Now()
+	invitation.AcceptedAt = &now
+
+	existing, err := members.FindMember(ctx, invitation.ProjectID, memberID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return nil
+	}
+	return members.AddMember(ctx, &project.Member{
+		ProjectID: invitation.ProjectID,
+		UserID:    memberID,
+		Role:      invitation.Role,
+	})
+}
+
+func generateToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
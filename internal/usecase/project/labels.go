@@ -0,0 +1,163 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/label"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+)
+
+const maxSearchResults = 100
+
+type ListProjectLabelsUseCase struct {
+	repo   project.Repository
+	labels label.Repository
+}
+
+func NewListProjectLabelsUseCase(repo project.Repository, labels label.Repository) *ListProjectLabelsUseCase {
+	return &ListProjectLabelsUseCase{repo: repo, labels: labels}
+}
+
+// Execute lists the labels the project's tasks can carry, those of its workspace. Project
+// members who are not in the workspace need it to show the labels of tasks.
+func (uc *ListProjectLabelsUseCase) Execute(ctx context.Context, projectID, userID string) ([]label.Label, error) {
+	p, err := loadProject(ctx, uc.repo, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := uc.labels.ListByWorkspace(ctx, p.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if labels == nil {
+		return []label.Label{}, nil
+	}
+	return labels, nil
+}
+
+type SearchUseCase struct {
+	repo project.Repository
+}
+
+func NewSearchUseCase(repo project.Repository) *SearchUseCase {
+	return &SearchUseCase{repo: repo}
+}
+
+type SearchInput struct {
+	UserID      string
+	WorkspaceID string
+	Query       string
+	LabelIDs    []string // results must carry all of them
+}
+
+// TaskMatch is a task found by a search, with the project it belongs to
+type TaskMatch struct {
+	ProjectID   uuid.UUID
+	ProjectName string
+	Task        project.Task
+}
+
+type SearchResult struct {
+	Projects []project.Project
+	Tasks    []TaskMatch
+}
+
+// Execute finds the projects and tasks of a workspace whose name, description or title
+// contains the query, ignoring case. An empty query lists everything with the labels.
+func (uc *SearchUseCase) Execute(ctx context.Context, input SearchInput) (*SearchResult, error) {
+	query := strings.ToLower(strings.TrimSpace(input.Query))
+	if query == "" && len(input.LabelIDs) == 0 {
+		return nil, errors.New("a search query or a label is required")
+	}
+	userID, err := uuid.Parse(input.UserID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+	workspaceID, err := uuid.Parse(input.WorkspaceID)
+	if err != nil {
+		return nil, errors.New("invalid workspace ID format")
+	}
+
+	projects, err := uc.repo.FindAllInWorkspace(ctx, workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SearchResult{Projects: []project.Project{}, Tasks: []TaskMatch{}}
+	for _, p := range projects {
+		if len(result.Projects) < maxSearchResults && hasLabels(p.LabelIDs, input.LabelIDs) &&
+			(contains(p.Name, query) || contains(p.Description, query)) {
+			result.Projects = append(result.Projects, p)
+		}
+		for _, t := range p.Tasks {
+			if len(result.Tasks) < maxSearchResults && hasLabels(t.LabelIDs, input.LabelIDs) && contains(t.Title, query) {
+				result.Tasks = append(result.Tasks, TaskMatch{ProjectID: p.ID, ProjectName: p.Name, Task: t})
+			}
+		}
+	}
+	return result, nil
+}
+
+func contains(text, query string) bool {
+	return strings.Contains(strings.ToLower(text), query)
+}
+
+// hasLabels reports whether labelIDs includes every one of wanted
+func hasLabels(labelIDs, wanted []string) bool {
+	for _, id := range wanted {
+		if !slices.Contains(labelIDs, id) {
+			return false
+		}
+	}
+	return true
+}
+
+// validateLabels checks that the labels belong to the workspace and drops duplicates
+func validateLabels(ctx context.Context, labels label.Repository, workspaceID uuid.UUID, ids []string) ([]string, error) {
+	valid := make([]string, 0, len(ids))
+	for _, id := range ids {
+		labelID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, errors.New("invalid label ID format")
+		}
+		if slices.Contains(valid, labelID.String()) {
+			continue
+		}
+		l, err := labels.FindByID(ctx, labelID)
+		if err != nil {
+			return nil, err
+		}
+		if l == nil || l.WorkspaceID != workspaceID {
+			return nil, label.ErrUnknown
+		}
+		valid = append(valid, l.ID.String())
+	}
+	if len(valid) > label.MaxPerItem {
+		return nil, errors.New("too many labels")
+	}
+	return valid, nil
+}
+
+// keepLabels drops the labels of a project and its tasks that have since been deleted
+func keepLabels(ctx context.Context, labels label.Repository, p *project.Project) error {
+	existing, err := labels.ListByWorkspace(ctx, p.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	known := make(map[string]bool, len(existing))
+	for _, l := range existing {
+		known[l.ID.String()] = true
+	}
+	keep := func(ids []string) []string {
+		return slices.DeleteFunc(ids, func(id string) bool { return !known[id] })
+	}
+	p.LabelIDs = keep(p.LabelIDs)
+	for i := range p.Tasks {
+		p.Tasks[i].LabelIDs = keep(p.Tasks[i].LabelIDs)
+	}
+	return nil
+}
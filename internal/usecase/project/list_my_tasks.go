@@ -0,0 +1,37 @@
+package project
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+)
+
+type ListMyTasksUseCase struct {
+	repo project.Repository
+}
+
+func NewListMyTasksUseCase(repo project.Repository) *ListMyTasksUseCase {
+	return &ListMyTasksUseCase{repo: repo}
+}
+
+// Execute lists tasks assigned to the user across every project they are a member of
+func (uc *ListMyTasksUseCase) Execute(ctx context.Context, userID string, filter project.TaskFilter) ([]project.AssignedTask, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+	if filter.DueBefore != nil && filter.DueAfter != nil && !filter.DueAfter.Before(*filter.DueBefore) {
+		return nil, errors.New("due_after must be before due_before")
+	}
+
+	tasks, err := uc.repo.FindTasksAssignedTo(ctx, userUUID, filter)
+	if err != nil {
+		return nil, err
+	}
+	if tasks == nil {
+		return []project.AssignedTask{}, nil
+	}
+	return tasks, nil
+}
@@ -15,21 +15,29 @@ func NewListProjectsUseCase(repo project.Repository) *ListProjectsUseCase {
 	return &ListProjectsUseCase{repo: repo}
 }
 
-func (uc *ListProjectsUseCase) Execute(ctx context.Context, userID string) ([]project.Project, error) {
+// Execute lists the projects the user can see in a workspace, only those carrying all
+// of labelIDs when any are given
+func (uc *ListProjectsUseCase) Execute(ctx context.Context, userID string, workspaceID string, labelIDs []string) ([]project.Project, error) {
 	// Parse UserID to UUID
 	userUUID, err := uuid.Parse(userID)
 	if err != nil {
 		return nil, err
 	}
-
-	projects, err := uc.repo.FindAllByUserID(ctx, userUUID)
+	workspaceUUID, err := uuid.Parse(workspaceID)
 	if err != nil {
 		return nil, err
 	}
 
-	if projects == nil {
-		return []project.Project{}, nil
+	projects, err := uc.repo.FindAllInWorkspace(ctx, workspaceUUID, userUUID)
+	if err != nil {
+		return nil, err
 	}
 
-	return projects, nil
+	labelled := []project.Project{}
+	for _, p := range projects {
+		if hasLabels(p.LabelIDs, labelIDs) {
+			labelled = append(labelled, p)
+		}
+	}
+	return labelled, nil
 }
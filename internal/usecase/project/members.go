@@ -0,0 +1,276 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+)
+
+var errLastOwner = errors.New("a project must keep at least one owner")
+
+type ListMembersUseCase struct {
+	repo    project.Repository
+	members project.MemberRepository
+}
+
+func NewListMembersUseCase(repo project.Repository, members project.MemberRepository) *ListMembersUseCase {
+	return &ListMembersUseCase{repo: repo, members: members}
+}
+
+// Execute lists the members of a project; any member may see who else has access
+func (uc *ListMembersUseCase) Execute(ctx context.Context, projectID, userID string) ([]project.Member, error) {
+	p, err := loadProject(ctx, uc.repo, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.members.ListMembers(ctx, p.ID)
+}
+
+type AddMemberUseCase struct {
+	repo    project.Repository
+	members project.MemberRepository
+	users   user.Repository
+	events  event.Publisher
+}
+
+func NewAddMemberUseCase(
+	repo project.Repository,
+	members project.MemberRepository,
+	users user.Repository,
+	events event.Publisher,
+) *AddMemberUseCase {
+	return &AddMemberUseCase{repo: repo, members: members, users: users, events: events}
+}
+
+type AddMemberInput struct {
+	ProjectID string
+	UserID    string
+	Email     string
+	Role      project.MemberRole
+}
+
+func (uc *AddMemberUseCase) Execute(ctx context.Context, input AddMemberInput) (*project.Member, error) {
+	if !input.Role.IsValid() {
+		return nil, errors.New("invalid member role")
+	}
+
+	p, err := loadProject(ctx, uc.repo, input.ProjectID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireOwner(p); err != nil {
+		return nil, err
+	}
+
+	u, err := uc.users.FindByEmail(ctx, strings.TrimSpace(input.Email))
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, errors.New("user not found")
+	}
+	memberID, err := uuid.Parse(u.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.members.FindMember(ctx, p.ID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errors.New("user is already a member of this project")
+	}
+
+	m := &project.Member{
+		ProjectID:   p.ID,
+		UserID:      memberID,
+		Email:       u.Email,
+		DisplayName: u.DisplayName,
+		Role:        input.Role,
+	}
+	if err := uc.members.AddMember(ctx, m); err != nil {
+		return nil, err
+	}
+
+	actorID, _ := uuid.Parse(input.UserID)
+	uc.events.Publish(ctx, event.MemberAdded{
+		ProjectChange: event.ChangeBy(p.ID, actorID),
+		Member:        *m,
+		ProjectName:   p.Name,
+		ActorName:     actorName(ctx, uc.members, p.ID, input.UserID),
+	})
+	return m, nil
+}
+
+type UpdateMemberRoleUseCase struct {
+	repo    project.Repository
+	members project.MemberRepository
+	events  event.Publisher
+}
+
+func NewUpdateMemberRoleUseCase(repo project.Repository, members project.MemberRepository, events event.Publisher) *UpdateMemberRoleUseCase {
+	return &UpdateMemberRoleUseCase{repo: repo, members: members, events: events}
+}
+
+type UpdateMemberRoleInput struct {
+	ProjectID    string
+	UserID       string
+	MemberUserID string
+	Role         project.MemberRole
+}
+
+func (uc *UpdateMemberRoleUseCase) Execute(ctx context.Context, input UpdateMemberRoleInput) (*project.Member, error) {
+	if !input.Role.IsValid() {
+		return nil, errors.New("invalid member role")
+	}
+	memberID, err := uuid.Parse(input.MemberUserID)
+	if err != nil {
+		return nil, errors.New("invalid member user ID format")
+	}
+
+	p, err := loadProject(ctx, uc.repo, input.ProjectID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireOwner(p); err != nil {
+		return nil, err
+	}
+
+	m, err := uc.members.FindMember(ctx, p.ID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return nil, errors.New("member not found")
+	}
+	if m.Role == project.RoleOwner && input.Role != project.RoleOwner {
+		if err := ensureAnotherOwner(ctx, uc.members, p.ID, memberID); err != nil {
+			return nil, err
+		}
+	}
+
+	if m.Role == input.Role {
+		return m, nil
+	}
+	if err := uc.members.UpdateMemberRole(ctx, p.ID, memberID, input.Role); err != nil {
+		return nil, err
+	}
+	previousRole := m.Role
+	m.Role = input.Role
+
+	actorID, _ := uuid.Parse(input.UserID)
+	uc.events.Publish(ctx, event.MemberRoleChanged{
+		ProjectChange: event.ChangeBy(p.ID, actorID),
+		Member:        *m,
+		PreviousRole:  previousRole,
+		ProjectName:   p.Name,
+		ActorName:     actorName(ctx, uc.members, p.ID, input.UserID),
+	})
+	return m, nil
+}
+
+type RemoveMemberUseCase struct {
+	repo    project.Repository
+	members project.MemberRepository
+	events  event.Publisher
+}
+
+func NewRemoveMemberUseCase(repo project.Repository, members project.MemberRepository, events event.Publisher) *RemoveMemberUseCase {
+	return &RemoveMemberUseCase{repo: repo, members: members, events: events}
+}
+
+// Execute removes a member. Owners may remove anyone; other members may only leave.
+func (uc *RemoveMemberUseCase) Execute(ctx context.Context, projectID, userID, memberUserID string) error {
+	memberID, err := uuid.Parse(memberUserID)
+	if err != nil {
+		return errors.New("invalid member user ID format")
+	}
+
+	p, err := loadProject(ctx, uc.repo, projectID, userID)
+	if err != nil {
+		return err
+	}
+	if memberUserID != userID {
+		if err := requireOwner(p); err != nil {
+			return err
+		}
+	}
+
+	m, err := uc.members.FindMember(ctx, p.ID, memberID)
+	if err != nil {
+		return err
+	}
+	if m == nil {
+		return errors.New("member not found")
+	}
+	if m.Role == project.RoleOwner {
+		if err := ensureAnotherOwner(ctx, uc.members, p.ID, memberID); err != nil {
+			return err
+		}
+	}
+
+	// Looked up first: a member who leaves is no longer there to be named
+	actor := actorName(ctx, uc.members, p.ID, userID)
+	actorID, _ := uuid.Parse(userID)
+	if err := uc.members.RemoveMember(ctx, p.ID, memberID, actorID); err != nil {
+		return err
+	}
+
+	uc.events.Publish(ctx, event.MemberRemoved{
+		ProjectChange: event.ChangeBy(p.ID, actorID),
+		Member:        *m,
+		ProjectName:   p.Name,
+		ActorName:     actor,
+	})
+	return nil
+}
+
+// actorName is how the member acting on the project is shown to others
+func actorName(ctx context.Context, members project.MemberRepository, projectID uuid.UUID, userID string) string {
+	if uid, err := uuid.Parse(userID); err == nil {
+		if m, err := members.FindMember(ctx, projectID, uid); err == nil && m != nil {
+			return memberName(*m)
+		}
+	}
+	return "Someone"
+}
+
+// loadProject parses the IDs and loads the project as seen by userID
+func loadProject(ctx context.Context, repo project.Repository, projectID, userID string) (*project.Project, error) {
+	pid, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, errors.New("invalid project ID format")
+	}
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	p, err := repo.FindByID(ctx, pid, uid)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, errors.New("project not found")
+	}
+	return p, nil
+}
+
+func ensureAnotherOwner(ctx context.Context, members project.MemberRepository, projectID, leaving uuid.UUID) error {
+	all, err := members.ListMembers(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	for _, m := range all {
+		if m.Role == project.RoleOwner && m.UserID != leaving {
+			return nil
+		}
+	}
+	return errLastOwner
+}
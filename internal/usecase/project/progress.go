@@ -0,0 +1,58 @@
+package project
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+)
+
+const maxStoryPoints = 1000
+
+type UpdateProgressSettingsUseCase struct {
+	repo   project.Repository
+	events event.Publisher
+}
+
+func NewUpdateProgressSettingsUseCase(repo project.Repository, events event.Publisher) *UpdateProgressSettingsUseCase {
+	return &UpdateProgressSettingsUseCase{repo: repo, events: events}
+}
+
+// Execute changes how a project works out its progress, or sets it by hand in manual
+// mode, and recalculates it right away
+func (uc *UpdateProgressSettingsUseCase) Execute(ctx context.Context, projectID, userID string, settings project.ProgressSettings) (*project.Project, error) {
+	p, err := loadProject(ctx, uc.repo, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireEditor(p); err != nil {
+		return nil, err
+	}
+	if err := settings.Normalize(); err != nil {
+		return nil, err
+	}
+
+	previous := event.SummarizeProject(p)
+	p.ProgressSettings = settings
+	p.Progress = project.ProgressFromTasks(p.Tasks, p.Workflow, p.ProgressSettings)
+
+	actorID, _ := uuid.Parse(userID)
+	if err := uc.repo.Update(ctx, p, actorID); err != nil {
+		return nil, err
+	}
+	uc.events.Publish(ctx, event.ProjectUpdated{
+		ProjectChange: event.ChangeBy(p.ID, actorID),
+		Project:       event.SummarizeProject(p),
+		Previous:      previous,
+	})
+	return p, nil
+}
+
+func validateStoryPoints(points float64) error {
+	if points < 0 || points > maxStoryPoints {
+		return errors.New("story points must be between 0 and 1000")
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+)
+
+type RankTaskUseCase struct {
+	repo   project.Repository
+	events event.Publisher
+}
+
+func NewRankTaskUseCase(repo project.Repository, events event.Publisher) *RankTaskUseCase {
+	return &RankTaskUseCase{repo: repo, events: events}
+}
+
+type RankTaskInput struct {
+	ProjectID string
+	UserID    string
+	TaskID    string
+	AfterID   string // the task to follow, empty to move to the start of the list when BeforeID is set
+	BeforeID  string // the task to precede, empty to move right after AfterID
+}
+
+// Execute moves a task between two neighbouring tasks of the list by giving it a new rank.
+// The move is worked out on the tasks as they are saved, so clients moving other tasks at the
+// same time do not undo each other's work. It fails with project.ErrRankConflict when the two
+// tasks are no longer neighbours.
+func (uc *RankTaskUseCase) Execute(ctx context.Context, input RankTaskInput) (*project.Project, error) {
+	p, err := loadProject(ctx, uc.repo, input.ProjectID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireEditor(p); err != nil {
+		return nil, err
+	}
+	task := findTask(p, input.TaskID)
+	if task == nil {
+		return nil, errors.New("task not found")
+	}
+	previous := *task
+
+	actorID, _ := uuid.Parse(input.UserID)
+	p, err = uc.repo.RankTask(ctx, p.ID, task.ID, input.AfterID, input.BeforeID, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.events.Publish(ctx, taskUpdates(event.ChangeBy(p.ID, actorID), p, []project.Task{previous})...)
+	return p, nil
+}
+
+// RebalanceRanksUseCase spreads out task ranks that have grown long from many moves into the
+// same spot. It is meant to run periodically.
+type RebalanceRanksUseCase struct {
+	repo project.Repository
+}
+
+func NewRebalanceRanksUseCase(repo project.Repository) *RebalanceRanksUseCase {
+	return &RebalanceRanksUseCase{repo: repo}
+}
+
+func (uc *RebalanceRanksUseCase) Execute(ctx context.Context) error {
+	changed, err := uc.repo.SpreadLongRanks(ctx)
+	if err != nil {
+		return err
+	}
+	if changed > 0 {
+		log.Printf("Rebalanced task ranks of %d projects", changed)
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+)
+
+const maxRecurrenceRuleLength = 500
+
+// RecurrenceInput makes a task recur. Timezone defaults to the acting user's.
+type RecurrenceInput struct {
+	Rule     string
+	Timezone string
+}
+
+type EditRecurringTaskUseCase struct {
+	repo        project.Repository
+	members     project.MemberRepository
+	preferences user.PreferencesReader
+	events      event.Publisher
+}
+
+func NewEditRecurringTaskUseCase(
+	repo project.Repository,
+	members project.MemberRepository,
+	preferences user.PreferencesReader,
+	events event.Publisher,
+) *EditRecurringTaskUseCase {
+	return &EditRecurringTaskUseCase{repo: repo, members: members, preferences: preferences, events: events}
+}
+
+type EditRecurringTaskInput struct {
+	ProjectID     string
+	UserID        string
+	TaskID        string
+	AllFuture     bool // also changes the occurrences still to come, not just this one
+	Title         *string
+	Priority      *project.TaskPriority
+	DueDate       *time.Time
+	EstimateHours *float64
+	StoryPoints   *float64
+	AssigneeIDs   *[]string
+	Rule          *string // only for all future occurrences; starts a series on a task that does not recur yet
+	Timezone      *string
+}
+
+// Execute edits one occurrence of a recurring task, or it and every later one. Moving the
+// due date of all future occurrences shifts the series to start at the new date.
+func (uc *EditRecurringTaskUseCase) Execute(ctx context.Context, input EditRecurringTaskInput) (*project.Project, error) {
+	if !input.AllFuture && (input.Rule != nil || input.Timezone != nil) {
+		return nil, errors.New("the recurrence rule can only be changed for all future occurrences")
+	}
+	if input.Rule != nil && len(*input.Rule) > maxRecurrenceRuleLength {
+		return nil, errors.New("recurrence rule is too long")
+	}
+	if input.Title != nil && *input.Title == "" {
+		return nil, errors.New("task title is required")
+	}
+	if input.EstimateHours != nil {
+		if err := validateEstimate(*input.EstimateHours); err != nil {
+			return nil, err
+		}
+	}
+	if input.StoryPoints != nil {
+		if err := validateStoryPoints(*input.StoryPoints); err != nil {
+			return nil, err
+		}
+	}
+
+	p, err := editTask(ctx, uc.repo, uc.events, input.ProjectID, input.UserID, input.TaskID, func(p *project.Project, t *project.Task) error {
+		if t.Recurrence == nil && (!input.AllFuture || input.Rule == nil) {
+			return project.ErrNotRecurring
+		}
+		var assignees []string
+		if input.AssigneeIDs != nil {
+			members, err := memberIndex(ctx, uc.members, p.ID)
+			if err != nil {
+				return err
+			}
+			if assignees, err = validateAssignees(*input.AssigneeIDs, members); err != nil {
+				return err
+			}
+		}
+
+		if input.Title != nil {
+			t.Title = *input.Title
+		}
+		if input.Priority != nil {
+			t.Priority = *input.Priority
+		}
+		if input.DueDate != nil {
+			t.DueDate = input.DueDate
+		}
+		if input.EstimateHours != nil {
+			t.EstimateHours = *input.EstimateHours
+		}
+		if input.StoryPoints != nil {
+			t.StoryPoints = *input.StoryPoints
+		}
+		if input.AssigneeIDs != nil {
+			t.AssigneeIDs = assignees
+		}
+		if !input.AllFuture {
+			return nil
+		}
+
+		if t.Recurrence == nil {
+			timezone, err := recurrenceTimezone(ctx, uc.preferences, input.UserID, input.Timezone)
+			if err != nil {
+				return err
+			}
+			t.Recurrence, err = project.NewRecurrence(*input.Rule, timezone, t)
+			return err
+		}
+
+		recurrence := *t.Recurrence
+		template := &recurrence.Template
+		if input.Title != nil {
+			template.Title = t.Title
+		}
+		if input.Priority != nil {
+			template.Priority = t.Priority
+		}
+		if input.EstimateHours != nil {
+			template.EstimateHours = t.EstimateHours
+		}
+		if input.StoryPoints != nil {
+			template.StoryPoints = t.StoryPoints
+		}
+		if input.AssigneeIDs != nil {
+			template.AssigneeIDs = append([]string(nil), t.AssigneeIDs...)
+		}
+		if input.Rule != nil || input.Timezone != nil || input.DueDate != nil {
+			rule, timezone := recurrence.Rule, recurrence.Timezone
+			if input.Rule != nil {
+				rule = *input.Rule
+			}
+			if input.Timezone != nil {
+				timezone = *input.Timezone
+			}
+			if t.DueDate == nil {
+				return project.ErrRecurrenceNeedsDueDate
+			}
+			if err := recurrence.Restart(rule, timezone, *t.DueDate); err != nil {
+				return err
+			}
+		}
+		t.Recurrence = &recurrence
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+type StopRecurrenceUseCase struct {
+	repo   project.Repository
+	events event.Publisher
+}
+
+func NewStopRecurrenceUseCase(repo project.Repository, events event.Publisher) *StopRecurrenceUseCase {
+	return &StopRecurrenceUseCase{repo: repo, events: events}
+}
+
+// Execute makes a task a one-off again; completing it no longer adds another occurrence
+func (uc *StopRecurrenceUseCase) Execute(ctx context.Context, projectID, userID, taskID string) (*project.Project, error) {
+	return editTask(ctx, uc.repo, uc.events, projectID, userID, taskID, func(_ *project.Project, t *project.Task) error {
+		if t.Recurrence == nil {
+			return project.ErrNotRecurring
+		}
+		t.Recurrence = nil
+		return nil
+	})
+}
+
+// newRecurrence starts a series on a new task from the request's rule
+func newRecurrence(ctx context.Context, preferences user.PreferencesReader, userID string, input *RecurrenceInput, t *project.Task) (*project.Recurrence, error) {
+	if len(input.Rule) > maxRecurrenceRuleLength {
+		return nil, errors.New("recurrence rule is too long")
+	}
+	var timezone *string
+	if input.Timezone != "" {
+		timezone = &input.Timezone
+	}
+	tz, err := recurrenceTimezone(ctx, preferences, userID, timezone)
+	if err != nil {
+		return nil, err
+	}
+	return project.NewRecurrence(input.Rule, tz, t)
+}
+
+// recurrenceTimezone is the given time zone, or the user's own
+func recurrenceTimezone(ctx context.Context, preferences user.PreferencesReader, userID string, timezone *string) (string, error) {
+	if timezone != nil && *timezone != "" {
+		if err := user.ValidateTimezone(*timezone); err != nil {
+			return "", err
+		}
+		return *timezone, nil
+	}
+	prefs, err := preferences.PreferencesFor(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	return prefs.Timezone, nil
+}
+
+// spawnOccurrences adds the next occurrence of every recurring task that has just been
+// done, right after it. previous holds the changed tasks as they were before.
+func spawnOccurrences(p *project.Project, previous []project.Task) []project.Task {
+	var added []project.Task
+	for _, prev := range previous {
+		i := slices.IndexFunc(p.Tasks, func(t project.Task) bool { return t.ID == prev.ID })
+		if i < 0 || p.Workflow.IsDone(prev.Status) || !p.Workflow.IsDone(p.Tasks[i].Status) {
+			continue
+		}
+		next := project.NextOccurrence(p.Tasks, &p.Tasks[i], p.Workflow.Initial(), time.Now())
+		if next == nil {
+			continue
+		}
+		p.Tasks = slices.Insert(p.Tasks, i+1, *next)
+		added = append(added, *next)
+	}
+	return added
+}
+
+// occurrencesAdded announces the occurrences spawnOccurrences added
+func occurrencesAdded(change event.ProjectChange, added []project.Task) []event.Event {
+	published := make([]event.Event, len(added))
+	for i, t := range added {
+		published[i] = event.TaskAdded{ProjectChange: change, Task: t, Occurrence: true}
+	}
+	return published
+}
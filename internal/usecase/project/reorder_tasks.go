@@ -5,15 +5,17 @@ import (
 	"errors"
 
 	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
 	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
 )
 
 type ReorderTasksUseCase struct {
-	repo project.Repository
+	repo   project.Repository
+	events event.Publisher
 }
 
-func NewReorderTasksUseCase(repo project.Repository) *ReorderTasksUseCase {
-	return &ReorderTasksUseCase{repo: repo}
+func NewReorderTasksUseCase(repo project.Repository, events event.Publisher) *ReorderTasksUseCase {
+	return &ReorderTasksUseCase{repo: repo, events: events}
 }
 
 // Execute reorders project tasks to match the given taskIds order.
@@ -36,10 +38,15 @@ func (uc *ReorderTasksUseCase) Execute(ctx context.Context, projectIDStr, userID
 	if err != nil || p == nil {
 		return nil, errors.New("project not found")
 	}
+	if err := requireEditor(p); err != nil {
+		return nil, err
+	}
 
 	// Build id -> task map
+	previous := make([]string, len(p.Tasks))
 	byID := make(map[string]project.Task)
-	for _, t := range p.Tasks {
+	for i, t := range p.Tasks {
+		previous[i] = t.ID
 		byID[t.ID] = t
 	}
 
@@ -59,8 +66,14 @@ func (uc *ReorderTasksUseCase) Execute(ctx context.Context, projectIDStr, userID
 	}
 
 	p.Tasks = newTasks
-	if err := uc.repo.Update(ctx, p); err != nil {
+	if err := uc.repo.Update(ctx, p, userID); err != nil {
 		return nil, err
 	}
+
+	order := make([]string, len(p.Tasks))
+	for i, t := range p.Tasks {
+		order[i] = t.ID
+	}
+	uc.events.Publish(ctx, event.TasksReordered{ProjectChange: event.ChangeBy(p.ID, userID), TaskIDs: order, Previous: previous})
 	return p, nil
 }
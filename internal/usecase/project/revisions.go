@@ -0,0 +1,236 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/label"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+)
+
+const (
+	defaultRevisionPageSize = 20
+	maxRevisionPageSize     = 100
+)
+
+type ListRevisionsUseCase struct {
+	repo      project.Repository
+	revisions project.RevisionRepository
+}
+
+func NewListRevisionsUseCase(repo project.Repository, revisions project.RevisionRepository) *ListRevisionsUseCase {
+	return &ListRevisionsUseCase{repo: repo, revisions: revisions}
+}
+
+type ListRevisionsInput struct {
+	ProjectID string
+	UserID    string
+	Page      int
+	PageSize  int
+}
+
+type ListRevisionsResult struct {
+	Revisions []project.Revision
+	Total     int
+	Page      int
+	PageSize  int
+}
+
+// Execute returns a page of a project's revisions, newest first. Any member may read them.
+func (uc *ListRevisionsUseCase) Execute(ctx context.Context, input ListRevisionsInput) (*ListRevisionsResult, error) {
+	if input.Page < 1 {
+		input.Page = 1
+	}
+	if input.PageSize < 1 {
+		input.PageSize = defaultRevisionPageSize
+	}
+	if input.PageSize > maxRevisionPageSize {
+		input.PageSize = maxRevisionPageSize
+	}
+
+	p, err := loadProject(ctx, uc.repo, input.ProjectID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, total, err := uc.revisions.ListRevisions(ctx, p.ID, (input.Page-1)*input.PageSize, input.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	if revisions == nil {
+		revisions = []project.Revision{}
+	}
+	return &ListRevisionsResult{Revisions: revisions, Total: total, Page: input.Page, PageSize: input.PageSize}, nil
+}
+
+type GetRevisionUseCase struct {
+	repo      project.Repository
+	revisions project.RevisionRepository
+}
+
+func NewGetRevisionUseCase(repo project.Repository, revisions project.RevisionRepository) *GetRevisionUseCase {
+	return &GetRevisionUseCase{repo: repo, revisions: revisions}
+}
+
+// Execute returns one revision of a project with its snapshot
+func (uc *GetRevisionUseCase) Execute(ctx context.Context, projectID, userID string, number int) (*project.Revision, error) {
+	p, err := loadProject(ctx, uc.repo, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.revisions.FindRevision(ctx, p.ID, number)
+}
+
+type DiffRevisionsUseCase struct {
+	repo      project.Repository
+	revisions project.RevisionRepository
+}
+
+func NewDiffRevisionsUseCase(repo project.Repository, revisions project.RevisionRepository) *DiffRevisionsUseCase {
+	return &DiffRevisionsUseCase{repo: repo, revisions: revisions}
+}
+
+type DiffRevisionsInput struct {
+	ProjectID string
+	UserID    string
+	From      int
+	To        int // zero compares against the project as it is now
+}
+
+type DiffRevisionsResult struct {
+	From int
+	To   int
+	Diff project.SnapshotDiff
+}
+
+// Execute compares two revisions of a project
+func (uc *DiffRevisionsUseCase) Execute(ctx context.Context, input DiffRevisionsInput) (*DiffRevisionsResult, error) {
+	if input.From < 1 || input.To < 0 {
+		return nil, errors.New("revision numbers must be positive")
+	}
+
+	p, err := loadProject(ctx, uc.repo, input.ProjectID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := uc.revisions.FindRevision(ctx, p.ID, input.From)
+	if err != nil {
+		return nil, err
+	}
+	to := p.Snapshot()
+	if input.To == 0 {
+		input.To = p.Revision
+	} else {
+		rev, err := uc.revisions.FindRevision(ctx, p.ID, input.To)
+		if err != nil {
+			return nil, err
+		}
+		to = rev.Snapshot
+	}
+
+	return &DiffRevisionsResult{From: input.From, To: input.To, Diff: project.DiffSnapshots(from.Snapshot, to)}, nil
+}
+
+type RestoreRevisionUseCase struct {
+	repo      project.Repository
+	revisions project.RevisionRepository
+	members   project.MemberRepository
+	labels    label.Repository
+	events    event.Publisher
+}
+
+func NewRestoreRevisionUseCase(
+	repo project.Repository,
+	revisions project.RevisionRepository,
+	members project.MemberRepository,
+	labels label.Repository,
+	events event.Publisher,
+) *RestoreRevisionUseCase {
+	return &RestoreRevisionUseCase{repo: repo, revisions: revisions, members: members, labels: labels, events: events}
+}
+
+// Execute rolls a project back to an earlier revision. The restore is saved as a new
+// revision, so it can itself be undone. Assignees who have since left the project and
+// labels that have since been deleted are not brought back, and tasks on statuses the
+// workflow has dropped since go back to its first status.
+func (uc *RestoreRevisionUseCase) Execute(ctx context.Context, projectID, userID string, number int) (*project.Project, error) {
+	p, err := loadProject(ctx, uc.repo, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireEditor(p); err != nil {
+		return nil, err
+	}
+	if number == p.Revision {
+		return nil, errors.New("project is already at this revision")
+	}
+
+	rev, err := uc.revisions.FindRevision(ctx, p.ID, number)
+	if err != nil {
+		return nil, err
+	}
+	members, err := memberIndex(ctx, uc.members, p.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := p.Snapshot()
+	p.Restore(rev.Snapshot)
+	for i := range p.Tasks {
+		p.Tasks[i].KeepAssignees(func(id string) bool {
+			_, ok := members[id]
+			return ok
+		})
+	}
+	if err := keepLabels(ctx, uc.labels, p); err != nil {
+		return nil, err
+	}
+	for i := range p.Tasks {
+		if !p.Workflow.Allows(p.Tasks[i].Status) {
+			p.Tasks[i].Status = p.Workflow.Initial()
+		}
+	}
+	// Progress follows the project's current settings, which revisions do not keep
+	p.Progress = project.ProgressFromTasks(p.Tasks, p.Workflow, p.ProgressSettings)
+
+	actorID, _ := uuid.Parse(userID)
+	if err := uc.repo.Update(ctx, p, actorID); err != nil {
+		return nil, err
+	}
+	uc.events.Publish(ctx, event.ProjectRestored{
+		ProjectChange:    event.ChangeBy(p.ID, actorID),
+		RestoredRevision: number,
+		Revision:         p.Revision,
+		Snapshot:         p.Snapshot(),
+		Previous:         previous,
+	})
+	return p, nil
+}
+
+// CompactRevisionsUseCase thins out old revisions so history does not grow without bound.
+// It is meant to run periodically.
+type CompactRevisionsUseCase struct {
+	revisions  project.RevisionRepository
+	keepAll    time.Duration
+	keepHourly time.Duration
+}
+
+func NewCompactRevisionsUseCase(revisions project.RevisionRepository, keepAll, keepHourly time.Duration) *CompactRevisionsUseCase {
+	return &CompactRevisionsUseCase{revisions: revisions, keepAll: keepAll, keepHourly: keepHourly}
+}
+
+func (uc *CompactRevisionsUseCase) Execute(ctx context.Context) error {
+	removed, err := uc.revisions.CompactRevisions(ctx, uc.keepAll, uc.keepHourly)
+	if err != nil {
+		return err
+	}
+	if removed > 0 {
+		log.Printf("Compacted %d project revisions", removed)
+	}
+	return nil
+}
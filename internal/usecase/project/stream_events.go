@@ -0,0 +1,70 @@
+package project
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+)
+
+// accessRecheckInterval is how often a long-lived stream confirms the user is still a member,
+// not banned and not signed out by a revocation of their tokens
+const accessRecheckInterval = time.Minute
+
+type StreamEventsUseCase struct {
+	repo    project.Repository
+	members project.MemberRepository
+	users   user.Repository
+	stream  project.EventStream
+}
+
+func NewStreamEventsUseCase(repo project.Repository, members project.MemberRepository, users user.Repository, stream project.EventStream) *StreamEventsUseCase {
+	return &StreamEventsUseCase{repo: repo, members: members, users: users, stream: stream}
+}
+
+// Execute streams the project's events to a member until ctx is done, they lose access, are
+// banned or the credentials the stream was opened with, issued at issuedAt, are revoked, or
+// until passes, then closes the channel. until is when those credentials expire; the zero
+// time means they do not.
+func (uc *StreamEventsUseCase) Execute(ctx context.Context, projectID, userID string, issuedAt, until time.Time) (<-chan project.Event, error) {
+	p, err := loadProject(ctx, uc.repo, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	uid, _ := uuid.Parse(userID)
+
+	var cancel context.CancelFunc
+	if until.IsZero() {
+		ctx, cancel = context.WithCancel(ctx)
+	} else {
+		ctx, cancel = context.WithDeadline(ctx, until)
+	}
+	go func() {
+		defer cancel()
+		ticker := time.NewTicker(accessRecheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if !uc.stillAllowed(ctx, p.ID, uid, issuedAt) {
+					return
+				}
+			}
+		}
+	}()
+	return uc.stream.Subscribe(ctx, p.ID), nil
+}
+
+// stillAllowed gives the benefit of the doubt when the database cannot be reached
+func (uc *StreamEventsUseCase) stillAllowed(ctx context.Context, projectID, userID uuid.UUID, issuedAt time.Time) bool {
+	m, err := uc.members.FindMember(ctx, projectID, userID)
+	if err == nil && m == nil {
+		return false
+	}
+	u, err := uc.users.FindByID(ctx, userID.String())
+	return err != nil || (u != nil && !u.IsBanned() && u.AcceptsTokenIssuedAt(issuedAt))
+}
@@ -0,0 +1,77 @@
+package project
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+)
+
+// streamTicketTTL is how long a stream ticket can be used; it is only meant to bridge the gap
+// between asking for it and opening the stream
+const streamTicketTTL = 30 * time.Second
+
+type IssueStreamTicketUseCase struct {
+	repo    project.Repository
+	tickets project.StreamTicketRepository
+}
+
+func NewIssueStreamTicketUseCase(repo project.Repository, tickets project.StreamTicketRepository) *IssueStreamTicketUseCase {
+	return &IssueStreamTicketUseCase{repo: repo, tickets: tickets}
+}
+
+// Execute returns a single-use ticket that opens the project's event stream for a member.
+// The stream ends when the access token the ticket is issued for, issued at tokenIssuedAt and
+// expiring at accessExpiresAt, would have, or is revoked.
+func (uc *IssueStreamTicketUseCase) Execute(ctx context.Context, projectID, userID string, tokenIssuedAt, accessExpiresAt time.Time) (string, time.Duration, error) {
+	p, err := loadProject(ctx, uc.repo, projectID, userID)
+	if err != nil {
+		return "", 0, err
+	}
+	uid, _ := uuid.Parse(userID)
+
+	token, err := generateToken()
+	if err != nil {
+		return "", 0, err
+	}
+	ticket := &project.StreamTicket{
+		TokenHash:       hashTicket(token),
+		UserID:          uid,
+		ProjectID:       p.ID,
+		ExpiresAt:       time.Now().Add(streamTicketTTL),
+		AccessExpiresAt: accessExpiresAt,
+		TokenIssuedAt:   tokenIssuedAt,
+	}
+	if err := uc.tickets.CreateStreamTicket(ctx, ticket); err != nil {
+		return "", 0, err
+	}
+	return token, streamTicketTTL, nil
+}
+
+type RedeemStreamTicketUseCase struct {
+	tickets project.StreamTicketRepository
+}
+
+func NewRedeemStreamTicketUseCase(tickets project.StreamTicketRepository) *RedeemStreamTicketUseCase {
+	return &RedeemStreamTicketUseCase{tickets: tickets}
+}
+
+// Execute uses up the ticket and returns it if it was issued for the project
+func (uc *RedeemStreamTicketUseCase) Execute(ctx context.Context, token, projectID string) (*project.StreamTicket, error) {
+	ticket, err := uc.tickets.RedeemStreamTicket(ctx, hashTicket(token))
+	if err != nil {
+		return nil, err
+	}
+	if ticket == nil || ticket.ProjectID.String() != projectID {
+		return nil, project.ErrInvalidStreamTicket
+	}
+	return ticket, nil
+}
+
+func hashTicket(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,97 @@
+package project
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/workspace"
+)
+
+type ReorderSubtasksUseCase struct {
+	repo   project.Repository
+	events event.Publisher
+}
+
+func NewReorderSubtasksUseCase(repo project.Repository, events event.Publisher) *ReorderSubtasksUseCase {
+	return &ReorderSubtasksUseCase{repo: repo, events: events}
+}
+
+// Execute reorders the direct subtasks of a task to match subtaskIDs. Subtasks not listed
+// keep their order after the listed ones. Other tasks do not move.
+func (uc *ReorderSubtasksUseCase) Execute(ctx context.Context, projectID, userID, taskID string, subtaskIDs []string) (*project.Project, error) {
+	p, err := loadProject(ctx, uc.repo, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireEditor(p); err != nil {
+		return nil, err
+	}
+	if findTask(p, taskID) == nil {
+		return nil, errors.New("task not found")
+	}
+
+	// The subtasks trade places among the slots they already hold in the task list
+	slots := project.Subtasks(p.Tasks, taskID)
+	previous := make([]string, len(p.Tasks))
+	for i, t := range p.Tasks {
+		previous[i] = t.ID
+	}
+	ordered := make([]project.Task, 0, len(slots))
+	placed := make(map[string]bool, len(slots))
+	for _, id := range subtaskIDs {
+		for _, i := range slots {
+			if p.Tasks[i].ID == id && !placed[id] {
+				ordered = append(ordered, p.Tasks[i])
+				placed[id] = true
+			}
+		}
+	}
+	for _, i := range slots {
+		if !placed[p.Tasks[i].ID] {
+			ordered = append(ordered, p.Tasks[i])
+		}
+	}
+	for n, i := range slots {
+		p.Tasks[i] = ordered[n]
+	}
+
+	actorID, _ := uuid.Parse(userID)
+	if err := uc.repo.Update(ctx, p, actorID); err != nil {
+		return nil, err
+	}
+
+	order := make([]string, len(p.Tasks))
+	for i, t := range p.Tasks {
+		order[i] = t.ID
+	}
+	uc.events.Publish(ctx, event.TasksReordered{ProjectChange: event.ChangeBy(p.ID, actorID), TaskIDs: order, Previous: previous})
+	return p, nil
+}
+
+// completeParents applies the workspace's parent completion rule from parentID up and returns
+// the parent tasks it changed, as they were before
+func completeParents(p *project.Project, settings workspace.Settings, parentID string) []project.Task {
+	return settings.ParentCompletion.Cascade(p.Tasks, parentID, p.Workflow)
+}
+
+// taskUpdates announces changes to tasks given their earlier versions. Only the first
+// earlier version of each task counts.
+func taskUpdates(change event.ProjectChange, p *project.Project, previous []project.Task) []event.Event {
+	var published []event.Event
+	seen := make(map[string]bool, len(previous))
+	for _, prev := range previous {
+		t := findTask(p, prev.ID)
+		if t == nil || seen[prev.ID] {
+			continue
+		}
+		seen[prev.ID] = true
+		published = append(published, event.TaskUpdated{ProjectChange: change, Task: *t, Previous: prev})
+		if p.Workflow.IsDone(t.Status) && !p.Workflow.IsDone(prev.Status) {
+			published = append(published, event.TaskCompleted{ProjectChange: change, Task: *t})
+		}
+	}
+	return published
+}
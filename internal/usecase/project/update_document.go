@@ -6,15 +6,17 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
 	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
 )
 
 type UpdateDocumentUseCase struct {
-	repo project.Repository
+	repo   project.Repository
+	events event.Publisher
 }
 
-func NewUpdateDocumentUseCase(repo project.Repository) *UpdateDocumentUseCase {
-	return &UpdateDocumentUseCase{repo: repo}
+func NewUpdateDocumentUseCase(repo project.Repository, events event.Publisher) *UpdateDocumentUseCase {
+	return &UpdateDocumentUseCase{repo: repo, events: events}
 }
 
 type UpdateDocumentInput struct {
@@ -44,11 +46,16 @@ func (uc *UpdateDocumentUseCase) Execute(ctx context.Context, input UpdateDocume
 	if err != nil || p == nil {
 		return nil, errors.New("project not found")
 	}
+	if err := requireEditor(p); err != nil {
+		return nil, err
+	}
 
-	found := false
+	var doc *project.Document
+	var previous project.Document
 	for i := range p.Documents {
 		if p.Documents[i].ID == input.DocumentID {
-			found = true
+			doc = &p.Documents[i]
+			previous = p.Documents[i]
 			if input.Name != nil {
 				p.Documents[i].Name = *input.Name
 			}
@@ -62,12 +69,17 @@ func (uc *UpdateDocumentUseCase) Execute(ctx context.Context, input UpdateDocume
 			break
 		}
 	}
-	if !found {
+	if doc == nil {
 		return nil, errors.New("document not found")
 	}
 
-	if err := uc.repo.Update(ctx, p); err != nil {
+	if err := uc.repo.Update(ctx, p, userID); err != nil {
 		return nil, err
 	}
+	uc.events.Publish(ctx, event.DocumentUpdated{
+		ProjectChange: event.ChangeBy(p.ID, userID),
+		Document:      *doc,
+		Previous:      previous,
+	})
 	return p, nil
 }
@@ -6,15 +6,19 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/label"
 	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
 )
 
 type UpdateProjectUseCase struct {
-	repo project.Repository
+	repo   project.Repository
+	labels label.Repository
+	events event.Publisher
 }
 
-func NewUpdateProjectUseCase(repo project.Repository) *UpdateProjectUseCase {
-	return &UpdateProjectUseCase{repo: repo}
+func NewUpdateProjectUseCase(repo project.Repository, labels label.Repository, events event.Publisher) *UpdateProjectUseCase {
+	return &UpdateProjectUseCase{repo: repo, labels: labels, events: events}
 }
 
 // UpdateProjectInput chỉ chứa thông tin cơ bản; task/document dùng API riêng
@@ -26,6 +30,7 @@ type UpdateProjectInput struct {
 	Status      project.ProjectStatus `json:"status" binding:"omitempty,oneof=active pending completed"`
 	StartDate   *time.Time            `json:"startDate"`
 	EndDate     *time.Time            `json:"endDate"`
+	LabelIDs    *[]string             `json:"labelIds"` // replaces the labels when set
 }
 
 func (uc *UpdateProjectUseCase) Execute(ctx context.Context, input UpdateProjectInput) (*project.Project, error) {
@@ -51,6 +56,11 @@ func (uc *UpdateProjectUseCase) Execute(ctx context.Context, input UpdateProject
 	if existingProject == nil {
 		return nil, errors.New("project not found")
 	}
+	if err := requireEditor(existingProject); err != nil {
+		return nil, err
+	}
+
+	previous := event.SummarizeProject(existingProject)
 
 	// Update fields if provided
 	if input.Name != "" {
@@ -69,6 +79,13 @@ func (uc *UpdateProjectUseCase) Execute(ctx context.Context, input UpdateProject
 	if input.EndDate != nil {
 		existingProject.EndDate = input.EndDate
 	}
+	if input.LabelIDs != nil {
+		labelIDs, err := validateLabels(ctx, uc.labels, existingProject.WorkspaceID, *input.LabelIDs)
+		if err != nil {
+			return nil, err
+		}
+		existingProject.LabelIDs = labelIDs
+	}
 
 	// Progress giữ nguyên (chỉ thay đổi qua API task)
 
@@ -77,9 +94,14 @@ func (uc *UpdateProjectUseCase) Execute(ctx context.Context, input UpdateProject
 		return nil, errors.New("end date cannot be before start date")
 	}
 
-	if err := uc.repo.Update(ctx, existingProject); err != nil {
+	if err := uc.repo.Update(ctx, existingProject, userID); err != nil {
 		return nil, err
 	}
+	uc.events.Publish(ctx, event.ProjectUpdated{
+		ProjectChange: event.ChangeBy(existingProject.ID, userID),
+		Project:       event.SummarizeProject(existingProject),
+		Previous:      previous,
+	})
 
 	return existingProject, nil
 }
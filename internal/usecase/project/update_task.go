@@ -6,25 +6,44 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/label"
 	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/workspace"
 )
 
 type UpdateTaskUseCase struct {
-	repo project.Repository
+	repo       project.Repository
+	workspaces workspace.Repository
+	members    project.MemberRepository
+	labels     label.Repository
+	events     event.Publisher
 }
 
-func NewUpdateTaskUseCase(repo project.Repository) *UpdateTaskUseCase {
-	return &UpdateTaskUseCase{repo: repo}
+func NewUpdateTaskUseCase(
+	repo project.Repository,
+	workspaces workspace.Repository,
+	members project.MemberRepository,
+	labels label.Repository,
+	events event.Publisher,
+) *UpdateTaskUseCase {
+	return &UpdateTaskUseCase{repo: repo, workspaces: workspaces, members: members, labels: labels, events: events}
 }
 
 type UpdateTaskInput struct {
-	ProjectID string
-	UserID    string
-	TaskID    string
-	Title     *string
-	Status    *project.TaskStatus
-	Priority  *project.TaskPriority
-	DueDate   *time.Time
+	ProjectID     string
+	UserID        string
+	TaskID        string
+	ParentID      *string // moves the task under another task, empty makes it top-level
+	Title         *string // like the other fields, changes only this occurrence of a recurring task
+	Status        *project.TaskStatus
+	Priority      *project.TaskPriority
+	DueDate       *time.Time
+	EstimateHours *float64
+	StoryPoints   *float64
+	AssigneeIDs   *[]string // replaces the assignees when set, empty unassigns everyone
+	LabelIDs      *[]string // replaces the labels when set
+	Force         bool      // changes the status even when dependencies do not allow it
 }
 
 func (uc *UpdateTaskUseCase) Execute(ctx context.Context, input UpdateTaskInput) (*project.Project, error) {
@@ -45,33 +64,126 @@ func (uc *UpdateTaskUseCase) Execute(ctx context.Context, input UpdateTaskInput)
 	if err != nil || p == nil {
 		return nil, errors.New("project not found")
 	}
+	if err := requireEditor(p); err != nil {
+		return nil, err
+	}
+	settings, err := uc.workspaces.SettingsFor(ctx, p.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if input.EstimateHours != nil {
+		if err := validateEstimate(*input.EstimateHours); err != nil {
+			return nil, err
+		}
+	}
+	if input.StoryPoints != nil {
+		if err := validateStoryPoints(*input.StoryPoints); err != nil {
+			return nil, err
+		}
+	}
 
-	found := false
-	for i := range p.Tasks {
-		if p.Tasks[i].ID == input.TaskID {
-			found = true
-			if input.Title != nil {
-				p.Tasks[i].Title = *input.Title
-			}
-			if input.Status != nil {
-				p.Tasks[i].Status = *input.Status
-			}
-			if input.Priority != nil {
-				p.Tasks[i].Priority = *input.Priority
-			}
-			if input.DueDate != nil {
-				p.Tasks[i].DueDate = input.DueDate
-			}
-			break
+	var assignees []string
+	if input.AssigneeIDs != nil {
+		members, err := memberIndex(ctx, uc.members, p.ID)
+		if err != nil {
+			return nil, err
+		}
+		assignees, err = validateAssignees(*input.AssigneeIDs, members)
+		if err != nil {
+			return nil, err
 		}
 	}
-	if !found {
+
+	var labelIDs []string
+	if input.LabelIDs != nil {
+		if labelIDs, err = validateLabels(ctx, uc.labels, p.WorkspaceID, *input.LabelIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	task := findTask(p, input.TaskID)
+	if task == nil {
 		return nil, errors.New("task not found")
 	}
+	previous := *task
+	if input.ParentID != nil && *input.ParentID != task.ParentID {
+		if err := project.CheckParent(p.Tasks, task.ID, *input.ParentID); err != nil {
+			return nil, err
+		}
+		task.ParentID = *input.ParentID
+	}
+	if input.Title != nil {
+		task.Title = *input.Title
+	}
+	if input.Status != nil {
+		if err := changeStatus(p, settings, task, *input.Status, input.Force); err != nil {
+			return nil, err
+		}
+	}
+	if input.Priority != nil {
+		task.Priority = *input.Priority
+	}
+	if input.DueDate != nil {
+		task.DueDate = input.DueDate
+	}
+	if input.EstimateHours != nil {
+		task.EstimateHours = *input.EstimateHours
+	}
+	if input.StoryPoints != nil {
+		task.StoryPoints = *input.StoryPoints
+	}
+	if input.AssigneeIDs != nil {
+		task.AssigneeIDs = assignees
+	}
+	if input.LabelIDs != nil {
+		task.LabelIDs = labelIDs
+	}
+
+	// Completing an occurrence of a recurring task brings up the next one
+	taskID, parentID, previousParentID := task.ID, task.ParentID, previous.ParentID
+	added := spawnOccurrences(p, []project.Task{previous})
+	task = findTask(p, taskID)
+
+	// Parents follow the task's status, both where it was and where it is now
+	var parents []project.Task
+	if task.Status != previous.Status || parentID != previousParentID {
+		parents = append(parents, completeParents(p, settings, parentID)...)
+	}
+	if parentID != previousParentID {
+		parents = append(parents, completeParents(p, settings, previousParentID)...)
+	}
+	added = append(added, spawnOccurrences(p, parents)...)
+	task = findTask(p, taskID)
 
-	p.Progress = project.ProgressFromTasks(p.Tasks)
-	if err := uc.repo.Update(ctx, p); err != nil {
+	p.Progress = project.ProgressFromTasks(p.Tasks, p.Workflow, p.ProgressSettings)
+	if err := uc.repo.Update(ctx, p, userID); err != nil {
 		return nil, err
 	}
+
+	change := event.ChangeBy(p.ID, userID)
+	uc.events.Publish(ctx, append(taskUpdates(change, p, append([]project.Task{previous}, parents...)),
+		occurrencesAdded(change, added)...)...)
 	return p, nil
 }
+
+// changeStatus moves task to status if the workflow, its WIP limits, the parent completion
+// rule and, unless forced, the task's dependencies allow it
+func changeStatus(p *project.Project, settings workspace.Settings, task *project.Task, status project.TaskStatus, force bool) error {
+	if err := p.Workflow.CheckTransition(task.Status, status); err != nil {
+		return err
+	}
+	changed := task.Status != status
+	task.Status = status
+	if changed {
+		if err := p.Workflow.CheckWIP(p.Tasks, task); err != nil {
+			return err
+		}
+	}
+	if err := settings.ParentCompletion.CheckCompletion(p.Tasks, task, p.Workflow); err != nil {
+		return err
+	}
+	if !force && changed {
+		return project.CheckStatus(p.Tasks, task, p.Workflow)
+	}
+	return nil
+}
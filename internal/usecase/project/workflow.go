@@ -0,0 +1,79 @@
+package project
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/event"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+)
+
+type UpdateWorkflowUseCase struct {
+	repo   project.Repository
+	events event.Publisher
+}
+
+func NewUpdateWorkflowUseCase(repo project.Repository, events event.Publisher) *UpdateWorkflowUseCase {
+	return &UpdateWorkflowUseCase{repo: repo, events: events}
+}
+
+type UpdateWorkflowInput struct {
+	ProjectID string
+	UserID    string
+	Workflow  project.Workflow
+	// StatusMap moves tasks off the statuses the new workflow drops, e.g. "qa" to "review"
+	StatusMap map[project.TaskStatus]project.TaskStatus
+}
+
+// Execute replaces a project's workflow; only owners may do this. Every task on a status
+// that goes away has to be moved through StatusMap. WIP limits apply to later moves, so
+// statuses already over their new limit are left alone.
+func (uc *UpdateWorkflowUseCase) Execute(ctx context.Context, input UpdateWorkflowInput) (*project.Project, error) {
+	p, err := loadProject(ctx, uc.repo, input.ProjectID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireOwner(p); err != nil {
+		return nil, err
+	}
+
+	workflow := input.Workflow
+	if err := workflow.Normalize(); err != nil {
+		return nil, err
+	}
+	for from, to := range input.StatusMap {
+		if !workflow.Allows(to) {
+			return nil, fmt.Errorf("cannot move tasks from %s to %s: %w", from, to, project.ErrUnknownStatus)
+		}
+	}
+
+	var moved []project.Task
+	for i := range p.Tasks {
+		t := &p.Tasks[i]
+		if workflow.Allows(t.Status) {
+			continue
+		}
+		to, ok := input.StatusMap[t.Status]
+		if !ok {
+			return nil, fmt.Errorf("tasks still use status %s; choose a status to move them to", t.Status)
+		}
+		moved = append(moved, *t)
+		t.Status = to
+	}
+
+	previous := p.Workflow
+	p.Workflow = workflow
+	added := spawnOccurrences(p, moved)
+	p.Progress = project.ProgressFromTasks(p.Tasks, p.Workflow, p.ProgressSettings)
+
+	actorID, _ := uuid.Parse(input.UserID)
+	if err := uc.repo.Update(ctx, p, actorID); err != nil {
+		return nil, err
+	}
+	change := event.ChangeBy(p.ID, actorID)
+	published := []event.Event{event.WorkflowUpdated{ProjectChange: change, Workflow: p.Workflow, Previous: previous}}
+	published = append(published, taskUpdates(change, p, moved)...)
+	uc.events.Publish(ctx, append(published, occurrencesAdded(change, added)...)...)
+	return p, nil
+}
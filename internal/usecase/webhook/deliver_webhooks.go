@@ -0,0 +1,134 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/webhook"
+)
+
+const (
+	deliveryBatchSize   = 32
+	deliveryConcurrency = 8
+	// deliveryLease must outlast a batch, or another worker would send the same deliveries again
+	deliveryLease   = 5 * time.Minute
+	firstRetryDelay = 30 * time.Second
+	maxRetryDelay   = 12 * time.Hour
+)
+
+// DeliverWebhooksUseCase sends queued deliveries that are due. It is meant to run
+// periodically. Failed attempts are retried with exponential backoff until maxAttempts,
+// and webhooks that keep failing are disabled.
+type DeliverWebhooksUseCase struct {
+	repo         webhook.Repository
+	sender       webhook.Sender
+	maxAttempts  int
+	disableAfter int
+}
+
+func NewDeliverWebhooksUseCase(repo webhook.Repository, sender webhook.Sender, maxAttempts, disableAfter int) *DeliverWebhooksUseCase {
+	return &DeliverWebhooksUseCase{repo: repo, sender: sender, maxAttempts: maxAttempts, disableAfter: disableAfter}
+}
+
+func (uc *DeliverWebhooksUseCase) Execute(ctx context.Context) error {
+	for ctx.Err() == nil {
+		deliveries, err := uc.repo.ClaimDue(ctx, deliveryBatchSize, deliveryLease)
+		if err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		slots := make(chan struct{}, deliveryConcurrency)
+		for i := range deliveries {
+			wg.Add(1)
+			slots <- struct{}{}
+			go func(d *webhook.Delivery) {
+				defer wg.Done()
+				defer func() { <-slots }()
+				if err := uc.deliver(ctx, d); err != nil {
+					log.Printf("Failed to record webhook delivery %s: %v", d.ID, err)
+				}
+			}(&deliveries[i])
+		}
+		wg.Wait()
+
+		if len(deliveries) < deliveryBatchSize {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (uc *DeliverWebhooksUseCase) deliver(ctx context.Context, d *webhook.Delivery) error {
+	w, err := uc.repo.FindForDelivery(ctx, d)
+	if err != nil {
+		return err
+	}
+	if w == nil {
+		return nil
+	}
+	if !w.Active {
+		// Not an attempt, so the webhook's failure count is left alone
+		d.Status = webhook.DeliveryFailed
+		d.NextAttemptAt = nil
+		d.LastError = "webhook is disabled"
+		_, err := uc.repo.RecordAttempt(ctx, d, false, uc.disableAfter)
+		return err
+	}
+
+	start := time.Now()
+	status, body, err := uc.sender.Send(ctx, w.URL, w.Secret, d.ID.String(), d.EventType, []byte(d.Payload))
+	duration := int(time.Since(start).Milliseconds())
+
+	d.Attempts++
+	d.DurationMs = &duration
+	d.ResponseStatus = nil
+	d.ResponseBody = ""
+	d.LastError = ""
+	if err != nil {
+		d.LastError = err.Error()
+	} else {
+		d.ResponseStatus = &status
+		d.ResponseBody = body
+		if status < 200 || status > 299 {
+			d.LastError = fmt.Sprintf("endpoint responded with status %d", status)
+		}
+	}
+
+	failed := d.LastError != ""
+	now := time.Now()
+	switch {
+	case !failed:
+		d.Status = webhook.DeliverySucceeded
+		d.DeliveredAt = &now
+		d.NextAttemptAt = nil
+	case d.Attempts >= uc.maxAttempts:
+		d.Status = webhook.DeliveryFailed
+		d.NextAttemptAt = nil
+	default:
+		next := now.Add(retryDelay(d.Attempts))
+		d.NextAttemptAt = &next
+	}
+
+	disabled, err := uc.repo.RecordAttempt(ctx, d, failed, uc.disableAfter)
+	if err != nil {
+		return err
+	}
+	if disabled {
+		log.Printf("Disabled webhook %s after %d consecutive failed deliveries", w.ID, uc.disableAfter)
+	}
+	return nil
+}
+
+// retryDelay is how long to wait after the given number of failed attempts:
+// 30s, 2m, 8m, 32m and so on, up to 12h
+func retryDelay(attempts int) time.Duration {
+	delay := firstRetryDelay
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 4
+	}
+	return min(delay, maxRetryDelay)
+}
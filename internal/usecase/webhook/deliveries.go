@@ -0,0 +1,101 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/webhook"
+)
+
+const (
+	defaultDeliveryPageSize = 20
+	maxDeliveryPageSize     = 100
+)
+
+type ListDeliveriesUseCase struct {
+	repo webhook.Repository
+}
+
+func NewListDeliveriesUseCase(repo webhook.Repository) *ListDeliveriesUseCase {
+	return &ListDeliveriesUseCase{repo: repo}
+}
+
+type ListDeliveriesInput struct {
+	WebhookID string
+	UserID    string
+	Page      int
+	PageSize  int
+}
+
+type ListDeliveriesResult struct {
+	Deliveries []webhook.Delivery
+	Total      int
+	Page       int
+	PageSize   int
+}
+
+// Execute returns a page of a webhook's delivery log, newest first
+func (uc *ListDeliveriesUseCase) Execute(ctx context.Context, input ListDeliveriesInput) (*ListDeliveriesResult, error) {
+	if input.Page < 1 {
+		input.Page = 1
+	}
+	if input.PageSize < 1 {
+		input.PageSize = defaultDeliveryPageSize
+	}
+	if input.PageSize > maxDeliveryPageSize {
+		input.PageSize = maxDeliveryPageSize
+	}
+
+	w, err := loadWebhook(ctx, uc.repo, input.WebhookID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	deliveries, total, err := uc.repo.ListDeliveries(ctx, w.ID, (input.Page-1)*input.PageSize, input.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	if deliveries == nil {
+		deliveries = []webhook.Delivery{}
+	}
+	return &ListDeliveriesResult{Deliveries: deliveries, Total: total, Page: input.Page, PageSize: input.PageSize}, nil
+}
+
+type RedeliverUseCase struct {
+	repo webhook.Repository
+}
+
+func NewRedeliverUseCase(repo webhook.Repository) *RedeliverUseCase {
+	return &RedeliverUseCase{repo: repo}
+}
+
+// Execute queues a fresh delivery of the same event and payload. It keeps the event ID
+// so receivers can tell it apart from a new event.
+func (uc *RedeliverUseCase) Execute(ctx context.Context, webhookID, userID, deliveryID string) (*webhook.Delivery, error) {
+	w, err := loadWebhook(ctx, uc.repo, webhookID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !w.Active {
+		return nil, errors.New("webhook is disabled; enable it before redelivering")
+	}
+	did, err := uuid.Parse(deliveryID)
+	if err != nil {
+		return nil, errors.New("invalid delivery ID format")
+	}
+	original, err := uc.repo.FindDelivery(ctx, w.ID, did)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := []webhook.Delivery{{
+		WebhookID: w.ID,
+		EventID:   original.EventID,
+		EventType: original.EventType,
+		Payload:   original.Payload,
+	}}
+	if err := uc.repo.Enqueue(ctx, deliveries); err != nil {
+		return nil, err
+	}
+	return &deliveries[0], nil
+}
@@ -0,0 +1,67 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/webhook"
+)
+
+// payload is the JSON body webhooks receive: the project event with an ID receivers
+// can use to spot repeated deliveries
+type payload struct {
+	ID uuid.UUID `json:"id"`
+	project.Event
+}
+
+// Publisher queues a delivery of each project event for every webhook subscribed to it.
+// Deliveries are stored before the change returns, so none are lost if the server stops.
+type Publisher struct {
+	repo webhook.Repository
+}
+
+func NewPublisher(repo webhook.Repository) *Publisher {
+	return &Publisher{repo: repo}
+}
+
+func (p *Publisher) Publish(ctx context.Context, event project.Event) {
+	// The change has happened; a client hanging up must not cancel its deliveries
+	ctx = context.WithoutCancel(ctx)
+
+	webhooks, err := p.repo.FindSubscribed(ctx, event.ProjectID, event.ActorID)
+	if err != nil {
+		log.Printf("Failed to find webhooks for %s event in project %s: %v", event.Type, event.ProjectID, err)
+		return
+	}
+
+	var deliveries []webhook.Delivery
+	var body []byte
+	eventID := uuid.New()
+	for _, w := range webhooks {
+		if !w.Wants(string(event.Type)) {
+			continue
+		}
+		if body == nil {
+			if body, err = json.Marshal(payload{ID: eventID, Event: event}); err != nil {
+				log.Printf("Failed to encode %s event for webhooks: %v", event.Type, err)
+				return
+			}
+		}
+		deliveries = append(deliveries, webhook.Delivery{
+			WebhookID: w.ID,
+			EventID:   eventID,
+			EventType: string(event.Type),
+			Payload:   string(body),
+		})
+	}
+	if len(deliveries) == 0 {
+		return
+	}
+
+	if err := p.repo.Enqueue(ctx, deliveries); err != nil {
+		log.Printf("Failed to queue %d webhook deliveries for %s event: %v", len(deliveries), event.Type, err)
+	}
+}
@@ -0,0 +1,267 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/webhook"
+)
+
+const (
+	maxURLLength         = 2048
+	maxDescriptionLength = 500
+	secretPrefix         = "whsec_"
+)
+
+type CreateWebhookUseCase struct {
+	repo     webhook.Repository
+	projects project.Repository
+	sender   webhook.Sender
+}
+
+func NewCreateWebhookUseCase(repo webhook.Repository, projects project.Repository, sender webhook.Sender) *CreateWebhookUseCase {
+	return &CreateWebhookUseCase{repo: repo, projects: projects, sender: sender}
+}
+
+type CreateWebhookInput struct {
+	UserID      string
+	ProjectID   string // empty subscribes to every project the user is a member of
+	URL         string
+	Events      []string // empty subscribes to every event
+	Description string
+}
+
+// Execute registers a webhook. Only owners may scope one to a project. The returned
+// webhook carries its signing secret, which is not shown again.
+func (uc *CreateWebhookUseCase) Execute(ctx context.Context, input CreateWebhookInput) (*webhook.Webhook, error) {
+	uid, err := uuid.Parse(input.UserID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	w := &webhook.Webhook{UserID: uid, Active: true}
+	if input.ProjectID != "" {
+		pid, err := uuid.Parse(input.ProjectID)
+		if err != nil {
+			return nil, errors.New("invalid project ID format")
+		}
+		p, err := uc.projects.FindByID(ctx, pid, uid)
+		if err != nil {
+			return nil, err
+		}
+		if p == nil {
+			return nil, errors.New("project not found")
+		}
+		if !p.Role.CanManage() {
+			return nil, project.ErrForbidden
+		}
+		w.ProjectID = &p.ID
+	}
+
+	if w.URL, err = validateURL(ctx, uc.sender, input.URL); err != nil {
+		return nil, err
+	}
+	if w.Events, err = validateEvents(input.Events); err != nil {
+		return nil, err
+	}
+	if w.Description, err = validateDescription(input.Description); err != nil {
+		return nil, err
+	}
+	if w.Secret, err = generateSecret(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Create(ctx, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+type ListWebhooksUseCase struct {
+	repo webhook.Repository
+}
+
+func NewListWebhooksUseCase(repo webhook.Repository) *ListWebhooksUseCase {
+	return &ListWebhooksUseCase{repo: repo}
+}
+
+func (uc *ListWebhooksUseCase) Execute(ctx context.Context, userID string) ([]webhook.Webhook, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+	webhooks, err := uc.repo.ListByUser(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	if webhooks == nil {
+		webhooks = []webhook.Webhook{}
+	}
+	return webhooks, nil
+}
+
+type GetWebhookUseCase struct {
+	repo webhook.Repository
+}
+
+func NewGetWebhookUseCase(repo webhook.Repository) *GetWebhookUseCase {
+	return &GetWebhookUseCase{repo: repo}
+}
+
+func (uc *GetWebhookUseCase) Execute(ctx context.Context, id, userID string) (*webhook.Webhook, error) {
+	return loadWebhook(ctx, uc.repo, id, userID)
+}
+
+type UpdateWebhookUseCase struct {
+	repo   webhook.Repository
+	sender webhook.Sender
+}
+
+func NewUpdateWebhookUseCase(repo webhook.Repository, sender webhook.Sender) *UpdateWebhookUseCase {
+	return &UpdateWebhookUseCase{repo: repo, sender: sender}
+}
+
+type UpdateWebhookInput struct {
+	ID           string
+	UserID       string
+	URL          *string
+	Events       *[]string
+	Description  *string
+	Active       *bool
+	RotateSecret bool
+}
+
+// Execute changes a webhook. Turning a disabled webhook back on clears its failure count;
+// deliveries that failed in the meantime can be redelivered. A rotated secret is returned
+// on the webhook and not shown again.
+func (uc *UpdateWebhookUseCase) Execute(ctx context.Context, input UpdateWebhookInput) (*webhook.Webhook, error) {
+	w, err := loadWebhook(ctx, uc.repo, input.ID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.URL != nil {
+		if w.URL, err = validateURL(ctx, uc.sender, *input.URL); err != nil {
+			return nil, err
+		}
+	}
+	if input.Events != nil {
+		if w.Events, err = validateEvents(*input.Events); err != nil {
+			return nil, err
+		}
+	}
+	if input.Description != nil {
+		if w.Description, err = validateDescription(*input.Description); err != nil {
+			return nil, err
+		}
+	}
+	if input.Active != nil && *input.Active != w.Active {
+		w.Active = *input.Active
+		if w.Active {
+			w.ConsecutiveFailures = 0
+			w.DisabledAt = nil
+		}
+	}
+	if input.RotateSecret {
+		if w.Secret, err = generateSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.repo.Update(ctx, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+type DeleteWebhookUseCase struct {
+	repo webhook.Repository
+}
+
+func NewDeleteWebhookUseCase(repo webhook.Repository) *DeleteWebhookUseCase {
+	return &DeleteWebhookUseCase{repo: repo}
+}
+
+// Execute removes a webhook along with its delivery log
+func (uc *DeleteWebhookUseCase) Execute(ctx context.Context, id, userID string) error {
+	w, err := loadWebhook(ctx, uc.repo, id, userID)
+	if err != nil {
+		return err
+	}
+	return uc.repo.Delete(ctx, w.ID)
+}
+
+// loadWebhook finds one of the user's webhooks
+func loadWebhook(ctx context.Context, repo webhook.Repository, id, userID string) (*webhook.Webhook, error) {
+	wid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.New("invalid webhook ID format")
+	}
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+	w, err := repo.FindByID(ctx, wid, uid)
+	if err != nil {
+		return nil, err
+	}
+	if w == nil {
+		return nil, webhook.ErrNotFound
+	}
+	return w, nil
+}
+
+func validateURL(ctx context.Context, sender webhook.Sender, rawURL string) (string, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return "", errors.New("webhook URL is required")
+	}
+	if len(rawURL) > maxURLLength {
+		return "", errors.New("webhook URL is too long")
+	}
+	checkCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := sender.CheckURL(checkCtx, rawURL); err != nil {
+		return "", err
+	}
+	return rawURL, nil
+}
+
+// validateEvents checks and deduplicates an event filter
+func validateEvents(events []string) ([]string, error) {
+	result := []string{}
+	seen := make(map[string]bool, len(events))
+	for _, e := range events {
+		if !project.EventType(e).IsValid() {
+			return nil, fmt.Errorf("unknown event type %q", e)
+		}
+		if !seen[e] {
+			seen[e] = true
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func validateDescription(description string) (string, error) {
+	description = strings.TrimSpace(description)
+	if len([]rune(description)) > maxDescriptionLength {
+		return "", errors.New("description is too long")
+	}
+	return description, nil
+}
+
+func generateSecret() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return secretPrefix + hex.EncodeToString(bytes), nil
+}
@@ -0,0 +1,38 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/workspace"
+)
+
+type CreateWorkspaceUseCase struct {
+	repo workspace.Repository
+}
+
+func NewCreateWorkspaceUseCase(repo workspace.Repository) *CreateWorkspaceUseCase {
+	return &CreateWorkspaceUseCase{repo: repo}
+}
+
+func (uc *CreateWorkspaceUseCase) Execute(ctx context.Context, userID, name string) (*workspace.Workspace, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("workspace name is required")
+	}
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	w := &workspace.Workspace{
+		Name:     name,
+		Settings: workspace.DefaultSettings(),
+	}
+	if err := uc.repo.Create(ctx, w, uid); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
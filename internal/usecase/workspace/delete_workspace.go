@@ -0,0 +1,34 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/workspace"
+)
+
+type DeleteWorkspaceUseCase struct {
+	repo workspace.Repository
+}
+
+func NewDeleteWorkspaceUseCase(repo workspace.Repository) *DeleteWorkspaceUseCase {
+	return &DeleteWorkspaceUseCase{repo: repo}
+}
+
+// Execute deletes an empty team workspace; only owners may do this
+func (uc *DeleteWorkspaceUseCase) Execute(ctx context.Context, workspaceID, userID string) error {
+	if workspaceID == "" {
+		return errors.New("workspace ID is required")
+	}
+	w, err := loadWorkspace(ctx, uc.repo, workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	if w.Role != workspace.RoleOwner {
+		return workspace.ErrForbidden
+	}
+	if w.Personal {
+		return errors.New("personal workspaces cannot be deleted")
+	}
+	return uc.repo.Delete(ctx, w.ID)
+}
@@ -0,0 +1,155 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/label"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/workspace"
+)
+
+type ListLabelsUseCase struct {
+	repo   workspace.Repository
+	labels label.Repository
+}
+
+func NewListLabelsUseCase(repo workspace.Repository, labels label.Repository) *ListLabelsUseCase {
+	return &ListLabelsUseCase{repo: repo, labels: labels}
+}
+
+func (uc *ListLabelsUseCase) Execute(ctx context.Context, workspaceID, userID string) ([]label.Label, error) {
+	w, err := loadWorkspace(ctx, uc.repo, workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := uc.labels.ListByWorkspace(ctx, w.ID)
+	if err != nil {
+		return nil, err
+	}
+	if labels == nil {
+		return []label.Label{}, nil
+	}
+	return labels, nil
+}
+
+type CreateLabelUseCase struct {
+	repo   workspace.Repository
+	labels label.Repository
+}
+
+func NewCreateLabelUseCase(repo workspace.Repository, labels label.Repository) *CreateLabelUseCase {
+	return &CreateLabelUseCase{repo: repo, labels: labels}
+}
+
+type CreateLabelInput struct {
+	WorkspaceID string
+	UserID      string
+	Name        string
+	Color       string
+}
+
+// Execute adds a label; any workspace member may, so labels can be made while tagging
+func (uc *CreateLabelUseCase) Execute(ctx context.Context, input CreateLabelInput) (*label.Label, error) {
+	w, err := loadWorkspace(ctx, uc.repo, input.WorkspaceID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &label.Label{WorkspaceID: w.ID, Name: input.Name, Color: input.Color}
+	if err := l.Normalize(); err != nil {
+		return nil, err
+	}
+	existing, err := uc.labels.ListByWorkspace(ctx, w.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= label.MaxPerWorkspace {
+		return nil, errors.New("this workspace has too many labels")
+	}
+	if err := uc.labels.Create(ctx, l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+type UpdateLabelUseCase struct {
+	repo   workspace.Repository
+	labels label.Repository
+}
+
+func NewUpdateLabelUseCase(repo workspace.Repository, labels label.Repository) *UpdateLabelUseCase {
+	return &UpdateLabelUseCase{repo: repo, labels: labels}
+}
+
+// UpdateLabelInput only changes the fields that are set
+type UpdateLabelInput struct {
+	WorkspaceID string
+	UserID      string
+	LabelID     string
+	Name        *string
+	Color       *string
+}
+
+// Execute renames or recolors a label. Tasks and projects refer to it by ID, so they
+// pick up the change without being rewritten.
+func (uc *UpdateLabelUseCase) Execute(ctx context.Context, input UpdateLabelInput) (*label.Label, error) {
+	l, err := manageableLabel(ctx, uc.repo, uc.labels, input.WorkspaceID, input.UserID, input.LabelID)
+	if err != nil {
+		return nil, err
+	}
+	if input.Name != nil {
+		l.Name = *input.Name
+	}
+	if input.Color != nil {
+		l.Color = *input.Color
+	}
+	if err := l.Normalize(); err != nil {
+		return nil, err
+	}
+	if err := uc.labels.Update(ctx, l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+type DeleteLabelUseCase struct {
+	repo   workspace.Repository
+	labels label.Repository
+}
+
+func NewDeleteLabelUseCase(repo workspace.Repository, labels label.Repository) *DeleteLabelUseCase {
+	return &DeleteLabelUseCase{repo: repo, labels: labels}
+}
+
+// Execute deletes a label and takes it off every task and project that carried it
+func (uc *DeleteLabelUseCase) Execute(ctx context.Context, workspaceID, userID, labelID string) error {
+	l, err := manageableLabel(ctx, uc.repo, uc.labels, workspaceID, userID, labelID)
+	if err != nil {
+		return err
+	}
+	return uc.labels.Delete(ctx, l.ID)
+}
+
+// manageableLabel loads a label of the workspace for a member who may change its labels
+func manageableLabel(ctx context.Context, repo workspace.Repository, labels label.Repository, workspaceID, userID, labelID string) (*label.Label, error) {
+	w, err := loadWorkspace(ctx, repo, workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !w.Role.CanManage() {
+		return nil, workspace.ErrForbidden
+	}
+	id, err := uuid.Parse(labelID)
+	if err != nil {
+		return nil, errors.New("invalid label ID format")
+	}
+	l, err := labels.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if l == nil || l.WorkspaceID != w.ID {
+		return nil, label.ErrNotFound
+	}
+	return l, nil
+}
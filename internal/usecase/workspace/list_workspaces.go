@@ -0,0 +1,37 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/workspace"
+)
+
+type ListWorkspacesUseCase struct {
+	repo workspace.Repository
+}
+
+func NewListWorkspacesUseCase(repo workspace.Repository) *ListWorkspacesUseCase {
+	return &ListWorkspacesUseCase{repo: repo}
+}
+
+// Execute lists the user's workspaces, personal workspace first
+func (uc *ListWorkspacesUseCase) Execute(ctx context.Context, userID string) ([]workspace.Workspace, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure the switcher always has the personal workspace to offer
+	if _, err := personalWorkspace(ctx, uc.repo, uid); err != nil {
+		return nil, err
+	}
+	workspaces, err := uc.repo.FindAllByUserID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	if workspaces == nil {
+		return []workspace.Workspace{}, nil
+	}
+	return workspaces, nil
+}
@@ -0,0 +1,207 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/user"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/workspace"
+)
+
+var errLastOwner = errors.New("a workspace must keep at least one owner")
+
+type ListMembersUseCase struct {
+	repo workspace.Repository
+}
+
+func NewListMembersUseCase(repo workspace.Repository) *ListMembersUseCase {
+	return &ListMembersUseCase{repo: repo}
+}
+
+func (uc *ListMembersUseCase) Execute(ctx context.Context, workspaceID, userID string) ([]workspace.Member, error) {
+	w, err := loadWorkspace(ctx, uc.repo, workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.repo.ListMembers(ctx, w.ID)
+}
+
+type AddMemberUseCase struct {
+	repo  workspace.Repository
+	users user.Repository
+}
+
+func NewAddMemberUseCase(repo workspace.Repository, users user.Repository) *AddMemberUseCase {
+	return &AddMemberUseCase{repo: repo, users: users}
+}
+
+type AddMemberInput struct {
+	WorkspaceID string
+	UserID      string
+	Email       string
+	Role        workspace.Role
+}
+
+func (uc *AddMemberUseCase) Execute(ctx context.Context, input AddMemberInput) (*workspace.Member, error) {
+	if !input.Role.IsValid() {
+		return nil, errors.New("invalid workspace role")
+	}
+
+	w, err := loadWorkspace(ctx, uc.repo, input.WorkspaceID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !w.Role.CanManage() || (input.Role == workspace.RoleOwner && w.Role != workspace.RoleOwner) {
+		return nil, workspace.ErrForbidden
+	}
+	if w.Personal {
+		return nil, errors.New("personal workspaces cannot be shared")
+	}
+
+	u, err := uc.users.FindByEmail(ctx, strings.TrimSpace(input.Email))
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, errors.New("user not found")
+	}
+	memberID, err := uuid.Parse(u.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.repo.FindMember(ctx, w.ID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errors.New("user is already a member of this workspace")
+	}
+
+	m := &workspace.Member{
+		WorkspaceID: w.ID,
+		UserID:      memberID,
+		Email:       u.Email,
+		DisplayName: u.DisplayName,
+		Role:        input.Role,
+	}
+	if err := uc.repo.AddMember(ctx, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+type UpdateMemberRoleUseCase struct {
+	repo workspace.Repository
+}
+
+func NewUpdateMemberRoleUseCase(repo workspace.Repository) *UpdateMemberRoleUseCase {
+	return &UpdateMemberRoleUseCase{repo: repo}
+}
+
+type UpdateMemberRoleInput struct {
+	WorkspaceID  string
+	UserID       string
+	MemberUserID string
+	Role         workspace.Role
+}
+
+// Execute changes a member's role. Admins manage members; only owners may grant or take away ownership.
+func (uc *UpdateMemberRoleUseCase) Execute(ctx context.Context, input UpdateMemberRoleInput) (*workspace.Member, error) {
+	if !input.Role.IsValid() {
+		return nil, errors.New("invalid workspace role")
+	}
+	memberID, err := uuid.Parse(input.MemberUserID)
+	if err != nil {
+		return nil, errors.New("invalid member user ID format")
+	}
+
+	w, err := loadWorkspace(ctx, uc.repo, input.WorkspaceID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !w.Role.CanManage() {
+		return nil, workspace.ErrForbidden
+	}
+
+	m, err := uc.repo.FindMember(ctx, w.ID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return nil, errors.New("member not found")
+	}
+	if (m.Role == workspace.RoleOwner || input.Role == workspace.RoleOwner) && w.Role != workspace.RoleOwner {
+		return nil, workspace.ErrForbidden
+	}
+	if m.Role == workspace.RoleOwner && input.Role != workspace.RoleOwner {
+		if err := ensureAnotherOwner(ctx, uc.repo, w.ID, memberID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.repo.UpdateMemberRole(ctx, w.ID, memberID, input.Role); err != nil {
+		return nil, err
+	}
+	m.Role = input.Role
+	return m, nil
+}
+
+type RemoveMemberUseCase struct {
+	repo workspace.Repository
+}
+
+func NewRemoveMemberUseCase(repo workspace.Repository) *RemoveMemberUseCase {
+	return &RemoveMemberUseCase{repo: repo}
+}
+
+// Execute removes a member. Admins may remove members, anyone may leave.
+func (uc *RemoveMemberUseCase) Execute(ctx context.Context, workspaceID, userID, memberUserID string) error {
+	memberID, err := uuid.Parse(memberUserID)
+	if err != nil {
+		return errors.New("invalid member user ID format")
+	}
+
+	w, err := loadWorkspace(ctx, uc.repo, workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	if w.Personal {
+		return errors.New("you cannot leave your personal workspace")
+	}
+
+	m, err := uc.repo.FindMember(ctx, w.ID, memberID)
+	if err != nil {
+		return err
+	}
+	if m == nil {
+		return errors.New("member not found")
+	}
+	if memberUserID != userID {
+		if !w.Role.CanManage() || (m.Role == workspace.RoleOwner && w.Role != workspace.RoleOwner) {
+			return workspace.ErrForbidden
+		}
+	}
+	if m.Role == workspace.RoleOwner {
+		if err := ensureAnotherOwner(ctx, uc.repo, w.ID, memberID); err != nil {
+			return err
+		}
+	}
+
+	return uc.repo.RemoveMember(ctx, w.ID, memberID)
+}
+
+func ensureAnotherOwner(ctx context.Context, repo workspace.Repository, workspaceID, leaving uuid.UUID) error {
+	all, err := repo.ListMembers(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+	for _, m := range all {
+		if m.Role == workspace.RoleOwner && m.UserID != leaving {
+			return nil
+		}
+	}
+	return errLastOwner
+}
@@ -0,0 +1,75 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/workspace"
+)
+
+const personalWorkspaceName = "Personal"
+
+type ResolveWorkspaceUseCase struct {
+	repo workspace.Repository
+}
+
+func NewResolveWorkspaceUseCase(repo workspace.Repository) *ResolveWorkspaceUseCase {
+	return &ResolveWorkspaceUseCase{repo: repo}
+}
+
+// Execute returns the workspace a request acts in. Without a workspace ID the user's
+// personal workspace is used, creating it on first use.
+func (uc *ResolveWorkspaceUseCase) Execute(ctx context.Context, userID, workspaceID string) (*workspace.Workspace, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	if workspaceID == "" {
+		return personalWorkspace(ctx, uc.repo, uid)
+	}
+	return loadWorkspace(ctx, uc.repo, workspaceID, userID)
+}
+
+// loadWorkspace parses the IDs and loads the workspace as seen by userID
+func loadWorkspace(ctx context.Context, repo workspace.Repository, workspaceID, userID string) (*workspace.Workspace, error) {
+	wid, err := uuid.Parse(workspaceID)
+	if err != nil {
+		return nil, errors.New("invalid workspace ID format")
+	}
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	w, err := repo.FindByID(ctx, wid, uid)
+	if err != nil {
+		return nil, err
+	}
+	if w == nil {
+		return nil, workspace.ErrNotMember
+	}
+	return w, nil
+}
+
+func personalWorkspace(ctx context.Context, repo workspace.Repository, userID uuid.UUID) (*workspace.Workspace, error) {
+	w, err := repo.FindPersonal(ctx, userID)
+	if err != nil || w != nil {
+		return w, err
+	}
+
+	w = &workspace.Workspace{
+		Name:     personalWorkspaceName,
+		Personal: true,
+		Settings: workspace.DefaultSettings(),
+	}
+	if err := repo.Create(ctx, w, userID); err != nil {
+		// Lost a race with a concurrent request creating the same workspace
+		if existing, findErr := repo.FindPersonal(ctx, userID); findErr == nil && existing != nil {
+			return existing, nil
+		}
+		return nil, err
+	}
+	return w, nil
+}
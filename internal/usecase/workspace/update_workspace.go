@@ -0,0 +1,63 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/project"
+	"github.com/tomtom2k/kairo-anchor-server/internal/domain/workspace"
+)
+
+type UpdateWorkspaceUseCase struct {
+	repo workspace.Repository
+}
+
+func NewUpdateWorkspaceUseCase(repo workspace.Repository) *UpdateWorkspaceUseCase {
+	return &UpdateWorkspaceUseCase{repo: repo}
+}
+
+// UpdateWorkspaceInput only changes the fields that are set
+type UpdateWorkspaceInput struct {
+	WorkspaceID         string
+	UserID              string
+	Name                *string
+	DefaultTaskStatuses []project.TaskStatus
+	ProjectCreation     *workspace.ProjectCreationPolicy
+	ParentCompletion    *project.ParentCompletion
+}
+
+func (uc *UpdateWorkspaceUseCase) Execute(ctx context.Context, input UpdateWorkspaceInput) (*workspace.Workspace, error) {
+	w, err := loadWorkspace(ctx, uc.repo, input.WorkspaceID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !w.Role.CanManage() {
+		return nil, workspace.ErrForbidden
+	}
+
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		if name == "" {
+			return nil, errors.New("workspace name is required")
+		}
+		w.Name = name
+	}
+	if input.DefaultTaskStatuses != nil {
+		w.Settings.DefaultTaskStatuses = input.DefaultTaskStatuses
+	}
+	if input.ProjectCreation != nil {
+		w.Settings.ProjectCreation = *input.ProjectCreation
+	}
+	if input.ParentCompletion != nil {
+		w.Settings.ParentCompletion = *input.ParentCompletion
+	}
+	if err := w.Settings.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Update(ctx, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS idx_users_email_revert_token;
+DROP INDEX IF EXISTS idx_users_email_change_token;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS email_revert_token_expires,
+    DROP COLUMN IF EXISTS email_revert_token,
+    DROP COLUMN IF EXISTS previous_email,
+    DROP COLUMN IF EXISTS email_change_token_expires,
+    DROP COLUMN IF EXISTS email_change_token,
+    DROP COLUMN IF EXISTS pending_email;
+
+DROP INDEX IF EXISTS idx_users_email_lower;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
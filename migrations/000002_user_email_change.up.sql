@@ -0,0 +1,14 @@
+-- Email uniqueness is case-insensitive: "Bob@x.com" and "bob@x.com" are the same account.
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_lower ON users (LOWER(email));
+
+ALTER TABLE users
+    ADD COLUMN pending_email              VARCHAR(255),
+    ADD COLUMN email_change_token         VARCHAR(255),
+    ADD COLUMN email_change_token_expires TIMESTAMPTZ,
+    ADD COLUMN previous_email             VARCHAR(255),
+    ADD COLUMN email_revert_token         VARCHAR(255),
+    ADD COLUMN email_revert_token_expires TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_users_email_change_token ON users(email_change_token);
+CREATE INDEX IF NOT EXISTS idx_users_email_revert_token ON users(email_revert_token);
@@ -0,0 +1,7 @@
+ALTER TABLE users
+    ADD COLUMN display_name VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN avatar_url   VARCHAR(2048),
+    ADD COLUMN timezone     VARCHAR(64)  NOT NULL DEFAULT 'UTC',
+    ADD COLUMN locale       VARCHAR(35)  NOT NULL DEFAULT 'en',
+    -- 0 = Sunday ... 6 = Saturday, matching Go's time.Weekday
+    ADD COLUMN week_start   SMALLINT     NOT NULL DEFAULT 1 CHECK (week_start BETWEEN 0 AND 6);
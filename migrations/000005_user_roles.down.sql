@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_users_role;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS password_reset_required,
+    DROP COLUMN IF EXISTS ban_reason,
+    DROP COLUMN IF EXISTS banned_at,
+    DROP COLUMN IF EXISTS role;
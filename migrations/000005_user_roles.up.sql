@@ -0,0 +1,10 @@
+ALTER TABLE users
+    ADD COLUMN role                    VARCHAR(20)  NOT NULL DEFAULT 'user' CHECK (role IN ('user', 'admin')),
+    ADD COLUMN banned_at               TIMESTAMPTZ,
+    ADD COLUMN ban_reason              VARCHAR(500) NOT NULL DEFAULT '',
+    ADD COLUMN password_reset_required BOOLEAN      NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_users_role ON users(role);
+
+-- The first administrator has to be promoted by hand, e.g.:
+--   UPDATE users SET role = 'admin' WHERE LOWER(email) = LOWER('support@kairo-anchor.com');
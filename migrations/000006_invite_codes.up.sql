@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS invite_codes (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code       VARCHAR(64)  NOT NULL UNIQUE,
+    email      VARCHAR(255),
+    max_uses   INTEGER      NOT NULL DEFAULT 1 CHECK (max_uses > 0),
+    uses       INTEGER      NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
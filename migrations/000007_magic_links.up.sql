@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS magic_links (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id     UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email       VARCHAR(255) NOT NULL,
+    token_hash  CHAR(64)     NOT NULL UNIQUE,
+    device_hash CHAR(64),
+    expires_at  TIMESTAMPTZ  NOT NULL,
+    used_at     TIMESTAMPTZ,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_magic_links_email_created_at ON magic_links (LOWER(email), created_at);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS project_members (
+    project_id UUID        NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role       VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (project_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_members_user_id ON project_members(user_id);
+
+-- Every existing project is owned by the user who created it
+INSERT INTO project_members (project_id, user_id, role, created_at)
+SELECT id, user_id, 'owner', created_at FROM projects
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS project_invitations (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_id  UUID         NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    email       VARCHAR(255) NOT NULL,
+    role        VARCHAR(20)  NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+    token       VARCHAR(255) NOT NULL UNIQUE,
+    invited_by  UUID REFERENCES users(id) ON DELETE SET NULL,
+    expires_at  TIMESTAMPTZ  NOT NULL,
+    accepted_at TIMESTAMPTZ,
+    declined_at TIMESTAMPTZ,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_invitations_project_id ON project_invitations(project_id);
@@ -0,0 +1,41 @@
+CREATE TABLE IF NOT EXISTS workspaces (
+    id                UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name              VARCHAR(255) NOT NULL,
+    -- Set only for personal workspaces, one per user
+    personal_owner_id UUID UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    settings          JSONB        NOT NULL DEFAULT '{}',
+    created_at        TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS workspace_members (
+    workspace_id UUID        NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    user_id      UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role         VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'admin', 'member')),
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (workspace_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_workspace_members_user_id ON workspace_members(user_id);
+
+-- Every existing user gets a personal workspace holding the projects they created
+INSERT INTO workspaces (name, personal_owner_id, settings, created_at, updated_at)
+SELECT 'Personal', id,
+       '{"defaultTaskStatuses": ["todo", "in-progress", "completed"], "projectCreation": "members"}',
+       NOW(), NOW()
+FROM users
+ON CONFLICT DO NOTHING;
+
+INSERT INTO workspace_members (workspace_id, user_id, role, created_at)
+SELECT id, personal_owner_id, 'owner', NOW() FROM workspaces WHERE personal_owner_id IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE projects ADD COLUMN workspace_id UUID REFERENCES workspaces(id) ON DELETE RESTRICT;
+
+UPDATE projects p SET workspace_id = w.id
+FROM workspaces w
+WHERE w.personal_owner_id = p.user_id;
+
+ALTER TABLE projects ALTER COLUMN workspace_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_projects_workspace_id ON projects(workspace_id);
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS task_comments (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_id UUID        NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    task_id    VARCHAR(64) NOT NULL,
+    -- Replies always point at the first comment of their thread
+    parent_id  UUID REFERENCES task_comments(id) ON DELETE CASCADE,
+    author_id  UUID REFERENCES users(id) ON DELETE SET NULL,
+    body       TEXT        NOT NULL,
+    mentions   JSONB       NOT NULL DEFAULT '[]',
+    edited_at  TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_comments_task ON task_comments(project_id, task_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_task_comments_parent_id ON task_comments(parent_id);
+
+CREATE TABLE IF NOT EXISTS task_comment_edits (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    comment_id UUID        NOT NULL REFERENCES task_comments(id) ON DELETE CASCADE,
+    -- The body as it was before the edit
+    body       TEXT        NOT NULL,
+    edited_by  UUID REFERENCES users(id) ON DELETE SET NULL,
+    edited_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_comment_edits_comment_id ON task_comment_edits(comment_id, edited_at);
@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS notifications (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type       VARCHAR(32) NOT NULL,
+    title      TEXT        NOT NULL,
+    body       TEXT        NOT NULL DEFAULT '',
+    project_id UUID REFERENCES projects(id) ON DELETE CASCADE,
+    task_id    VARCHAR(64),
+    data       JSONB       NOT NULL DEFAULT '{}',
+    read_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user ON notifications(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_unread ON notifications(user_id) WHERE read_at IS NULL;
+
+-- Only preferences users changed are stored; everything else uses the defaults
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type       VARCHAR(32) NOT NULL,
+    in_app     BOOLEAN     NOT NULL DEFAULT TRUE,
+    email      BOOLEAN     NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, type)
+);
+
+-- One due-soon reminder per assignee, task and due date
+CREATE TABLE IF NOT EXISTS task_due_reminders (
+    user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    project_id UUID        NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    task_id    VARCHAR(64) NOT NULL,
+    due_date   TIMESTAMPTZ NOT NULL,
+    sent_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, project_id, task_id, due_date)
+);
@@ -0,0 +1,38 @@
+-- Webhooks without a project_id receive events from every project their owner belongs to
+CREATE TABLE IF NOT EXISTS webhooks (
+    id                   UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id              UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    project_id           UUID REFERENCES projects(id) ON DELETE CASCADE,
+    url                  TEXT        NOT NULL,
+    secret               TEXT        NOT NULL,
+    events               JSONB       NOT NULL DEFAULT '[]',
+    description          TEXT        NOT NULL DEFAULT '',
+    active               BOOLEAN     NOT NULL DEFAULT TRUE,
+    consecutive_failures INT         NOT NULL DEFAULT 0,
+    disabled_at          TIMESTAMPTZ,
+    created_at           TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at           TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhooks_user ON webhooks(user_id);
+CREATE INDEX IF NOT EXISTS idx_webhooks_project ON webhooks(project_id) WHERE project_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    webhook_id      UUID        NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event_id        UUID        NOT NULL,
+    event_type      VARCHAR(64) NOT NULL,
+    payload         TEXT        NOT NULL,
+    status          VARCHAR(16) NOT NULL DEFAULT 'pending',
+    attempts        INT         NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ,
+    response_status INT,
+    response_body   TEXT        NOT NULL DEFAULT '',
+    last_error      TEXT        NOT NULL DEFAULT '',
+    duration_ms     INT,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    delivered_at    TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
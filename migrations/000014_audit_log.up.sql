@@ -0,0 +1,28 @@
+-- Activity is kept after a project is deleted, so project_id has no foreign key
+CREATE TABLE IF NOT EXISTS project_activity (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_id  UUID        NOT NULL,
+    actor_id    UUID REFERENCES users(id) ON DELETE SET NULL,
+    action      VARCHAR(64) NOT NULL,
+    entity_type VARCHAR(16) NOT NULL,
+    entity_id   VARCHAR(64) NOT NULL,
+    changes     JSONB       NOT NULL DEFAULT '{}',
+    request_id  VARCHAR(64) NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_activity_project ON project_activity(project_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_project_activity_entity ON project_activity(project_id, entity_type, entity_id);
+
+CREATE TABLE IF NOT EXISTS security_events (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    event      VARCHAR(64) NOT NULL,
+    details    JSONB       NOT NULL DEFAULT '{}',
+    ip         VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent TEXT        NOT NULL DEFAULT '',
+    request_id VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_security_events_user ON security_events(user_id, created_at DESC);
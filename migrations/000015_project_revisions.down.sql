@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS project_revisions;
+
+ALTER TABLE projects DROP COLUMN IF EXISTS revision;
@@ -0,0 +1,23 @@
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS revision INTEGER NOT NULL DEFAULT 1;
+
+CREATE TABLE IF NOT EXISTS project_revisions (
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    revision   INTEGER NOT NULL,
+    author_id  UUID REFERENCES users(id) ON DELETE SET NULL,
+    snapshot   JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (project_id, revision)
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_revisions_created_at ON project_revisions(created_at);
+
+-- Existing projects start out with their current state as revision 1
+INSERT INTO project_revisions (project_id, revision, author_id, snapshot, created_at)
+SELECT id, 1, NULL,
+       jsonb_build_object(
+           'name', name, 'description', description, 'status', status, 'progress', progress,
+           'startDate', start_date, 'endDate', end_date, 'tasks', tasks, 'documents', documents
+       ),
+       updated_at
+FROM projects
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS project_labels;
+
+DROP TABLE IF EXISTS labels;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS labels (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    workspace_id UUID        NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    name         VARCHAR(50) NOT NULL,
+    color        VARCHAR(7)  NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_labels_workspace_name ON labels(workspace_id, LOWER(name));
+
+-- Task labels live in the tasks JSON as labelIds; project labels are kept here
+CREATE TABLE IF NOT EXISTS project_labels (
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    label_id   UUID NOT NULL REFERENCES labels(id) ON DELETE CASCADE,
+    PRIMARY KEY (project_id, label_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_labels_label_id ON project_labels(label_id);
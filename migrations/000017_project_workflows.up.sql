@@ -0,0 +1,21 @@
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS workflow JSONB;
+
+-- Existing projects get the statuses their workspace allows, in its order, plus any other
+-- built-in status their tasks still use
+UPDATE projects p
+SET workflow = jsonb_build_object('statuses', (
+    SELECT jsonb_agg(jsonb_build_object('key', b.key, 'name', b.name, 'category', b.category)
+                     ORDER BY COALESCE(d.ord, 100 + b.ord))
+    FROM (VALUES ('todo', 'To do', 'todo', 1),
+                 ('in-progress', 'In progress', 'doing', 2),
+                 ('completed', 'Completed', 'done', 3)) AS b(key, name, category, ord)
+    LEFT JOIN jsonb_array_elements_text(
+        COALESCE(w.settings->'defaultTaskStatuses', '["todo", "in-progress", "completed"]')
+    ) WITH ORDINALITY AS d(key, ord) ON d.key = b.key
+    WHERE d.key IS NOT NULL
+       OR EXISTS (SELECT 1 FROM jsonb_array_elements(p.tasks) AS t(task) WHERE t.task->>'status' = b.key)
+))
+FROM workspaces w
+WHERE w.id = p.workspace_id AND p.workflow IS NULL;
+
+ALTER TABLE projects ALTER COLUMN workflow SET NOT NULL;
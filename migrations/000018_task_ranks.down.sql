@@ -0,0 +1,7 @@
+-- Store the tasks in rank order again, without their ranks
+UPDATE projects p
+SET tasks = (
+    SELECT jsonb_agg(t.task - 'rank' ORDER BY t.task->>'rank' COLLATE "C" NULLS LAST, t.ord)
+    FROM jsonb_array_elements(p.tasks) WITH ORDINALITY AS t(task, ord)
+)
+WHERE jsonb_array_length(p.tasks) > 0;
@@ -0,0 +1,8 @@
+-- Tasks are ordered by a rank key instead of their place in the array. Existing tasks get
+-- evenly spaced keys in their current order; see pkg/lexorank for the format.
+UPDATE projects p
+SET tasks = (
+    SELECT jsonb_agg(jsonb_set(t.task, '{rank}', to_jsonb(lpad(t.ord::text, 6, '0') || 'i')) ORDER BY t.ord)
+    FROM jsonb_array_elements(p.tasks) WITH ORDINALITY AS t(task, ord)
+)
+WHERE jsonb_array_length(p.tasks) > 0;
@@ -0,0 +1,2 @@
+-- How each project works out its progress; existing projects keep counting tasks
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS progress_settings JSONB NOT NULL DEFAULT '{"mode": "count"}';
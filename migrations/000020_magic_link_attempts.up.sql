@@ -0,0 +1,11 @@
+-- Every magic link request, whether or not the email belongs to an account, so rate limits
+-- can't tell registered addresses apart
+CREATE TABLE IF NOT EXISTS magic_link_attempts (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    email      VARCHAR(255) NOT NULL,
+    ip         VARCHAR(64)  NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_magic_link_attempts_email ON magic_link_attempts (LOWER(email), created_at);
+CREATE INDEX IF NOT EXISTS idx_magic_link_attempts_ip ON magic_link_attempts (ip, created_at);
@@ -0,0 +1,11 @@
+-- Single-use tickets for opening a project's event stream, so access tokens stay out of URLs
+CREATE TABLE IF NOT EXISTS stream_tickets (
+    token_hash        CHAR(64)    PRIMARY KEY,
+    user_id           UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    project_id        UUID        NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    expires_at        TIMESTAMPTZ NOT NULL,
+    access_expires_at TIMESTAMPTZ NOT NULL,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_stream_tickets_expires_at ON stream_tickets (expires_at);
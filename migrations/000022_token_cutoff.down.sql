@@ -0,0 +1,2 @@
+ALTER TABLE stream_tickets DROP COLUMN IF EXISTS token_issued_at;
+ALTER TABLE users DROP COLUMN IF EXISTS tokens_valid_after;
@@ -0,0 +1,6 @@
+-- Access tokens issued at or before this time are refused, so a reset forced by support also
+-- ends the sessions that were open
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tokens_valid_after TIMESTAMPTZ;
+
+-- When the access token a stream ticket was issued with was itself issued
+ALTER TABLE stream_tickets ADD COLUMN IF NOT EXISTS token_issued_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
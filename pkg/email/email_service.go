@@ -3,6 +3,7 @@ package email
 import (
 	"fmt"
 	"log"
+	"time"
 )
 
 // MockEmailService logs emails to console for development
@@ -44,3 +45,126 @@ func (s *MockEmailService) SendPasswordResetEmail(email, token string) error {
 	
 	return nil
 }
+
+func (s *MockEmailService) SendEmailChangeConfirmation(newEmail, token string) error {
+	confirmLink := fmt.Sprintf("%s/api/auth/email/confirm?token=%s", s.baseURL, token)
+
+	log.Printf("\n=== CONFIRM EMAIL CHANGE ===")
+	log.Printf("To: %s", newEmail)
+	log.Printf("Subject: Confirm Your New Email Address")
+	log.Printf("Body:")
+	log.Printf("  Please confirm this is your new email address by clicking the link below:")
+	log.Printf("  %s", confirmLink)
+	log.Printf("  Or use this token: %s", token)
+	log.Printf("  This link will expire in 24 hours.")
+	log.Printf("============================\n")
+
+	return nil
+}
+
+func (s *MockEmailService) SendEmailChangedNotice(oldEmail, newEmail, revertToken string) error {
+	revertLink := fmt.Sprintf("%s/api/auth/email/revert?token=%s", s.baseURL, revertToken)
+
+	log.Printf("\n=== EMAIL CHANGED NOTICE ===")
+	log.Printf("To: %s", oldEmail)
+	log.Printf("Subject: Your Email Address Was Changed")
+	log.Printf("Body:")
+	log.Printf("  The email address on your account was changed to %s.", newEmail)
+	log.Printf("  If you did not make this change, undo it by clicking the link below:")
+	log.Printf("  %s", revertLink)
+	log.Printf("  Or use this token: %s", revertToken)
+	log.Printf("  This link will expire in 7 days.")
+	log.Printf("============================\n")
+
+	return nil
+}
+
+func (s *MockEmailService) SendAccountDeletionScheduled(email string, deleteAt time.Time) error {
+	log.Printf("\n=== ACCOUNT DELETION SCHEDULED ===")
+	log.Printf("To: %s", email)
+	log.Printf("Subject: Your Account Will Be Deleted")
+	log.Printf("Body:")
+	log.Printf("  Your account and all of its projects will be permanently deleted on %s.", deleteAt.UTC().Format(time.RFC1123))
+	log.Printf("  Changed your mind? Log in and cancel the deletion before then.")
+	log.Printf("==================================\n")
+
+	return nil
+}
+
+func (s *MockEmailService) SendMagicLinkEmail(email, token string, expiresIn time.Duration) error {
+	signInLink := fmt.Sprintf("%s/api/auth/magic-link/verify?token=%s", s.baseURL, token)
+
+	log.Printf("\n=== MAGIC LINK EMAIL ===")
+	log.Printf("To: %s", email)
+	log.Printf("Subject: Your Sign-in Link")
+	log.Printf("Body:")
+	log.Printf("  Click the link below to sign in, no password needed:")
+	log.Printf("  %s", signInLink)
+	log.Printf("  Or use this token: %s", token)
+	log.Printf("  This link can be used once and will expire in %d minutes.", int(expiresIn.Minutes()))
+	log.Printf("========================\n")
+
+	return nil
+}
+
+func (s *MockEmailService) SendProjectInvitation(email, inviterName, projectName, role, token string, expiresAt time.Time) error {
+	acceptLink := fmt.Sprintf("%s/api/invitations/accept?token=%s", s.baseURL, token)
+	registerLink := fmt.Sprintf("%s/api/auth/register?invitation=%s", s.baseURL, token)
+
+	log.Printf("\n=== PROJECT INVITATION EMAIL ===")
+	log.Printf("To: %s", email)
+	log.Printf("Subject: %s invited you to %s", inviterName, projectName)
+	log.Printf("Body:")
+	log.Printf("  %s invited you to join the project %q as %s.", inviterName, projectName, role)
+	log.Printf("  Already have an account? Accept the invitation here:")
+	log.Printf("  %s", acceptLink)
+	log.Printf("  New to Kairo Anchor? Sign up with this link and you will be added straight away:")
+	log.Printf("  %s", registerLink)
+	log.Printf("  Or use this token: %s", token)
+	log.Printf("  This invitation expires on %s.", expiresAt.UTC().Format(time.RFC1123))
+	log.Printf("================================\n")
+
+	return nil
+}
+
+func (s *MockEmailService) SendTaskAssigned(email, assignerName, projectName, taskTitle string) error {
+	tasksLink := fmt.Sprintf("%s/api/me/tasks", s.baseURL)
+
+	log.Printf("\n=== TASK ASSIGNED EMAIL ===")
+	log.Printf("To: %s", email)
+	log.Printf("Subject: New task in %s: %s", projectName, taskTitle)
+	log.Printf("Body:")
+	log.Printf("  %s assigned you to %q in the project %q.", assignerName, taskTitle, projectName)
+	log.Printf("  See everything assigned to you here:")
+	log.Printf("  %s", tasksLink)
+	log.Printf("===========================\n")
+
+	return nil
+}
+
+func (s *MockEmailService) SendCommentMention(email, authorName, projectName, taskTitle, excerpt string) error {
+	log.Printf("\n=== COMMENT MENTION EMAIL ===")
+	log.Printf("To: %s", email)
+	log.Printf("Subject: %s mentioned you on %s", authorName, taskTitle)
+	log.Printf("Body:")
+	log.Printf("  %s mentioned you in a comment on %q in the project %q:", authorName, taskTitle, projectName)
+	log.Printf("  %s", excerpt)
+	log.Printf("=============================\n")
+
+	return nil
+}
+
+func (s *MockEmailService) SendNotification(email, subject, body string) error {
+	inboxLink := fmt.Sprintf("%s/api/notifications", s.baseURL)
+
+	log.Printf("\n=== NOTIFICATION EMAIL ===")
+	log.Printf("To: %s", email)
+	log.Printf("Subject: %s", subject)
+	log.Printf("Body:")
+	log.Printf("  %s", body)
+	log.Printf("  See all your notifications here:")
+	log.Printf("  %s", inboxLink)
+	log.Printf("==========================\n")
+
+	return nil
+}
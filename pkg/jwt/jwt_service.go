@@ -38,6 +38,13 @@ func (s *JWTService) Generate(userID string) (string, error) {
 }
 
 func (s *JWTService) Validate(tokenString string) (string, error) {
+	userID, _, _, err := s.ValidateWithTimes(tokenString)
+	return userID, err
+}
+
+// ValidateWithTimes also returns when the token was issued, so revoked tokens can be told
+// apart, and when it expires, for connections that outlive a request
+func (s *JWTService) ValidateWithTimes(tokenString string) (string, time.Time, time.Time, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.New("invalid signing method")
@@ -46,12 +53,19 @@ func (s *JWTService) Validate(tokenString string) (string, error) {
 	})
 
 	if err != nil {
-		return "", err
+		return "", time.Time{}, time.Time{}, err
 	}
 
 	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
-		return claims.UserID, nil
+		var issuedAt, expiresAt time.Time
+		if claims.IssuedAt != nil {
+			issuedAt = claims.IssuedAt.Time
+		}
+		if claims.ExpiresAt != nil {
+			expiresAt = claims.ExpiresAt.Time
+		}
+		return claims.UserID, issuedAt, expiresAt, nil
 	}
 
-	return "", errors.New("invalid token")
+	return "", time.Time{}, time.Time{}, errors.New("invalid token")
 }
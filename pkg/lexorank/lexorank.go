@@ -0,0 +1,126 @@
+// Package lexorank generates sortable rank keys for ordered lists. Keys are base 36
+// strings that compare like the fractions 0.key, so there is always another key between
+// two neighbours and moving an item only changes that item's key. Keys grow a little
+// every time the space between two neighbours is split; Spread hands out short, evenly
+// spaced keys again.
+package lexorank
+
+import (
+	"errors"
+	"strings"
+)
+
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+const base = len(digits)
+
+var (
+	ErrInvalid   = errors.New("invalid rank key")
+	ErrNotBefore = errors.New("rank keys are not in order")
+)
+
+// Valid reports whether key is a rank key: base 36 digits in lower case, not ending in 0
+func Valid(key string) bool {
+	if key == "" || key[len(key)-1] == '0' {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if strings.IndexByte(digits, key[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Between returns a key that sorts after a and before b. An empty a stands for the start
+// of the list and an empty b for its end, so Between("", "") is a list's first key.
+func Between(a, b string) (string, error) {
+	if (a != "" && !Valid(a)) || (b != "" && !Valid(b)) {
+		return "", ErrInvalid
+	}
+	if b != "" && a >= b {
+		return "", ErrNotBefore
+	}
+	// Lists mostly grow at either end, where stepping by one digit keeps keys short for
+	// longer than splitting the remaining space in half
+	if a != "" && b == "" {
+		if i := strings.IndexFunc(a, func(r rune) bool { return r != 'z' }); i >= 0 {
+			return a[:i] + string(digits[strings.IndexByte(digits, a[i])+1]), nil
+		}
+	}
+	if a == "" && b != "" {
+		if i := strings.IndexFunc(b, func(r rune) bool { return r != '0' }); i >= 0 && b[i] != '1' {
+			return b[:i] + string(digits[strings.IndexByte(digits, b[i])-1]), nil
+		}
+	}
+	return midpoint(a, b), nil
+}
+
+// midpoint finds a key between a and b, where b is empty for the end of the list. Missing
+// digits of a count as 0, which is why keys never end in 0.
+func midpoint(a, b string) string {
+	if b != "" {
+		n := 0
+		for n < len(b) && digitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			return b[:n] + midpoint(tail(a, n), b[n:])
+		}
+	}
+
+	low := 0
+	if a != "" {
+		low = strings.IndexByte(digits, a[0])
+	}
+	high := base
+	if b != "" {
+		high = strings.IndexByte(digits, b[0])
+	}
+	if high-low > 1 {
+		return string(digits[(low+high)/2])
+	}
+	// The first digits are neighbours: b's first digit alone is enough if b goes on,
+	// otherwise keep a's first digit and look further along a
+	if len(b) > 1 {
+		return b[:1]
+	}
+	return string(digits[low]) + midpoint(tail(a, 1), "")
+}
+
+func digitAt(key string, i int) byte {
+	if i < len(key) {
+		return key[i]
+	}
+	return '0'
+}
+
+func tail(key string, n int) string {
+	if n >= len(key) {
+		return ""
+	}
+	return key[n:]
+}
+
+// Spread returns n increasing keys spaced evenly over the whole range, all of the same
+// short length except where trailing zeros are dropped
+func Spread(n int) []string {
+	width, size := 1, base
+	for size <= n*base {
+		width++
+		size *= base
+	}
+	step := size / (n + 1)
+
+	keys := make([]string, n)
+	buf := make([]byte, width)
+	for i := range keys {
+		v := (i + 1) * step
+		for d := width - 1; d >= 0; d-- {
+			buf[d] = digits[v%base]
+			v /= base
+		}
+		keys[i] = strings.TrimRight(string(buf), "0")
+	}
+	return keys
+}
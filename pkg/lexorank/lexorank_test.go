@@ -0,0 +1,155 @@
+package lexorank
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    string
+		want    string
+		wantErr error
+	}{
+		{name: "empty list", a: "", b: "", want: "i"},
+		{name: "append steps one digit", a: "i", b: "", want: "j"},
+		{name: "prepend steps one digit", a: "", b: "i", want: "h"},
+		{name: "append after the last digit", a: "z", b: "", want: "zi"},
+		{name: "append after all last digits", a: "zz", b: "", want: "zzi"},
+		{name: "prepend before the first key", a: "", b: "1", want: "0i"},
+		{name: "prepend before a leading zero", a: "", b: "01", want: "00i"},
+		{name: "room in between", a: "i", b: "k", want: "j"},
+		{name: "neighbours", a: "i", b: "j", want: "ii"},
+		{name: "b extends a", a: "i", b: "i1", want: "i0i"},
+		{name: "different lengths", a: "az", b: "b", want: "azi"},
+		{name: "equal keys", a: "i", b: "i", wantErr: ErrNotBefore},
+		{name: "keys out of order", a: "j", b: "i", wantErr: ErrNotBefore},
+		{name: "trailing zero", a: "i0", b: "", wantErr: ErrInvalid},
+		{name: "upper case", a: "", b: "I", wantErr: ErrInvalid},
+		{name: "not a digit", a: "i-", b: "", wantErr: ErrInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Between(tt.a, tt.b)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Between(%q, %q) error = %v, want %v", tt.a, tt.b, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Between(%q, %q): %v", tt.a, tt.b, err)
+			}
+			if got != tt.want {
+				t.Errorf("Between(%q, %q) = %q, want %q", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBetweenRepeatedly(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string                                  // the first bounds
+		next func(a, b, key string) (string, string) // the bounds of the next split
+	}{
+		{name: "always after the new key", a: "i", b: "j", next: func(a, b, key string) (string, string) { return key, b }},
+		{name: "always before the new key", a: "i", b: "j", next: func(a, b, key string) (string, string) { return a, key }},
+		{name: "alternating", a: "i", b: "j", next: func(a, b, key string) (string, string) {
+			if len(key)%2 == 0 {
+				return key, b
+			}
+			return a, key
+		}},
+		{name: "appending", next: func(a, b, key string) (string, string) { return key, "" }},
+		{name: "prepending", next: func(a, b, key string) (string, string) { return "", key }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := tt.a, tt.b
+			for i := 0; i < 500; i++ {
+				key, err := Between(a, b)
+				if err != nil {
+					t.Fatalf("split %d: Between(%q, %q): %v", i, a, b, err)
+				}
+				if !Valid(key) || (a != "" && key <= a) || (b != "" && key >= b) {
+					t.Fatalf("split %d: Between(%q, %q) = %q is not between them", i, a, b, key)
+				}
+				a, b = tt.next(a, b, key)
+			}
+		})
+	}
+}
+
+func TestSpread(t *testing.T) {
+	tests := []struct {
+		n         int
+		maxLength int
+	}{
+		{n: 0, maxLength: 0},
+		{n: 1, maxLength: 2},
+		{n: 3, maxLength: 2},
+		{n: 35, maxLength: 2},
+		{n: 36, maxLength: 3},
+		{n: 1000, maxLength: 3},
+		{n: 1296, maxLength: 4},
+		{n: 10000, maxLength: 4},
+	}
+
+	for _, tt := range tests {
+		keys := Spread(tt.n)
+		if len(keys) != tt.n {
+			t.Errorf("Spread(%d) returned %d keys", tt.n, len(keys))
+			continue
+		}
+		for i, key := range keys {
+			if !Valid(key) {
+				t.Errorf("Spread(%d)[%d] = %q is not valid", tt.n, i, key)
+			}
+			if len(key) > tt.maxLength {
+				t.Errorf("Spread(%d)[%d] = %q is longer than %d", tt.n, i, key, tt.maxLength)
+			}
+		}
+		if !slices.IsSorted(keys) || len(slices.Compact(slices.Clone(keys))) != len(keys) {
+			t.Errorf("Spread(%d) keys do not increase", tt.n)
+		}
+		// Every gap, including those at either end, leaves room for another key
+		for i := 0; i <= len(keys); i++ {
+			a, b := "", ""
+			if i > 0 {
+				a = keys[i-1]
+			}
+			if i < len(keys) {
+				b = keys[i]
+			}
+			if key, err := Between(a, b); err != nil || len(key) > tt.maxLength+1 {
+				t.Errorf("Spread(%d): Between(%q, %q) = %q, %v", tt.n, a, b, key, err)
+			}
+		}
+	}
+}
+
+func TestValid(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"i", true},
+		{"0i", true},
+		{"z9", true},
+		{"", false},
+		{"0", false},
+		{"i0", false},
+		{"I", false},
+		{"i i", false},
+	}
+	for _, tt := range tests {
+		if got := Valid(tt.key); got != tt.want {
+			t.Errorf("Valid(%q) = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+// Package mention finds @mentions in markdown text.
+package mention
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	fencedCode = regexp.MustCompile("(?s)```.*?```|~~~.*?~~~")
+	inlineCode = regexp.MustCompile("`[^`\n]*`")
+	// A mention is either @name or @email, and must not be glued to a preceding word
+	handle = regexp.MustCompile(`(?:^|[^\w@.])@([\w.+-]+(?:@[\w-]+(?:\.[\w-]+)+)?)`)
+)
+
+// Parse returns the handles mentioned in a markdown body, without the leading @,
+// in order of first appearance. Mentions inside code spans and blocks are ignored.
+func Parse(markdown string) []string {
+	text := fencedCode.ReplaceAllString(markdown, " ")
+	text = inlineCode.ReplaceAllString(text, " ")
+
+	seen := make(map[string]bool)
+	var handles []string
+	for _, m := range handle.FindAllStringSubmatch(text, -1) {
+		h := strings.TrimRight(m[1], ".-")
+		key := strings.ToLower(h)
+		if h == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		handles = append(handles, h)
+	}
+	return handles
+}
+
+// IsEmail reports whether a handle returned by Parse is an email address
+func IsEmail(handle string) bool {
+	return strings.Contains(handle, "@")
+}
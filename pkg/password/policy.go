@@ -0,0 +1,105 @@
+package password
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+//go:embed common_passwords.txt
+var commonPasswordList string
+
+var commonPasswords = func() map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, line := range strings.Split(commonPasswordList, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			set[strings.ToLower(line)] = struct{}{}
+		}
+	}
+	return set
+}()
+
+// bcrypt ignores everything after 72 bytes
+const maxLength = 72
+
+// Policy describes what an acceptable password looks like
+type Policy struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	RejectCommon  bool
+}
+
+// Violation is one rule a password failed
+type Violation struct {
+	Rule    string `json:"rule" example:"min_length"`
+	Message string `json:"message" example:"must be at least 8 characters long"`
+}
+
+// ValidationError lists every rule a password failed, so clients can show them all at once
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = v.Message
+	}
+	return "password " + strings.Join(msgs, ", ")
+}
+
+// Validate returns a *ValidationError if the password breaks any rule of the policy
+func (p Policy) Validate(pw string) error {
+	var violations []Violation
+	add := func(rule, msg string) {
+		violations = append(violations, Violation{Rule: rule, Message: msg})
+	}
+
+	if n := len([]rune(pw)); n < p.MinLength {
+		add("min_length", fmt.Sprintf("must be at least %d characters long", p.MinLength))
+	}
+	if len(pw) > maxLength {
+		add("max_length", fmt.Sprintf("must be at most %d bytes long", maxLength))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range pw {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+	if p.RequireUpper && !hasUpper {
+		add("uppercase", "must contain an uppercase letter")
+	}
+	if p.RequireLower && !hasLower {
+		add("lowercase", "must contain a lowercase letter")
+	}
+	if p.RequireDigit && !hasDigit {
+		add("digit", "must contain a digit")
+	}
+	if p.RequireSymbol && !hasSymbol {
+		add("symbol", "must contain a symbol")
+	}
+
+	if p.RejectCommon {
+		if _, ok := commonPasswords[strings.ToLower(pw)]; ok {
+			add("common", "is too common")
+		}
+	}
+
+	if len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
+	return nil
+}
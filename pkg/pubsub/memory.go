@@ -0,0 +1,21 @@
+package pubsub
+
+import "context"
+
+// Memory is a Broker for a single server instance
+type Memory struct {
+	hub *hub
+}
+
+func NewMemory() *Memory {
+	return &Memory{hub: newHub()}
+}
+
+func (m *Memory) Publish(ctx context.Context, topic string, payload []byte) error {
+	m.hub.deliver(topic, payload)
+	return nil
+}
+
+func (m *Memory) Subscribe(topic string) *Subscription {
+	return m.hub.subscribe(topic)
+}
@@ -0,0 +1,98 @@
+package pubsub
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// maxPayload stays below the 8000 byte limit Postgres puts on NOTIFY payloads,
+// leaving room for the topic
+const maxPayload = 7500
+
+var ErrPayloadTooLarge = errors.New("pubsub: payload too large")
+
+// Postgres is a Broker shared by every server instance connected to the same database.
+// Messages go out with NOTIFY on one channel and come back to each instance, including
+// the publisher, through a dedicated LISTEN connection. Payloads must be text.
+type Postgres struct {
+	db      *sql.DB
+	dsn     string
+	channel string
+	hub     *hub
+}
+
+// NewPostgres starts listening on channel until ctx is cancelled. The listener reconnects
+// on its own; messages published while it is disconnected are lost.
+func NewPostgres(ctx context.Context, db *sql.DB, dsn, channel string) *Postgres {
+	p := &Postgres{db: db, dsn: dsn, channel: channel, hub: newHub()}
+	go p.listen(ctx)
+	return p
+}
+
+func (p *Postgres) Publish(ctx context.Context, topic string, payload []byte) error {
+	if len(payload)+len(topic) > maxPayload {
+		return ErrPayloadTooLarge
+	}
+	_, err := p.db.ExecContext(ctx, `SELECT pg_notify($1, $2)`, p.channel, topic+"\n"+string(payload))
+	return err
+}
+
+func (p *Postgres) Subscribe(topic string) *Subscription {
+	return p.hub.subscribe(topic)
+}
+
+func (p *Postgres) listen(ctx context.Context) {
+	backoff := time.Second
+	for {
+		started := time.Now()
+		err := p.receive(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		// A connection that held up for a while starts the backoff over
+		if time.Since(started) > time.Minute {
+			backoff = time.Second
+		}
+		log.Printf("[PUBSUB] listener on %s stopped: %v, reconnecting in %s", p.channel, err, backoff)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff < 30*time.Second {
+			backoff *= 2
+		}
+	}
+}
+
+// receive holds one LISTEN connection and delivers notifications until it fails
+func (p *Postgres) receive(ctx context.Context) error {
+	conn, err := pgx.Connect(ctx, p.dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{p.channel}.Sanitize()); err != nil {
+		return err
+	}
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		topic, payload, ok := strings.Cut(n.Payload, "\n")
+		if !ok {
+			continue
+		}
+		p.hub.deliver(topic, []byte(payload))
+	}
+}
@@ -0,0 +1,74 @@
+// Package pubsub fans messages out to subscribers of a topic, either within one
+// process or across server instances through Postgres LISTEN/NOTIFY.
+package pubsub
+
+import (
+	"context"
+	"sync"
+)
+
+// subscriberBuffer is how many messages a slow subscriber may fall behind before
+// further messages are dropped for it
+const subscriberBuffer = 64
+
+// Broker delivers messages published on a topic to everyone subscribed to it.
+// Delivery is best effort: messages are not stored and slow subscribers miss messages.
+type Broker interface {
+	Publish(ctx context.Context, topic string, payload []byte) error
+	Subscribe(topic string) *Subscription
+}
+
+// Subscription receives the messages of one topic until it is closed
+type Subscription struct {
+	C <-chan []byte
+
+	once  sync.Once
+	close func()
+}
+
+// Close stops the subscription and closes C
+func (s *Subscription) Close() {
+	s.once.Do(s.close)
+}
+
+// hub keeps the subscribers of this process
+type hub struct {
+	mu     sync.RWMutex
+	topics map[string]map[chan []byte]struct{}
+}
+
+func newHub() *hub {
+	return &hub{topics: make(map[string]map[chan []byte]struct{})}
+}
+
+func (h *hub) subscribe(topic string) *Subscription {
+	ch := make(chan []byte, subscriberBuffer)
+
+	h.mu.Lock()
+	if h.topics[topic] == nil {
+		h.topics[topic] = make(map[chan []byte]struct{})
+	}
+	h.topics[topic][ch] = struct{}{}
+	h.mu.Unlock()
+
+	return &Subscription{C: ch, close: func() {
+		h.mu.Lock()
+		delete(h.topics[topic], ch)
+		if len(h.topics[topic]) == 0 {
+			delete(h.topics, topic)
+		}
+		h.mu.Unlock()
+		close(ch)
+	}}
+}
+
+func (h *hub) deliver(topic string, payload []byte) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for ch := range h.topics[topic] {
+		select {
+		case ch <- payload:
+		default:
+		}
+	}
+}
@@ -0,0 +1,23 @@
+// Package requestinfo carries details of the HTTP request being served through a context,
+// so logs and audit records can be traced back to it.
+package requestinfo
+
+import "context"
+
+type Info struct {
+	ID        string
+	IP        string
+	UserAgent string
+}
+
+type contextKey struct{}
+
+func NewContext(ctx context.Context, info Info) context.Context {
+	return context.WithValue(ctx, contextKey{}, info)
+}
+
+// FromContext returns the request info, empty outside a request such as in background jobs
+func FromContext(ctx context.Context) Info {
+	info, _ := ctx.Value(contextKey{}).(Info)
+	return info
+}
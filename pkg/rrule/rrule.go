@@ -0,0 +1,410 @@
+// Package rrule parses and expands the part of RFC 5545 recurrence rules that recurring
+// tasks need: DAILY, WEEKLY and MONTHLY rules with INTERVAL, BYDAY, BYMONTHDAY, COUNT,
+// UNTIL and WKST.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+// maxPeriods stops expansion of rules that can never produce another occurrence
+const maxPeriods = 100000
+
+var dayCodes = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Weekday is one BYDAY entry. In monthly rules N picks the nth such day of the month,
+// counting from the end when negative; 0 means every such day.
+type Weekday struct {
+	N   int
+	Day time.Weekday
+}
+
+func (w Weekday) String() string {
+	if w.N == 0 {
+		return dayCodes[w.Day]
+	}
+	return strconv.Itoa(w.N) + dayCodes[w.Day]
+}
+
+// Rule is a parsed recurrence rule. The first occurrence is always the start time the
+// rule is expanded from (DTSTART); later ones keep its time of day in its location.
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	ByDay      []Weekday
+	ByMonthDay []int
+	Count      int
+	Until      time.Time
+	WeekStart  time.Weekday
+
+	// untilLayout is how UNTIL was written. Dates and local times without a trailing Z
+	// are read in the start time's location.
+	untilLayout string
+}
+
+const (
+	untilDate     = "20060102"
+	untilLocal    = "20060102T150405"
+	untilUTC      = "20060102T150405Z"
+	maxInterval   = 1000
+	maxOrdinalDay = 5
+)
+
+// Parse reads a rule such as "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10". A leading "RRULE:" is allowed.
+func Parse(s string) (*Rule, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	s = strings.TrimPrefix(s, "RRULE:")
+	if s == "" {
+		return nil, errors.New("recurrence rule is empty")
+	}
+
+	r := &Rule{Interval: 1, WeekStart: time.Monday}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid rule part %q", part)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("%s is given more than once", name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			r.Freq = Frequency(value)
+			if r.Freq != Daily && r.Freq != Weekly && r.Freq != Monthly {
+				err = fmt.Errorf("unsupported frequency %s", value)
+			}
+		case "INTERVAL":
+			r.Interval, err = parseInt(value, 1, maxInterval)
+		case "COUNT":
+			r.Count, err = parseInt(value, 1, maxPeriods)
+		case "UNTIL":
+			err = r.parseUntil(value)
+		case "BYDAY":
+			for _, v := range strings.Split(value, ",") {
+				day, dayErr := parseWeekday(v)
+				if dayErr != nil {
+					err = dayErr
+					break
+				}
+				r.ByDay = append(r.ByDay, day)
+			}
+		case "BYMONTHDAY":
+			for _, v := range strings.Split(value, ",") {
+				day, dayErr := parseInt(v, -31, 31)
+				if dayErr != nil || day == 0 {
+					err = fmt.Errorf("invalid BYMONTHDAY %q", v)
+					break
+				}
+				r.ByMonthDay = append(r.ByMonthDay, day)
+			}
+		case "WKST":
+			day, dayErr := parseWeekday(value)
+			if dayErr != nil || day.N != 0 {
+				err = fmt.Errorf("invalid WKST %q", value)
+			}
+			r.WeekStart = day.Day
+		default:
+			err = fmt.Errorf("%s is not supported", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case r.Freq == "":
+		return nil, errors.New("FREQ is required")
+	case r.Count > 0 && r.untilLayout != "":
+		return nil, errors.New("COUNT and UNTIL cannot be used together")
+	case r.Freq == Weekly && len(r.ByMonthDay) > 0:
+		return nil, errors.New("BYMONTHDAY cannot be used with weekly rules")
+	}
+	if r.Freq != Monthly {
+		for _, d := range r.ByDay {
+			if d.N != 0 {
+				return nil, errors.New("numbered BYDAY values are only allowed in monthly rules")
+			}
+		}
+	}
+	return r, nil
+}
+
+// String writes the rule back in its canonical form
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, d := range r.ByDay {
+			days[i] = d.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, d := range r.ByMonthDay {
+			days[i] = strconv.Itoa(d)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.untilLayout != "" {
+		parts = append(parts, "UNTIL="+r.Until.Format(r.untilLayout))
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+dayCodes[r.WeekStart])
+	}
+	return strings.Join(parts, ";")
+}
+
+// After returns the first occurrence later than t of the rule started at start. It
+// reports false once the rule has no more occurrences.
+func (r *Rule) After(start, t time.Time) (time.Time, bool) {
+	it := r.Iterate(start)
+	for {
+		next, ok := it.Next()
+		if !ok || next.After(t) {
+			return next, ok
+		}
+	}
+}
+
+// Iterator walks the occurrences of a rule in order
+type Iterator struct {
+	rule    *Rule
+	start   time.Time
+	until   time.Time
+	period  int
+	pending []time.Time
+	emitted int
+	done    bool
+}
+
+// Iterate expands the rule from start, which is its first occurrence
+func (r *Rule) Iterate(start time.Time) *Iterator {
+	it := &Iterator{rule: r, start: start}
+	if r.untilLayout != "" {
+		it.until = r.Until
+		if r.untilLayout != untilUTC {
+			u := r.Until
+			it.until = time.Date(u.Year(), u.Month(), u.Day(), u.Hour(), u.Minute(), u.Second(), 0, start.Location())
+		}
+	}
+	return it
+}
+
+// Next returns the next occurrence, or false when there are no more
+func (it *Iterator) Next() (time.Time, bool) {
+	if it.done {
+		return time.Time{}, false
+	}
+
+	var next time.Time
+	if it.emitted == 0 {
+		next = it.start
+	} else {
+		for len(it.pending) == 0 {
+			if it.period >= maxPeriods {
+				it.done = true
+				return time.Time{}, false
+			}
+			for _, t := range it.rule.period(it.start, it.period) {
+				if t.After(it.start) {
+					it.pending = append(it.pending, t)
+				}
+			}
+			it.period++
+		}
+		next, it.pending = it.pending[0], it.pending[1:]
+	}
+
+	if !it.until.IsZero() && next.After(it.until) {
+		it.done = true
+		return time.Time{}, false
+	}
+	it.emitted++
+	if it.rule.Count > 0 && it.emitted >= it.rule.Count {
+		it.done = true
+	}
+	return next, true
+}
+
+// period lists the candidate occurrences in the nth day, week or month after start's, in order
+func (r *Rule) period(start time.Time, n int) []time.Time {
+	y, m, d := start.Date()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+	}
+
+	switch r.Freq {
+	case Daily:
+		t := at(y, m, d+n*r.Interval)
+		if r.matchesWeekday(t) && r.matchesMonthDay(t) {
+			return []time.Time{t}
+		}
+		return nil
+
+	case Weekly:
+		offset := (int(start.Weekday()) - int(r.WeekStart) + 7) % 7
+		weekStart := d - offset + 7*n*r.Interval
+		var times []time.Time
+		for i := 0; i < 7; i++ {
+			t := at(y, m, weekStart+i)
+			if len(r.ByDay) == 0 && t.Weekday() == start.Weekday() || len(r.ByDay) > 0 && r.matchesWeekday(t) {
+				times = append(times, t)
+			}
+		}
+		return times
+
+	default:
+		first := time.Date(y, m+time.Month(n*r.Interval), 1, 0, 0, 0, 0, time.UTC)
+		days := r.monthDays(first.Year(), first.Month(), d)
+		times := make([]time.Time, len(days))
+		for i, day := range days {
+			times[i] = at(first.Year(), first.Month(), day)
+		}
+		return times
+	}
+}
+
+// monthDays lists the days of a month a monthly rule falls on, in order
+func (r *Rule) monthDays(year int, month time.Month, startDay int) []int {
+	length := daysIn(year, month)
+	var days []int
+	switch {
+	case len(r.ByMonthDay) > 0:
+		for _, d := range r.ByMonthDay {
+			if d < 0 {
+				d = length + d + 1
+			}
+			if d < 1 || d > length {
+				continue
+			}
+			if len(r.ByDay) == 0 || r.matchesNthWeekday(year, month, d) {
+				days = append(days, d)
+			}
+		}
+	case len(r.ByDay) > 0:
+		for d := 1; d <= length; d++ {
+			if r.matchesNthWeekday(year, month, d) {
+				days = append(days, d)
+			}
+		}
+	case startDay <= length:
+		// Months without the start's day are skipped rather than moved
+		days = append(days, startDay)
+	}
+	slices.Sort(days)
+	return slices.Compact(days)
+}
+
+func (r *Rule) matchesWeekday(t time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, w := range r.ByDay {
+		if w.Day == t.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) matchesMonthDay(t time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	length := daysIn(t.Year(), t.Month())
+	for _, d := range r.ByMonthDay {
+		if d == t.Day() || d < 0 && length+d+1 == t.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) matchesNthWeekday(year int, month time.Month, day int) bool {
+	weekday := time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday()
+	length := daysIn(year, month)
+	for _, w := range r.ByDay {
+		if w.Day != weekday {
+			continue
+		}
+		switch {
+		case w.N == 0,
+			w.N > 0 && (day-1)/7+1 == w.N,
+			w.N < 0 && (length-day)/7+1 == -w.N:
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) parseUntil(value string) error {
+	for _, layout := range []string{untilUTC, untilLocal, untilDate} {
+		t, err := time.Parse(layout, value)
+		if err != nil {
+			continue
+		}
+		if layout == untilDate {
+			// A date includes the whole day
+			t = t.Add(24*time.Hour - time.Second)
+		}
+		r.Until, r.untilLayout = t, layout
+		return nil
+	}
+	return fmt.Errorf("invalid UNTIL %q", value)
+}
+
+func parseWeekday(s string) (Weekday, error) {
+	if len(s) < 2 {
+		return Weekday{}, fmt.Errorf("invalid BYDAY %q", s)
+	}
+	code := s[len(s)-2:]
+	day := slices.Index(dayCodes[:], code)
+	if day < 0 {
+		return Weekday{}, fmt.Errorf("invalid BYDAY %q", s)
+	}
+	w := Weekday{Day: time.Weekday(day)}
+	if ordinal := strings.TrimPrefix(s[:len(s)-2], "+"); ordinal != "" {
+		n, err := parseInt(ordinal, -maxOrdinalDay, maxOrdinalDay)
+		if err != nil || n == 0 {
+			return Weekday{}, fmt.Errorf("invalid BYDAY %q", s)
+		}
+		w.N = n
+	}
+	return w, nil
+}
+
+func parseInt(s string, min, max int) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < min || n > max {
+		return 0, fmt.Errorf("%q must be a number from %d to %d", s, min, max)
+	}
+	return n, nil
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
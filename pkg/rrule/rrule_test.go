@@ -0,0 +1,238 @@
+package rrule
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// expand returns up to limit occurrences of rule from start as dates
+func expand(t *testing.T, rule string, start time.Time, limit int) []string {
+	t.Helper()
+	r, err := Parse(rule)
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", rule, err)
+	}
+	var dates []string
+	it := r.Iterate(start)
+	for len(dates) < limit {
+		next, ok := it.Next()
+		if !ok {
+			break
+		}
+		dates = append(dates, next.Format("2006-01-02"))
+	}
+	return dates
+}
+
+func TestIterate(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name  string
+		rule  string
+		start time.Time
+		want  []string
+	}{
+		{
+			name:  "daily with interval",
+			rule:  "FREQ=DAILY;INTERVAL=2;COUNT=3",
+			start: date(2024, 1, 1),
+			want:  []string{"2024-01-01", "2024-01-03", "2024-01-05"},
+		},
+		{
+			name:  "daily on weekdays",
+			rule:  "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR;COUNT=3",
+			start: date(2024, 1, 5),
+			want:  []string{"2024-01-05", "2024-01-08", "2024-01-09"},
+		},
+		{
+			name:  "weekly by day",
+			rule:  "FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=5",
+			start: date(2024, 1, 1),
+			want:  []string{"2024-01-01", "2024-01-03", "2024-01-05", "2024-01-08", "2024-01-10"},
+		},
+		{
+			name:  "fortnightly by day, starting on another day",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;COUNT=4",
+			start: date(2024, 1, 1),
+			want:  []string{"2024-01-01", "2024-01-02", "2024-01-04", "2024-01-16"},
+		},
+		{
+			name:  "week start decides which weeks an interval skips",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=SU,TU;WKST=SU;COUNT=4",
+			start: date(2024, 1, 2),
+			want:  []string{"2024-01-02", "2024-01-14", "2024-01-16", "2024-01-28"},
+		},
+		{
+			name:  "until a date includes the whole day",
+			rule:  "FREQ=DAILY;UNTIL=20240103",
+			start: date(2024, 1, 1),
+			want:  []string{"2024-01-01", "2024-01-02", "2024-01-03"},
+		},
+		{
+			name:  "until a time before the time of day",
+			rule:  "FREQ=DAILY;UNTIL=20240103T080000Z",
+			start: date(2024, 1, 1),
+			want:  []string{"2024-01-01", "2024-01-02"},
+		},
+		{
+			name:  "count of one is just the start",
+			rule:  "FREQ=WEEKLY;COUNT=1",
+			start: date(2024, 1, 1),
+			want:  []string{"2024-01-01"},
+		},
+		{
+			name:  "monthly on the 31st skips shorter months",
+			rule:  "FREQ=MONTHLY;COUNT=4",
+			start: date(2024, 1, 31),
+			want:  []string{"2024-01-31", "2024-03-31", "2024-05-31", "2024-07-31"},
+		},
+		{
+			name:  "last day of the month",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=4",
+			start: date(2024, 1, 31),
+			want:  []string{"2024-01-31", "2024-02-29", "2024-03-31", "2024-04-30"},
+		},
+		{
+			name:  "last day of february outside a leap year",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=2",
+			start: date(2023, 1, 31),
+			want:  []string{"2023-01-31", "2023-02-28"},
+		},
+		{
+			name:  "second tuesday of the month",
+			rule:  "FREQ=MONTHLY;BYDAY=2TU;COUNT=3",
+			start: date(2024, 1, 9),
+			want:  []string{"2024-01-09", "2024-02-13", "2024-03-12"},
+		},
+		{
+			name:  "last friday of the month",
+			rule:  "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3",
+			start: date(2024, 1, 26),
+			want:  []string{"2024-01-26", "2024-02-23", "2024-03-29"},
+		},
+		{
+			name:  "fifth monday only in months that have one",
+			rule:  "FREQ=MONTHLY;BYDAY=5MO;COUNT=3",
+			start: date(2024, 1, 29),
+			want:  []string{"2024-01-29", "2024-04-29", "2024-07-29"},
+		},
+		{
+			name:  "month days that are also a weekday",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=13;BYDAY=FR;COUNT=3",
+			start: date(2024, 9, 13),
+			want:  []string{"2024-09-13", "2024-12-13", "2025-06-13"},
+		},
+		{
+			name:  "rule that can never occur again",
+			rule:  "FREQ=MONTHLY;INTERVAL=12;BYMONTHDAY=30",
+			start: date(2024, 2, 1),
+			want:  []string{"2024-02-01"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := expand(t, tt.rule, tt.start, 20); !slices.Equal(got, tt.want) {
+				t.Errorf("occurrences = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIterateKeepsTimeOfDayInLocation(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data not available: %v", err)
+	}
+	r, err := Parse("FREQ=DAILY;COUNT=2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Daylight saving time starts between the two occurrences
+	it := r.Iterate(time.Date(2024, 3, 30, 9, 0, 0, 0, berlin))
+	it.Next()
+	next, ok := it.Next()
+	if !ok || next.Hour() != 9 || next.Day() != 31 {
+		t.Errorf("second occurrence = %v, %v; want 2024-03-31 09:00 in Berlin", next, ok)
+	}
+}
+
+func TestAfter(t *testing.T) {
+	r, err := Parse("FREQ=WEEKLY;BYDAY=MO,TH;COUNT=3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		t      time.Time
+		want   string
+		wantOK bool
+	}{
+		{name: "before the start", t: start.Add(-time.Hour), want: "2024-01-01", wantOK: true},
+		{name: "at the start", t: start, want: "2024-01-04", wantOK: true},
+		{name: "between occurrences", t: start.AddDate(0, 0, 5), want: "2024-01-08", wantOK: true},
+		{name: "after the last", t: start.AddDate(0, 0, 7), wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, ok := r.After(start, tt.t)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && next.Format("2006-01-02") != tt.want {
+				t.Errorf("After = %v, want %s", next, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		rule    string
+		want    string // canonical form, empty when parsing must fail
+		wantErr bool
+	}{
+		{rule: "RRULE:freq=weekly;byday=mo,we", want: "FREQ=WEEKLY;BYDAY=MO,WE"},
+		{rule: "FREQ=MONTHLY;BYDAY=+2TU,-1FR;INTERVAL=1", want: "FREQ=MONTHLY;BYDAY=2TU,-1FR"},
+		{rule: "FREQ=DAILY;UNTIL=20240103T080000Z;INTERVAL=3", want: "FREQ=DAILY;INTERVAL=3;UNTIL=20240103T080000Z"},
+		{rule: "FREQ=WEEKLY;WKST=SU;COUNT=4", want: "FREQ=WEEKLY;COUNT=4;WKST=SU"},
+		{rule: "", wantErr: true},
+		{rule: "INTERVAL=2", wantErr: true},
+		{rule: "FREQ=YEARLY", wantErr: true},
+		{rule: "FREQ=DAILY;FREQ=WEEKLY", wantErr: true},
+		{rule: "FREQ=DAILY;COUNT=2;UNTIL=20240101", wantErr: true},
+		{rule: "FREQ=DAILY;COUNT=0", wantErr: true},
+		{rule: "FREQ=DAILY;INTERVAL=1001", wantErr: true},
+		{rule: "FREQ=WEEKLY;BYDAY=1MO", wantErr: true},
+		{rule: "FREQ=WEEKLY;BYMONTHDAY=1", wantErr: true},
+		{rule: "FREQ=MONTHLY;BYDAY=6MO", wantErr: true},
+		{rule: "FREQ=MONTHLY;BYMONTHDAY=0", wantErr: true},
+		{rule: "FREQ=MONTHLY;BYMONTHDAY=32", wantErr: true},
+		{rule: "FREQ=DAILY;UNTIL=tomorrow", wantErr: true},
+		{rule: "FREQ=DAILY;BYHOUR=9", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			r, err := Parse(tt.rule)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Parse succeeded with %q, want an error", r)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := r.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
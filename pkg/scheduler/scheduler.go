@@ -0,0 +1,35 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Every runs fn once per interval until ctx is cancelled.
+// Errors are logged and do not stop the loop.
+// Returns an error without starting the job if the interval is not positive.
+func Every(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context) error) error {
+	if interval <= 0 {
+		return fmt.Errorf("job %s: interval must be positive, got %v", name, interval)
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := fn(ctx); err != nil {
+					log.Printf("[JOB %s] %v", name, err)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// maxResponseBody is how much of a response is kept for the delivery log
+const maxResponseBody = 4096
+
+var (
+	ErrUnsupportedURL = errors.New("webhook URL must be an absolute http or https URL")
+	ErrPrivateTarget  = errors.New("webhook URL points to a private or local address")
+)
+
+// cgnat is the carrier-grade NAT range, which net.IP.IsPrivate does not cover
+var cgnat = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// Client posts signed webhook requests. Unless private targets are allowed it refuses to
+// connect to loopback, private and link-local addresses, checked on every connection so
+// DNS changes cannot sneak requests into the internal network.
+type Client struct {
+	http         *http.Client
+	allowPrivate bool
+}
+
+func NewClient(timeout time.Duration, allowPrivate bool) *Client {
+	c := &Client{allowPrivate: allowPrivate}
+	dialer := &net.Dialer{Timeout: timeout, Control: c.checkConn}
+	c.http = &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   timeout,
+			ResponseHeaderTimeout: timeout,
+			MaxIdleConnsPerHost:   2,
+		},
+		// A redirect is treated as a failed delivery rather than followed somewhere unchecked
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	return c
+}
+
+// CheckURL validates a webhook URL before it is saved
+func (c *Client) CheckURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return ErrUnsupportedURL
+	}
+	if c.allowPrivate {
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil {
+		return fmt.Errorf("cannot resolve webhook host %s", u.Hostname())
+	}
+	for _, addr := range addrs {
+		if isPrivate(addr.IP) {
+			return ErrPrivateTarget
+		}
+	}
+	return nil
+}
+
+// Send posts payload to url with the event, delivery and signature headers
+func (c *Client) Send(ctx context.Context, url, secret, deliveryID, eventType string, payload []byte) (int, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Kairo-Webhooks/1.0")
+	req.Header.Set(EventHeader, eventType)
+	req.Header.Set(DeliveryHeader, deliveryID)
+	req.Header.Set(SignatureHeader, Sign(secret, time.Now(), payload))
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	return resp.StatusCode, string(body), nil
+}
+
+func (c *Client) checkConn(network, address string, _ syscall.RawConn) error {
+	if c.allowPrivate {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || isPrivate(ip) {
+		return ErrPrivateTarget
+	}
+	return nil
+}
+
+func isPrivate(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast() ||
+		cgnat.Contains(ip)
+}
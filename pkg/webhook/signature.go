@@ -0,0 +1,65 @@
+// Package webhook signs and sends webhook requests.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers sent with every webhook request
+const (
+	SignatureHeader = "X-Kairo-Signature"
+	EventHeader     = "X-Kairo-Event"
+	DeliveryHeader  = "X-Kairo-Delivery"
+)
+
+var (
+	ErrInvalidSignature = errors.New("webhook: invalid signature")
+	ErrExpiredSignature = errors.New("webhook: signature timestamp outside tolerance")
+)
+
+// Sign returns the signature header for a payload sent at timestamp:
+// "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<payload>">".
+// Receivers recompute the HMAC with their secret and reject stale timestamps to stop replays.
+func Sign(secret string, timestamp time.Time, payload []byte) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+	return "t=" + t + ",v1=" + signature(secret, t, payload)
+}
+
+// Verify checks a signature header produced by Sign
+func Verify(secret, header string, payload []byte, now time.Time, tolerance time.Duration) error {
+	var t, v1 string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			t = value
+		case "v1":
+			v1 = value
+		}
+	}
+	unix, err := strconv.ParseInt(t, 10, 64)
+	if err != nil || v1 == "" {
+		return ErrInvalidSignature
+	}
+	if age := now.Sub(time.Unix(unix, 0)); age > tolerance || age < -tolerance {
+		return ErrExpiredSignature
+	}
+	if !hmac.Equal([]byte(v1), []byte(signature(secret, t, payload))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func signature(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}